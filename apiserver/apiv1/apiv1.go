@@ -1,29 +1,57 @@
 package apiv1
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
 	"image/gif"
 	"image/jpeg"
 	"image/png"
+	"io"
 	"mime"
 	"net/http"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/chai2010/webp"
-	"github.com/emersion/go-vcard"
 	"github.com/labstack/echo/v4"
 	"github.com/whitekid/echox"
 	"github.com/whitekid/goxp"
-	"github.com/whitekid/goxp/request"
+	"github.com/whitekid/goxp/fx"
+	"github.com/whitekid/goxp/validate"
 
-	"qrcodeapi/pkg/ical"
 	"qrcodeapi/pkg/qrcode"
+	"qrcodeapi/pkg/store"
 )
 
-type APIv1 struct{}
+type APIv1 struct {
+	store   store.Interface
+	baseURL string
+}
 
 var _ echox.Router = (*APIv1)(nil)
 
-func NewAPIv1() echox.Router { return &APIv1{} }
+// Option option of APIv1
+type Option func(*APIv1)
+
+// WithStore store of hosted files; hosted mode requires the store and base URL
+func WithStore(s store.Interface) Option { return func(api *APIv1) { api.store = s } }
+
+// WithBaseURL public base URL of hosted file links; ex. https://qr.example.com
+func WithBaseURL(baseURL string) Option {
+	return func(api *APIv1) { api.baseURL = strings.TrimSuffix(baseURL, "/") }
+}
+
+func NewAPIv1(opts ...Option) echox.Router {
+	api := &APIv1{}
+	for _, opt := range opts {
+		opt(api)
+	}
+
+	return api
+}
 
 func (api *APIv1) Name() string { return "api v1" }
 func (api *APIv1) Path() string { return "/api/v1" }
@@ -31,8 +59,16 @@ func (api *APIv1) Path() string { return "/api/v1" }
 func (api *APIv1) Route(g *echo.Group) {
 	g.GET("/qrcode", api.handleGenerate)
 	g.GET("/contact", api.handleContact)
+	g.POST("/contact", api.handleContact)
 	g.POST("/vcard", api.handleContactVCard)
 	g.POST("/vevent", api.handleVEvent)
+	g.POST("/event", api.handleEvent)
+	g.POST("/vevent/occurrences", api.handleVEventOccurrences)
+	g.POST("/vtodo", api.handleVTodo)
+	g.POST("/vjournal", api.handleVJournal)
+	g.GET("/emvco", api.handleEMVCo)
+	g.GET("/crypto", api.handleCrypto)
+	g.GET("/otp", api.handleOTP)
 }
 
 type RenderRequest struct {
@@ -54,21 +90,71 @@ func (api *APIv1) renderQRCode(c echo.Context, in *qrcode.QR) error {
 		return err
 	}
 
-	accepts := strings.Split(strings.ToLower(req.ImageType), ",")
+	encode, contentType, err := imageEncoder(req.ImageType)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, contentType)
+	return encode(c.Response().Writer, img)
+}
+
+// imageEncoder returns image encoder and its content type for accept header
+func imageEncoder(accept string) (func(io.Writer, image.Image) error, string, error) {
+	accepts := strings.Split(strings.ToLower(accept), ",")
 	for _, accept := range accepts {
 		switch strings.ToLower(accept) {
 		case "image/jpeg", "image/jpg":
-			return jpeg.Encode(c.Response().Writer, img, nil)
+			return func(w io.Writer, img image.Image) error { return jpeg.Encode(w, img, nil) }, "image/jpeg", nil
 		case "image/gif":
-			return gif.Encode(c.Response().Writer, img, nil)
+			return func(w io.Writer, img image.Image) error { return gif.Encode(w, img, nil) }, "image/gif", nil
 		case "image/webp":
-			return webp.Encode(c.Response().Writer, img, nil)
+			return func(w io.Writer, img image.Image) error { return webp.Encode(w, img, nil) }, "image/webp", nil
 		case "text/html", "", "*/*", "image/*", "image/png":
-			return png.Encode(c.Response().Writer, img)
+			return png.Encode, "image/png", nil
 		}
 	}
 
-	return echo.ErrUnsupportedMediaType
+	return nil, "", echo.ErrUnsupportedMediaType
+}
+
+// QRCodeEnvelope QRCode image with additional values, for the response that has generated values
+type QRCodeEnvelope struct {
+	ContentType string `json:"content_type"`
+	Image       []byte `json:"image"` // base64 encoded
+	Content     string `json:"content"`
+	Secret      string `json:"secret,omitempty"`
+}
+
+// renderEnvelope render QRCode as JSON envelope; image type is chosen by accept header except application/json
+func (api *APIv1) renderEnvelope(c echo.Context, in *qrcode.QR, envelope *QRCodeEnvelope) error {
+	img, err := in.Render(
+		goxp.ParseIntDef(c.QueryParam("w"), 200, 21, 200),
+		goxp.ParseIntDef(c.QueryParam("h"), 200, 21, 200))
+	if err != nil {
+		return err
+	}
+
+	accepts := fx.Filter(strings.Split(c.Request().Header.Get(echo.HeaderAccept), ","), func(accept string) bool {
+		mediaType, _, _ := mime.ParseMediaType(accept)
+		return mediaType != echo.MIMEApplicationJSON
+	})
+
+	encode, contentType, err := imageEncoder(strings.Join(accepts, ","))
+	if err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := encode(buf, img); err != nil {
+		return err
+	}
+
+	envelope.ContentType = contentType
+	envelope.Image = buf.Bytes()
+	envelope.Content = in.Content
+
+	return c.JSON(http.StatusOK, envelope)
 }
 
 func (api *APIv1) handleGenerate(c echo.Context) error {
@@ -148,6 +234,10 @@ func (api *APIv1) handleContact(c echo.Context) error {
 		FirstName  string `query:"name[first]"`
 		LastName   string `query:"name[last]"`
 		MiddleName string `query:"name[middle]"`
+		PrefixName string `query:"name[prefix]"`
+		SuffixName string `query:"name[suffix]"`
+		Formatted  string `query:"name[formatted]"`
+		NickName   string `query:"nickname"`
 
 		Company    string `query:"company"`
 		Department string `query:"department"`
@@ -183,17 +273,57 @@ func (api *APIv1) handleContact(c echo.Context) error {
 			Street2         string `query:"addr[work][street2]"`
 		} `validate:"dive"`
 
+		URL     string `query:"url"`
+		URLHome string `query:"url[home]"`
+		URLWork string `query:"url[work]"`
+
 		Note string `query:"note"`
+
+		Photo       string `query:"photo"`
+		PhotoBudget int    `query:"photo[budget]"`
+		Logo        string `query:"logo"`
+		LogoBudget  int    `query:"logo[budget]"`
+
+		Format  string `query:"format" validate:"omitempty,oneof=mecard vcard21 vcard3 vcard4"`
+		Version string `query:"version" validate:"omitempty,oneof=2.1 3.0 4.0"` // vcard version, same as format=vcardXX
 	}{}
 
-	if err := echox.Bind(c, req); err != nil {
+	photo, logo := []byte(nil), []byte(nil)
+	if c.Request().Method == http.MethodPost {
+		// NOTE c.Bind()는 Post에서 query를 bind하지 않음
+		if err := bindQuery(c, req); err != nil {
+			return err
+		}
+
+		c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, maxMultipartSize)
+
+		var err error
+		if photo, err = formFile(c, "photo"); err != nil {
+			return err
+		}
+		if logo, err = formFile(c, "logo"); err != nil {
+			return err
+		}
+	} else if err := echox.Bind(c, req); err != nil {
 		return err
 	}
 
-	qr, err := qrcode.Contact(&qrcode.Card{
-		FirstName:  req.FirstName,
-		LastName:   req.LastName,
-		MiddleName: req.MiddleName,
+	format := req.Format
+	if req.Version != "" {
+		if format != "" && format != vcardFormats[req.Version] {
+			return echo.NewHTTPError(http.StatusBadRequest, "format and version mismatch")
+		}
+		format = vcardFormats[req.Version]
+	}
+
+	qr, err := qrcode.ContactAs(&qrcode.Card{
+		FirstName:     req.FirstName,
+		LastName:      req.LastName,
+		MiddleName:    req.MiddleName,
+		PrefixName:    req.PrefixName,
+		SuffixName:    req.SuffixName,
+		FormattedName: req.Formatted,
+		NickName:      req.NickName,
 
 		Company:    req.Company,
 		Department: req.Department,
@@ -229,54 +359,131 @@ func (api *APIv1) handleContact(c echo.Context) error {
 			Street2:         req.WorkAddr.Street2,
 		},
 
+		Homepage:     req.URL,
+		HomeHomepage: req.URLHome,
+		WorkHomepage: req.URLWork,
+
 		Note: req.Note,
-	})
+
+		SocialProfiles: socialProfiles(c),
+
+		Photo: qrcode.CardImage{URL: req.Photo, Data: photo, Budget: req.PhotoBudget},
+		Logo:  qrcode.CardImage{URL: req.Logo, Data: logo, Budget: req.LogoBudget},
+	}, format)
 	if err != nil {
-		return err
+		var capacityErr *qrcode.CapacityError
+		if errors.As(err, &capacityErr) {
+			return echo.NewHTTPError(http.StatusRequestEntityTooLarge, err.Error()+"; structured append is not supported, use photo url instead of upload")
+		}
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
 	return api.renderQRCode(c, qr)
 }
 
-const (
-	mimeVCard  = "text/vcard"
-	mimeVEvent = "text/vevent"
-)
+var vcardFormats = map[string]string{
+	qrcode.VCardVersion21: qrcode.FormatVCard21,
+	qrcode.VCardVersion30: qrcode.FormatVCard3,
+	qrcode.VCardVersion40: qrcode.FormatVCard4,
+}
 
-func (api *APIv1) handleContactVCard(c echo.Context) error {
-	if mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(request.HeaderContentType)); mediaType != mimeVCard {
-		return echo.NewHTTPError(http.StatusBadRequest)
+// bindQuery bind query params for any method and validate
+func bindQuery(c echo.Context, v any) error {
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, v); err != nil {
+		return err
 	}
 
-	card, err := vcard.NewDecoder(c.Request().Body).Decode()
-	if err != nil {
+	if err := validate.Struct(v); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
-	defer c.Request().Body.Close()
 
-	qr, err := qrcode.VCard(card)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	return nil
+}
+
+// maxBodySize max size of request body of vcard, calendar and JSON
+const maxBodySize = 1 << 20
+
+// limitBody limit request body to maxBodySize; reading more fails with *http.MaxBytesError
+func limitBody(c echo.Context) io.ReadCloser {
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, maxBodySize)
+	return c.Request().Body
+}
+
+// badRequest returns 400 error, or 413 if request body is too large
+func badRequest(err error) error {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, err.Error())
 	}
+	return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+}
 
-	return api.renderQRCode(c, qr)
+// encodeError returns 413 error if the content does not fit in QR code, or 400 error
+func encodeError(err error) error {
+	var capacityErr *qrcode.CapacityError
+	if errors.As(err, &capacityErr) {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, err.Error())
+	}
+	return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 }
 
-func (api *APIv1) handleVEvent(c echo.Context) error {
-	if mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(request.HeaderContentType)); mediaType != mimeVEvent {
-		return echo.NewHTTPError(http.StatusBadRequest)
+// maxUploadSize max size of uploaded file
+const maxUploadSize = 5 << 20
+
+// maxMultipartSize max size of multipart request body; photo and logo with form fields
+const maxMultipartSize = 2*maxUploadSize + maxBodySize
+
+// formFile returns uploaded multipart file; nil if not exists
+func formFile(c echo.Context, name string) ([]byte, error) {
+	fh, err := c.FormFile(name)
+	if err != nil {
+		if errors.Is(err, http.ErrMissingFile) || errors.Is(err, http.ErrNotMultipart) {
+			return nil, nil
+		}
+		return nil, badRequest(err)
 	}
 
-	evt := new(ical.VEvent)
-	if err := ical.NewEventDecoder(c.Request().Body).Decode(evt); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	if fh.Size > maxUploadSize {
+		return nil, echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("%s: file too large: %d bytes", name, fh.Size))
 	}
-	defer c.Request().Body.Close()
 
-	qr, err := qrcode.VEvent(evt)
+	f, err := fh.Open()
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer f.Close()
 
-	return api.renderQRCode(c, qr)
+	return io.ReadAll(io.LimitReader(f, maxUploadSize))
 }
+
+// social[twitter]=id
+var reSocialProfile = regexp.MustCompile(`^social\[([^\]]+)\]$`)
+
+// socialProfiles collect social profiles from query, sorted by type
+func socialProfiles(c echo.Context) []qrcode.SocialProfile {
+	profiles := []qrcode.SocialProfile{}
+	for key, values := range c.QueryParams() {
+		m := reSocialProfile.FindStringSubmatch(key)
+		if m == nil {
+			continue
+		}
+
+		for _, value := range values {
+			profiles = append(profiles, qrcode.SocialProfile{Type: m[1], ID: value})
+		}
+	}
+
+	sort.SliceStable(profiles, func(i, j int) bool { return profiles[i].Type < profiles[j].Type })
+	return profiles
+}
+
+const (
+	mimeVCard     = "text/vcard"
+	mimeJCard     = "application/vcard+json"
+	mimeVEvent    = "text/vevent"
+	mimeVTodo     = "text/vtodo"
+	mimeVJournal  = "text/vjournal"
+	mimeVCalendar = "text/calendar"
+	mimeJCal      = "application/calendar+json"
+	mimeXCal      = "application/calendar+xml"
+)
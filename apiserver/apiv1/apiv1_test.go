@@ -1,20 +1,33 @@
 package apiv1
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"encoding/json"
 	"image"
+	"image/png"
+	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 
+	"github.com/emersion/go-vcard"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/require"
+	"github.com/whitekid/echox"
+	"github.com/whitekid/goxp/fx"
 	"github.com/whitekid/goxp/request"
 
 	"qrcodeapi/pkg/ical"
 	"qrcodeapi/pkg/qrcode"
+	"qrcodeapi/pkg/store"
 	"qrcodeapi/pkg/testutils.go"
 )
 
@@ -286,6 +299,224 @@ END:VCARD`
 	require.Equal(t, strings.ReplaceAll(content, "\n", "\r\n"), got)
 }
 
+func TestContactJCard(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ts := testutils.NewTestServer(ctx, NewAPIv1())
+
+	type args struct {
+		contentType string
+		content     string
+	}
+	tests := [...]struct {
+		name       string
+		args       args
+		wantStatus int
+		want       string
+	}{
+		{"jcard", args{mimeJCard, `["vcard", [
+			["version", {}, "text", "4.0"],
+			["n", {}, "text", ["lastname", "firstname", "", "", ""]],
+			["tel", {"type": "cell"}, "text", "010-1234-5678"]
+		]]`}, http.StatusOK, "BEGIN:VCARD\r\nVERSION:4.0\r\nN:lastname;firstname;;;\r\nTEL;TYPE=cell:010-1234-5678\r\nEND:VCARD"},
+		{"invalid jcard", args{mimeJCard, `{"n": "lastname"}`}, http.StatusBadRequest, ""},
+		{"exceed capacity", args{mimeJCard, `["vcard", [
+			["version", {}, "text", "4.0"],
+			["fn", {}, "text", "John Doe"],
+			["note", {}, "text", "` + strings.Repeat("x", 2000) + `"]
+		]]`}, http.StatusRequestEntityTooLarge, ""},
+		{"unsupported content type", args{"application/json", `["vcard", []]`}, http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := request.Post("%s/api/v1/vcard", ts.URL).
+				ContentType(tt.args.contentType).
+				Body(strings.NewReader(tt.args.content)).
+				Do(ctx)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equalf(t, tt.wantStatus, resp.StatusCode, "status=%d, wantStatus=%d", resp.StatusCode, tt.wantStatus)
+			if err := resp.Success(); err != nil {
+				return
+			}
+
+			img, _, err := image.Decode(resp.Body)
+			require.NoError(t, err)
+
+			got, err := qrcode.Decode(img)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestContactVCFBatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ts := testutils.NewTestServer(ctx, NewAPIv1())
+
+	type args struct {
+		content string
+	}
+	tests := [...]struct {
+		name       string
+		args       args
+		wantStatus int
+		wantFiles  []string
+		wantErrors []int
+	}{
+		{"batch", args{`BEGIN:VCARD
+VERSION:4.0
+FN:John Doe
+N:Doe;John;;;
+END:VCARD
+BEGIN:VCARD
+VERSION:4.0
+FN:John Doe
+N:Doe;John;;;
+END:VCARD
+BEGIN:VCARD
+VERSION:3.0
+FN:A/B
+END:VCARD
+BEGIN:VCARD
+VERSION:4.0
+N:Noname;;;;
+END:VCARD`}, http.StatusOK, []string{"John Doe.png", "John Doe (2).png", "A_B.png", "card-4.png"}, nil},
+		{"partial error", args{`BEGIN:VCARD
+VERSION:4.0
+FN:John Doe
+END:VCARD
+BEGIN:VCARD
+FN:Missing Version
+END:VCARD
+NOTE:outside of card
+BEGIN:VCARD
+VERSION:4.0
+FN:Jane Doe
+END:VCARD`}, http.StatusOK, []string{"John Doe.png", "Jane Doe.png", "errors.json"}, []int{2, 3}},
+		{"all failed", args{`BEGIN:VCARD
+FN:John Doe
+END:VCARD
+BEGIN:VCARD
+FN:Jane Doe
+END:VCARD`}, http.StatusBadRequest, nil, nil},
+		{"exceed capacity", args{"BEGIN:VCARD\nVERSION:4.0\nFN:John Doe\nNOTE:" + strings.Repeat("x", 2000) + "\nEND:VCARD"}, http.StatusRequestEntityTooLarge, nil, nil},
+		{"too large", args{"BEGIN:VCARD\nVERSION:4.0\nFN:John Doe\n" + strings.Repeat("NOTE:"+strings.Repeat("x", 1000)+"\n", 1100) + "END:VCARD"},
+			http.StatusRequestEntityTooLarge, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := request.Post("%s/api/v1/vcard", ts.URL).
+				ContentType(mimeVCard).
+				Body(strings.NewReader(tt.args.content)).
+				Do(ctx)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equalf(t, tt.wantStatus, resp.StatusCode, "status=%d, wantStatus=%d", resp.StatusCode, tt.wantStatus)
+			if err := resp.Success(); err != nil {
+				return
+			}
+			require.Equal(t, "application/zip", resp.Header.Get(request.HeaderContentType))
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+			require.NoError(t, err)
+
+			files := []string{}
+			for _, f := range archive.File {
+				files = append(files, f.Name)
+
+				r, err := f.Open()
+				require.NoError(t, err)
+				defer r.Close()
+
+				if f.Name == "errors.json" {
+					errs := []vcardError{}
+					require.NoError(t, json.NewDecoder(r).Decode(&errs))
+					require.Equal(t, tt.wantErrors, fx.Map(errs, func(e vcardError) int { return e.Index }))
+					continue
+				}
+
+				img, _, err := image.Decode(r)
+				require.NoError(t, err)
+				got, err := qrcode.Decode(img)
+				require.NoError(t, err)
+				require.True(t, strings.HasPrefix(got, "BEGIN:VCARD\r\n"), got)
+			}
+			require.Equal(t, tt.wantFiles, files)
+		})
+	}
+}
+
+// read error is not a broken card, or decoding never ends
+func TestDecodeVCardsReadError(t *testing.T) {
+	r := io.MultiReader(strings.NewReader("BEGIN:VCARD\nVERSION:4.0\nFN:John Doe\n"), iotest.ErrReader(io.ErrUnexpectedEOF))
+
+	entries, err := decodeVCards(r, "")
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	require.Nil(t, entries)
+}
+
+func TestContactVCFVersion(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ts := testutils.NewTestServer(ctx, NewAPIv1())
+
+	content := `BEGIN:VCARD
+VERSION:4.0
+N:lastname;firstname;;;
+TEL;TYPE=cell;PREF=1:010-1234-5678
+END:VCARD`
+
+	type args struct {
+		version string
+	}
+	tests := [...]struct {
+		name       string
+		args       args
+		wantStatus int
+		want       string
+	}{
+		{"as is", args{""}, http.StatusOK, "TEL;TYPE=cell;PREF=1:010-1234-5678"},
+		{"3.0", args{"3.0"}, http.StatusOK, "TEL;TYPE=CELL,PREF:010-1234-5678"},
+		{"2.1", args{"2.1"}, http.StatusOK, "TEL;CELL;PREF:010-1234-5678"},
+		{"unknown", args{"5.0"}, http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := request.Post("%s/api/v1/vcard", ts.URL).
+				ContentType(mimeVCard).
+				Body(strings.NewReader(content))
+			if tt.args.version != "" {
+				req = req.Query("version", tt.args.version)
+			}
+
+			resp, err := req.Do(ctx)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equalf(t, tt.wantStatus, resp.StatusCode, "status=%d, wantStatus=%d", resp.StatusCode, tt.wantStatus)
+			if err := resp.Success(); err != nil {
+				return
+			}
+
+			img, _, err := image.Decode(resp.Body)
+			require.NoError(t, err)
+
+			got, err := qrcode.Decode(img)
+			require.NoError(t, err)
+			require.Contains(t, got, "\r\n"+tt.want+"\r\n")
+		})
+	}
+}
+
 // VEvent는 QR 스캐너에서 안되네
 func TestVEvent(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
@@ -317,11 +548,1116 @@ END:VEVENT`
 	err = ical.NewEventDecoder(strings.NewReader(got)).Decode(evt)
 	require.NoError(t, err)
 
-	require.Equal(t, "Summer+Vacation!", evt.Summary)
+	require.Equal(t, "Summer+Vacation!", evt.Summary.Value)
 	require.Equal(t, ical.DateTime{
 		Time: time.Date(2018, 6, 1, 7, 0, 0, 0, time.UTC),
 	}, evt.DtStart)
 	require.Equal(t, ical.DateTime{
 		Time: time.Date(2018, 8, 31, 7, 0, 0, 0, time.UTC),
 	}, evt.DtEnd)
+
+	resp, err = request.Post("%s/api/v1/vevent", ts.URL).
+		ContentType(mimeVEvent).
+		Body(strings.NewReader(strings.Replace(content, "END:VEVENT", strings.Repeat("X-PAD:"+strings.Repeat("x", 1000)+"\n", 1100)+"END:VEVENT", 1))).
+		Do(ctx)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+}
+
+func TestVCalendar(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ts := testutils.NewTestServer(ctx, NewAPIv1())
+
+	event := `BEGIN:VEVENT
+SUMMARY:Summer+Vacation!
+DTSTART:20180601T070000Z
+DTEND:20180831T070000Z
+END:VEVENT`
+
+	type args struct {
+		contentType string
+		content     string
+		wrap        string
+	}
+	tests := [...]struct {
+		name       string
+		args       args
+		wantStatus int
+		wantEvents int
+	}{
+		{"wrap vevent", args{mimeVEvent, event, "true"}, http.StatusOK, 1},
+		{"calendar", args{mimeVCalendar, "BEGIN:VCALENDAR\nVERSION:2.0\nPRODID:-//test//EN\n" + event + "\n" + event + "\nEND:VCALENDAR", ""}, http.StatusOK, 2},
+		{"calendar without event", args{mimeVCalendar, "BEGIN:VCALENDAR\nVERSION:2.0\nEND:VCALENDAR", ""}, http.StatusBadRequest, 0},
+		{"invalid calendar", args{mimeVCalendar, event, ""}, http.StatusBadRequest, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := request.Post("%s/api/v1/vevent", ts.URL).
+				ContentType(tt.args.contentType).
+				Body(strings.NewReader(tt.args.content))
+			if tt.args.wrap != "" {
+				req = req.Query("wrap", tt.args.wrap)
+			}
+
+			resp, err := req.Do(ctx)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equalf(t, tt.wantStatus, resp.StatusCode, "status=%d, wantStatus=%d", resp.StatusCode, tt.wantStatus)
+			if err := resp.Success(); err != nil {
+				return
+			}
+
+			img, _, err := image.Decode(resp.Body)
+			require.NoError(t, err)
+			got, err := qrcode.Decode(img)
+			require.NoError(t, err)
+
+			cal := new(ical.VCalendar)
+			require.NoError(t, ical.NewCalendarDecoder(strings.NewReader(got)).Decode(cal))
+			require.Len(t, cal.Events, tt.wantEvents)
+			require.Equal(t, "Summer+Vacation!", cal.Events[0].Summary.Value)
+		})
+	}
+}
+
+func TestVEventDecode(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ts := testutils.NewTestServer(ctx, NewAPIv1())
+
+	event := `BEGIN:VEVENT
+UID:19970901T130000Z-123401@example.com
+DTSTAMP:19970901T130000Z
+DTSTART:19970903T163000Z
+X-WR-CALNAME:Work
+FOO:bar
+DTEND:yesterday
+END:VEVENT`
+
+	type args struct {
+		decode string
+	}
+	tests := [...]struct {
+		name       string
+		args       args
+		wantStatus int
+		wantErrors []ical.DecodeError
+	}{
+		{"default", args{""}, http.StatusBadRequest, []ical.DecodeError{
+			{Line: 7, Column: 7, Property: "DTEND"},
+		}},
+		{"strict", args{"strict"}, http.StatusBadRequest, []ical.DecodeError{
+			{Line: 6, Column: 1, Property: "FOO"},
+			{Line: 7, Column: 7, Property: "DTEND"},
+		}},
+		{"lenient", args{"lenient"}, http.StatusOK, nil},
+		{"invalid mode", args{"loose"}, http.StatusBadRequest, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := request.Post("%s/api/v1/vevent", ts.URL).
+				ContentType(mimeVEvent).
+				Body(strings.NewReader(event))
+			if tt.args.decode != "" {
+				req = req.Query("decode", tt.args.decode)
+			}
+
+			resp, err := req.Do(ctx)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equalf(t, tt.wantStatus, resp.StatusCode, "status=%d, wantStatus=%d", resp.StatusCode, tt.wantStatus)
+			if tt.wantErrors == nil {
+				return
+			}
+
+			got := []ical.DecodeError{}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+			for i := range got {
+				require.NotEmpty(t, got[i].Message)
+				got[i].Message = ""
+			}
+			require.Equal(t, tt.wantErrors, got)
+		})
+	}
+}
+
+func TestVEventJCalXCal(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ts := testutils.NewTestServer(ctx, NewAPIv1())
+
+	jcal := `["vcalendar", [["version", {}, "text", "2.0"], ["prodid", {}, "text", "-//test//EN"]], [
+  ["vevent", [
+    ["summary", {}, "text", "Summer+Vacation!"],
+    ["dtstart", {"tzid": "Asia/Seoul"}, "date-time", "2018-06-01T07:00:00"],
+    ["dtend", {"tzid": "Asia/Seoul"}, "date-time", "2018-08-31T07:00:00"]
+  ], []]
+]]`
+	xcal := `<?xml version="1.0" encoding="utf-8"?>
+<icalendar xmlns="urn:ietf:params:xml:ns:icalendar-2.0"><vcalendar>
+  <properties><version><text>2.0</text></version><prodid><text>-//test//EN</text></prodid></properties>
+  <components><vevent><properties>
+    <summary><text>Summer+Vacation!</text></summary>
+    <dtstart><parameters><tzid><text>Asia/Seoul</text></tzid></parameters><date-time>2018-06-01T07:00:00</date-time></dtstart>
+    <dtend><parameters><tzid><text>Asia/Seoul</text></tzid></parameters><date-time>2018-08-31T07:00:00</date-time></dtend>
+  </properties></vevent></components>
+</vcalendar></icalendar>`
+
+	type args struct {
+		contentType string
+		content     string
+		decode      string
+	}
+	tests := [...]struct {
+		name       string
+		args       args
+		wantStatus int
+		wantErrors []ical.DecodeError
+	}{
+		{"jcal", args{mimeJCal, jcal, ""}, http.StatusOK, nil},
+		{"xcal", args{mimeXCal, xcal, ""}, http.StatusOK, nil},
+		{"strict jcal", args{mimeJCal, jcal, "strict"}, http.StatusBadRequest, []ical.DecodeError{{Property: "UID"}, {Property: "DTSTAMP"}}},
+		{"jcal without event", args{mimeJCal, `["vcalendar", [["version", {}, "text", "2.0"]], []]`, ""}, http.StatusBadRequest, nil},
+		{"invalid jcal", args{mimeJCal, `{"summary": "Summer+Vacation!"}`, ""}, http.StatusBadRequest, nil},
+		{"invalid xcal", args{mimeXCal, jcal, ""}, http.StatusBadRequest, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := request.Post("%s/api/v1/vevent", ts.URL).
+				ContentType(tt.args.contentType).
+				Body(strings.NewReader(tt.args.content))
+			if tt.args.decode != "" {
+				req = req.Query("decode", tt.args.decode)
+			}
+
+			resp, err := req.Do(ctx)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equalf(t, tt.wantStatus, resp.StatusCode, "status=%d, wantStatus=%d", resp.StatusCode, tt.wantStatus)
+			if tt.wantErrors != nil {
+				got := []ical.DecodeError{}
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+				for i := range got {
+					require.NotEmpty(t, got[i].Message)
+					got[i].Message = ""
+				}
+				require.Equal(t, tt.wantErrors, got)
+				return
+			}
+			if err := resp.Success(); err != nil {
+				return
+			}
+
+			img, _, err := image.Decode(resp.Body)
+			require.NoError(t, err)
+			got, err := qrcode.Decode(img)
+			require.NoError(t, err)
+
+			cal := new(ical.VCalendar)
+			require.NoError(t, ical.NewCalendarDecoder(strings.NewReader(got)).Decode(cal))
+			require.Len(t, cal.Events, 1)
+			require.Equal(t, "Summer+Vacation!", cal.Events[0].Summary.Value)
+			require.Equal(t, "Asia/Seoul", cal.Events[0].DtStart.TZID())
+		})
+	}
+}
+
+func TestVEventMode(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ts := testutils.NewTestServer(ctx, NewAPIv1())
+
+	content := `BEGIN:VEVENT
+UID:1@example.com
+DTSTAMP:20180501T000000Z
+SUMMARY:Summer+Vacation!
+DTSTART:20180601T070000Z
+DTEND:20180831T070000Z
+END:VEVENT`
+
+	type args struct {
+		mode string
+	}
+	tests := [...]struct {
+		name       string
+		args       args
+		wantStatus int
+		wantPrefix string
+	}{
+		{"vevent", args{"vevent"}, http.StatusOK, "BEGIN:VEVENT"},
+		{"google", args{"google"}, http.StatusOK, "https://calendar.google.com/calendar/render?"},
+		{"outlook", args{"outlook"}, http.StatusOK, "https://outlook.live.com/calendar/0/deeplink/compose?"},
+		{"yahoo", args{"yahoo"}, http.StatusOK, "https://calendar.yahoo.com/?"},
+		{"unknown", args{"icloud"}, http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := request.Post("%s/api/v1/vevent", ts.URL).
+				ContentType(mimeVEvent).
+				Query("mode", tt.args.mode).
+				Body(strings.NewReader(content)).
+				Do(ctx)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equalf(t, tt.wantStatus, resp.StatusCode, "status=%d, wantStatus=%d", resp.StatusCode, tt.wantStatus)
+			if err := resp.Success(); err != nil {
+				return
+			}
+
+			img, _, err := image.Decode(resp.Body)
+			require.NoError(t, err)
+			got, err := qrcode.Decode(img)
+			require.NoError(t, err)
+			require.Truef(t, strings.HasPrefix(got, tt.wantPrefix), "got %s, want prefix %s", got, tt.wantPrefix)
+		})
+	}
+}
+
+func TestHosted(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	files, err := store.New(filepath.Join(t.TempDir(), "qrcodeapi.db"))
+	require.NoError(t, err)
+	defer files.Close()
+
+	api := NewAPIv1(WithStore(files)).(*APIv1)
+	ts := testutils.NewTestServer(ctx, api, NewHosted(files))
+	WithBaseURL(ts.URL)(api) // base url is known after the server is started
+
+	type args struct {
+		path        string
+		contentType string
+		content     string
+		update      string
+		invalid     string
+	}
+	tests := [...]struct {
+		name            string
+		args            args
+		wantStatus      int
+		wantPath        string
+		wantContentType string
+		want            string // want line of the hosted file
+		wantUpdated     string // want line of the updated file
+	}{
+		{"event", args{"vevent", mimeVEvent, `BEGIN:VEVENT
+UID:1@example.com
+DTSTAMP:20180501T000000Z
+SUMMARY:Summer+Vacation!
+DTSTART:20180601T070000Z
+DTEND:20180831T070000Z
+END:VEVENT`, `BEGIN:VEVENT
+UID:1@example.com
+DTSTAMP:20180501T000000Z
+SUMMARY:Summer Vacation!
+DTSTART:20180601T070000Z
+DTEND:20180831T070000Z
+END:VEVENT`, "BEGIN:VEVENT\nDTSTART:invalid\nEND:VEVENT"}, http.StatusOK, `^/e/[\w-]{8}\.ics$`, mimeVCalendar, "SUMMARY:Summer+Vacation!", "SUMMARY:Summer Vacation!"},
+		{"contact", args{"vcard", mimeVCard, `BEGIN:VCARD
+VERSION:3.0
+FN:Jon Doe
+N:Doe;Jon;;;
+END:VCARD`, `BEGIN:VCARD
+VERSION:3.0
+FN:John Doe
+N:Doe;John;;;
+END:VCARD`, "FN:John Doe"}, http.StatusOK, `^/c/[\w-]{8}\.vcf$`, mimeVCard, "FN:Jon Doe", "FN:John Doe"},
+		{"invalid event", args{"vevent", mimeVEvent, "BEGIN:VEVENT\nDTSTART:invalid\nEND:VEVENT", "", ""}, http.StatusBadRequest, "", "", "", ""},
+		{"invalid contact", args{"vcard", mimeVCard, "FN:John Doe", "", ""}, http.StatusBadRequest, "", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := request.Post("%s/api/v1/%s", ts.URL, tt.args.path).
+				ContentType(tt.args.contentType).
+				Query("mode", "hosted").
+				Body(strings.NewReader(tt.args.content)).
+				Do(ctx)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equalf(t, tt.wantStatus, resp.StatusCode, "status=%d, wantStatus=%d", resp.StatusCode, tt.wantStatus)
+			if err := resp.Success(); err != nil {
+				return
+			}
+
+			envelope := new(QRCodeEnvelope)
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(envelope))
+			require.Len(t, envelope.Secret, 32)
+			require.Equal(t, "image/png", envelope.ContentType)
+
+			img, _, err := image.Decode(bytes.NewReader(envelope.Image))
+			require.NoError(t, err)
+			got, err := qrcode.Decode(img)
+			require.NoError(t, err)
+			require.Equal(t, envelope.Content, got)
+
+			link, ok := strings.CutPrefix(got, ts.URL)
+			require.Truef(t, ok, "got %s, want prefix %s", got, ts.URL)
+			require.Regexp(t, tt.wantPath, link)
+
+			hosted := func() string {
+				resp, err := request.Get(got).Do(ctx)
+				require.NoError(t, err)
+				require.NoError(t, resp.Success())
+				defer resp.Body.Close()
+
+				mediaType, _, _ := mime.ParseMediaType(resp.Header.Get(request.HeaderContentType))
+				require.Equal(t, tt.wantContentType, mediaType)
+
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				return string(body)
+			}
+			require.Contains(t, hosted(), tt.want)
+
+			for _, secret := range []string{"", "unknown", envelope.Secret + "0"} {
+				resp, err := request.Put(got).
+					ContentType(tt.args.contentType).
+					Header(echo.HeaderAuthorization, "Bearer "+secret).
+					Body(strings.NewReader(tt.args.update)).
+					Do(ctx)
+				require.NoError(t, err)
+				resp.Body.Close()
+				require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+			}
+			require.Contains(t, hosted(), tt.want)
+
+			resp, err = request.Put(got).
+				ContentType(tt.args.contentType).
+				Header(echo.HeaderAuthorization, "Bearer "+envelope.Secret).
+				Body(strings.NewReader(tt.args.invalid)).
+				Do(ctx)
+			require.NoError(t, err)
+			resp.Body.Close()
+			require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+			resp, err = request.Put(got).
+				ContentType(tt.args.contentType).
+				Header(echo.HeaderAuthorization, "Bearer "+envelope.Secret).
+				Body(strings.NewReader(strings.Repeat("X", maxBodySize+1))).
+				Do(ctx)
+			require.NoError(t, err)
+			resp.Body.Close()
+			require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+
+			resp, err = request.Put(got).
+				ContentType(tt.args.contentType).
+				Header(echo.HeaderAuthorization, "Bearer "+envelope.Secret).
+				Body(strings.NewReader(tt.args.update)).
+				Do(ctx)
+			require.NoError(t, err)
+			resp.Body.Close()
+			require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+			updated := hosted()
+			require.Contains(t, updated, tt.wantUpdated)
+			require.NotContains(t, updated, tt.want)
+		})
+	}
+
+	t.Run("base url", func(t *testing.T) {
+		ts := testutils.NewTestServer(ctx, NewAPIv1(WithStore(files), WithBaseURL("https://qr.example.com/")))
+
+		resp, err := request.Post("%s/api/v1/vevent", ts.URL).
+			ContentType(mimeVEvent).
+			Query("mode", "hosted").
+			Body(strings.NewReader(tests[0].args.content)).
+			Do(ctx)
+		require.NoError(t, err)
+		require.NoError(t, resp.Success())
+		defer resp.Body.Close()
+
+		envelope := new(QRCodeEnvelope)
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(envelope))
+		require.Regexp(t, `^https://qr\.example\.com/e/[\w-]{8}\.ics$`, envelope.Content)
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		for _, api := range []echox.Router{NewAPIv1(), NewAPIv1(WithStore(files)), NewAPIv1(WithBaseURL("https://qr.example.com"))} {
+			ts := testutils.NewTestServer(ctx, api)
+
+			resp, err := request.Post("%s/api/v1/vevent", ts.URL).
+				ContentType(mimeVEvent).
+				Query("mode", "hosted").
+				Body(strings.NewReader(tests[0].args.content)).
+				Do(ctx)
+			require.NoError(t, err)
+			resp.Body.Close()
+			require.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+		}
+	})
+
+	t.Run("quota exceeded", func(t *testing.T) {
+		files := store.NewMemory(store.WithMaxRecords(1))
+		ts := testutils.NewTestServer(ctx, NewAPIv1(WithStore(files), WithBaseURL("https://qr.example.com")))
+
+		for _, wantStatus := range []int{http.StatusOK, http.StatusTooManyRequests} {
+			resp, err := request.Post("%s/api/v1/vevent", ts.URL).
+				ContentType(mimeVEvent).
+				Query("mode", "hosted").
+				Body(strings.NewReader(tests[0].args.content)).
+				Do(ctx)
+			require.NoError(t, err)
+			resp.Body.Close()
+			require.Equal(t, wantStatus, resp.StatusCode)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		for _, path := range []string{"/e/unknown.ics", "/e/unknown", "/c/unknown.vcf", "/c/unknown.ics"} {
+			resp, err := request.Get("%s%s", ts.URL, path).Do(ctx)
+			require.NoError(t, err)
+			resp.Body.Close()
+			require.Equal(t, http.StatusNotFound, resp.StatusCode, path)
+		}
+	})
+}
+
+func TestEvent(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ts := testutils.NewTestServer(ctx, NewAPIv1())
+
+	type args struct {
+		contentType string
+		content     string
+		wrap        string
+	}
+	tests := [...]struct {
+		name         string
+		args         args
+		wantStatus   int
+		wantErrors   []qrcode.FieldError
+		wantContains []string
+	}{
+		{"event", args{echo.MIMEApplicationJSON, `{"title": "Summer Vacation!", "start": "2018-06-01T09:00", "end": "2018-06-01T10:00", "tz": "Asia/Seoul",
+  "location": "Seoul", "attendees": [{"email": "john@example.com", "name": "John", "rsvp": true}],
+  "recurrence": {"freq": "weekly", "count": 3}}`, ""},
+			http.StatusOK, nil, []string{"BEGIN:VEVENT\r\n", "DTSTART;TZID=Asia/Seoul:20180601T090000\r\n", "RRULE:FREQ=WEEKLY;COUNT=3\r\n", "LOCATION:Seoul\r\n"}},
+		{"all day wrapped", args{echo.MIMEApplicationJSON, `{"title": "Summer Vacation!", "start": "2018-06-01", "end": "2018-08-31", "allDay": true}`, "true"},
+			http.StatusOK, nil, []string{"BEGIN:VCALENDAR\r\n", "DTSTART;VALUE=DATE:20180601\r\n", "DTEND;VALUE=DATE:20180901\r\n"}},
+		{"invalid fields", args{echo.MIMEApplicationJSON, `{"start": "2018-06-01T09:00", "end": "2018-06-01T08:00", "attendees": [{"name": "John"}]}`, ""},
+			http.StatusBadRequest, []qrcode.FieldError{{Field: "title"}, {Field: "attendees[0].email"}, {Field: "end"}}, nil},
+		{"invalid json", args{echo.MIMEApplicationJSON, `{"title": 1}`, ""}, http.StatusBadRequest, nil, nil},
+		{"not json", args{mimeVEvent, "BEGIN:VEVENT\nEND:VEVENT", ""}, http.StatusBadRequest, nil, nil},
+		{"too large", args{echo.MIMEApplicationJSON, `{"title": "` + strings.Repeat("x", maxBodySize) + `"}`, ""}, http.StatusRequestEntityTooLarge, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := request.Post("%s/api/v1/event", ts.URL).
+				ContentType(tt.args.contentType).
+				Body(strings.NewReader(tt.args.content))
+			if tt.args.wrap != "" {
+				req = req.Query("wrap", tt.args.wrap)
+			}
+
+			resp, err := req.Do(ctx)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equalf(t, tt.wantStatus, resp.StatusCode, "status=%d, wantStatus=%d", resp.StatusCode, tt.wantStatus)
+			if tt.wantErrors != nil {
+				got := []qrcode.FieldError{}
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+				for i := range got {
+					require.NotEmpty(t, got[i].Message)
+					got[i].Message = ""
+				}
+				require.Equal(t, tt.wantErrors, got)
+				return
+			}
+			if err := resp.Success(); err != nil {
+				return
+			}
+
+			img, _, err := image.Decode(resp.Body)
+			require.NoError(t, err)
+			got, err := qrcode.Decode(img)
+			require.NoError(t, err)
+
+			require.Contains(t, got, "SUMMARY:Summer Vacation!\r\n")
+			require.Contains(t, got, "\r\nUID:")
+			require.Contains(t, got, "\r\nDTSTAMP:")
+			for _, want := range tt.wantContains {
+				require.Contains(t, got, want)
+			}
+		})
+	}
+}
+
+func TestVEventOccurrences(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ts := testutils.NewTestServer(ctx, NewAPIv1())
+
+	event := `BEGIN:VEVENT
+SUMMARY:Weekly meeting
+DTSTART;TZID=Asia/Seoul:20230102T100000
+DTEND;TZID=Asia/Seoul:20230102T110000
+RRULE:FREQ=WEEKLY;COUNT=5
+EXDATE;TZID=Asia/Seoul:20230116T100000
+END:VEVENT`
+
+	type occurrence struct {
+		Start string `json:"start"`
+		End   string `json:"end"`
+	}
+	type args struct {
+		contentType string
+		content     string
+		count       string
+		after       string
+	}
+	tests := [...]struct {
+		name       string
+		args       args
+		wantStatus int
+		want       []occurrence
+	}{
+		{"vevent", args{mimeVEvent, event, "3", "2023-01-01T00:00:00Z"}, http.StatusOK, []occurrence{
+			{"2023-01-02T10:00:00+09:00", "2023-01-02T11:00:00+09:00"},
+			{"2023-01-09T10:00:00+09:00", "2023-01-09T11:00:00+09:00"},
+			{"2023-01-23T10:00:00+09:00", "2023-01-23T11:00:00+09:00"},
+		}},
+		{"after", args{mimeVEvent, event, "", "2023-01-20T00:00:00Z"}, http.StatusOK, []occurrence{
+			{"2023-01-23T10:00:00+09:00", "2023-01-23T11:00:00+09:00"},
+			{"2023-01-30T10:00:00+09:00", "2023-01-30T11:00:00+09:00"},
+		}},
+		{"ended", args{mimeVEvent, event, "", "2024-01-01T00:00:00Z"}, http.StatusOK, []occurrence{}},
+		{"all day calendar", args{mimeVCalendar, "BEGIN:VCALENDAR\nVERSION:2.0\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20230101\nRRULE:FREQ=YEARLY\nEND:VEVENT\nEND:VCALENDAR", "2", "2023-01-01T00:00:00Z"}, http.StatusOK, []occurrence{
+			{"2023-01-01", "2023-01-02"},
+			{"2024-01-01", "2024-01-02"},
+		}},
+		{"sparse minutely", args{mimeVEvent, "BEGIN:VEVENT\nDTSTART:20230102T100000Z\nRRULE:FREQ=MINUTELY;BYMONTHDAY=1\nEND:VEVENT", "2", "2023-01-03T00:00:00Z"}, http.StatusOK, []occurrence{
+			{"2023-02-01T00:00:00Z", ""},
+			{"2023-02-01T00:01:00Z", ""},
+		}},
+		{"never matched", args{mimeVEvent, "BEGIN:VEVENT\nDTSTART:20230102T100000Z\nRRULE:FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30\nEND:VEVENT", "2", "2023-01-03T00:00:00Z"}, http.StatusBadRequest, nil},
+		{"invalid count", args{mimeVEvent, event, "101", ""}, http.StatusBadRequest, nil},
+		{"invalid after", args{mimeVEvent, event, "", "tomorrow"}, http.StatusBadRequest, nil},
+		{"invalid rrule", args{mimeVEvent, "BEGIN:VEVENT\nDTSTART:20230102T100000Z\nRRULE:FREQ=WEEKLY;BYDAY=1MO\nEND:VEVENT", "", ""}, http.StatusBadRequest, nil},
+		{"dtstart required", args{mimeVEvent, "BEGIN:VEVENT\nSUMMARY:no start\nEND:VEVENT", "", ""}, http.StatusBadRequest, nil},
+		{"unsupported content type", args{"text/plain", event, "", ""}, http.StatusBadRequest, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := request.Post("%s/api/v1/vevent/occurrences", ts.URL).
+				ContentType(tt.args.contentType).
+				Body(strings.NewReader(tt.args.content))
+			if tt.args.count != "" {
+				req = req.Query("count", tt.args.count)
+			}
+			if tt.args.after != "" {
+				req = req.Query("after", tt.args.after)
+			}
+
+			resp, err := req.Do(ctx)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equalf(t, tt.wantStatus, resp.StatusCode, "status=%d, wantStatus=%d", resp.StatusCode, tt.wantStatus)
+			if err := resp.Success(); err != nil {
+				return
+			}
+
+			got := &struct {
+				Occurrences []occurrence `json:"occurrences"`
+			}{}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(got))
+			require.Equal(t, tt.want, got.Occurrences)
+		})
+	}
+}
+
+func TestVTodoVJournal(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ts := testutils.NewTestServer(ctx, NewAPIv1())
+
+	todo := `BEGIN:VTODO
+UID:20070313T123432Z-456553@example.com
+DTSTAMP:20070313T123432Z
+DUE:20070501T090000Z
+SUMMARY:Replace air filter
+PERCENT-COMPLETE:0
+END:VTODO`
+	journal := `BEGIN:VJOURNAL
+UID:19970901T130000Z-123405@example.com
+DTSTAMP:19970901T130000Z
+SUMMARY:Air filter replaced
+END:VJOURNAL`
+
+	type args struct {
+		path        string
+		contentType string
+		content     string
+		wrap        string
+	}
+	tests := [...]struct {
+		name       string
+		args       args
+		wantStatus int
+		wantPrefix string
+	}{
+		{"vtodo", args{"vtodo", mimeVTodo, todo, ""}, http.StatusOK, "BEGIN:VTODO\r\n"},
+		{"wrap vtodo", args{"vtodo", mimeVTodo, todo, "true"}, http.StatusOK, "BEGIN:VCALENDAR\r\n"},
+		{"vtodo due and duration", args{"vtodo", mimeVTodo, "BEGIN:VTODO\nDUE:20070501T090000Z\nDURATION:PT1H\nEND:VTODO", ""}, http.StatusBadRequest, ""},
+		{"vtodo content type", args{"vtodo", mimeVEvent, todo, ""}, http.StatusBadRequest, ""},
+		{"vjournal", args{"vjournal", mimeVJournal, journal, ""}, http.StatusOK, "BEGIN:VJOURNAL\r\n"},
+		{"wrap vjournal", args{"vjournal", mimeVJournal, journal, "true"}, http.StatusOK, "BEGIN:VCALENDAR\r\n"},
+		{"invalid vjournal", args{"vjournal", mimeVJournal, "BEGIN:VJOURNAL\nDTSTART:yesterday\nEND:VJOURNAL", ""}, http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := request.Post("%s/api/v1/%s", ts.URL, tt.args.path).
+				ContentType(tt.args.contentType).
+				Body(strings.NewReader(tt.args.content))
+			if tt.args.wrap != "" {
+				req = req.Query("wrap", tt.args.wrap)
+			}
+
+			resp, err := req.Do(ctx)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equalf(t, tt.wantStatus, resp.StatusCode, "status=%d, wantStatus=%d", resp.StatusCode, tt.wantStatus)
+			if err := resp.Success(); err != nil {
+				return
+			}
+
+			img, _, err := image.Decode(resp.Body)
+			require.NoError(t, err)
+			got, err := qrcode.Decode(img)
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(got, tt.wantPrefix), got)
+		})
+	}
+}
+
+func TestEMVCo(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ts := testutils.NewTestServer(ctx, NewAPIv1())
+
+	type args struct {
+		query map[string]string
+	}
+	tests := [...]struct {
+		name       string
+		args       args
+		wantStatus int
+	}{
+		{"valid", args{map[string]string{
+			"method":          "dynamic",
+			"account[02]":     "4000123456789012",
+			"account[26][00]": "A000000677010111",
+			"account[26][01]": "0066812345678",
+			"mcc":             "5812",
+			"currency":        "410",
+			"amount":          "15000",
+			"country":         "KR",
+			"name":            "Coffee Shop",
+			"city":            "Seoul",
+			"bill":            "INV-0001",
+		}}, http.StatusOK},
+		{"invalid method", args{map[string]string{
+			"method":      "unknown",
+			"account[02]": "4000123456789012",
+			"mcc":         "5812",
+			"currency":    "410",
+			"country":     "KR",
+			"name":        "Coffee Shop",
+			"city":        "Seoul",
+		}}, http.StatusBadRequest},
+		{"missing account", args{map[string]string{
+			"mcc":      "5812",
+			"currency": "410",
+			"country":  "KR",
+			"name":     "Coffee Shop",
+			"city":     "Seoul",
+		}}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := request.Get("%s/api/v1/emvco", ts.URL).Queries(tt.args.query).Do(ctx)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equalf(t, tt.wantStatus, resp.StatusCode, "status=%d, wantStatus=%d", resp.StatusCode, tt.wantStatus)
+			if err := resp.Success(); err != nil {
+				return
+			}
+
+			img, _, err := image.Decode(resp.Body)
+			require.NoError(t, err)
+
+			payload, err := qrcode.DecodeEMVCo(img)
+			require.NoError(t, err)
+			require.Equal(t, qrcode.EMVCoDynamic, payload.InitiationMethod)
+			require.Equal(t, []qrcode.EMVCoMerchantAccount{
+				{ID: "02", Value: "4000123456789012"},
+				{ID: "26", GUID: "A000000677010111", Fields: []qrcode.EMVCoTLV{{ID: "01", Value: "0066812345678"}}},
+			}, payload.MerchantAccounts)
+			require.Equal(t, "INV-0001", payload.AdditionalData.BillNumber)
+		})
+	}
+}
+
+func TestCrypto(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ts := testutils.NewTestServer(ctx, NewAPIv1())
+
+	type args struct {
+		query map[string]string
+	}
+	tests := [...]struct {
+		name       string
+		args       args
+		wantStatus int
+		want       string
+	}{
+		{"bitcoin", args{map[string]string{
+			"coin":    "bitcoin",
+			"address": "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq",
+			"amount":  "0.01",
+			"label":   "coffee shop",
+		}}, http.StatusOK, "bitcoin:bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq?amount=0.01&label=coffee%20shop"},
+		{"ethereum", args{map[string]string{
+			"coin":    "ethereum",
+			"address": "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+			"chain":   "1",
+			"value":   "1000000000000000000",
+		}}, http.StatusOK, "ethereum:0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed@1?value=1000000000000000000"},
+		{"unknown coin", args{map[string]string{
+			"coin":    "dogecoin",
+			"address": "D7Y55r6Yoc1G8EECxkQ6SuSjTgGJJ7M6yD",
+		}}, http.StatusBadRequest, ""},
+		{"invalid address", args{map[string]string{
+			"coin":    "bitcoin",
+			"address": "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN3",
+		}}, http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := request.Get("%s/api/v1/crypto", ts.URL).Queries(tt.args.query).Do(ctx)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equalf(t, tt.wantStatus, resp.StatusCode, "status=%d, wantStatus=%d", resp.StatusCode, tt.wantStatus)
+			if err := resp.Success(); err != nil {
+				return
+			}
+
+			img, _, err := image.Decode(resp.Body)
+			require.NoError(t, err)
+
+			got, err := qrcode.Decode(img)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestOTP(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ts := testutils.NewTestServer(ctx, NewAPIv1())
+
+	type args struct {
+		query  map[string]string
+		accept string
+	}
+	tests := [...]struct {
+		name            string
+		args            args
+		wantStatus      int
+		wantContentType string
+		want            string
+	}{
+		{"totp", args{map[string]string{
+			"secret":  "JBSWY3DPEHPK3PXP",
+			"issuer":  "Example",
+			"account": "alice@google.com",
+		}, ""}, http.StatusOK, "image/png", "otpauth://totp/Example:alice@google.com?secret=JBSWY3DPEHPK3PXP&issuer=Example"},
+		{"hotp", args{map[string]string{
+			"type":    "hotp",
+			"secret":  "JBSWY3DPEHPK3PXP",
+			"account": "alice",
+			"counter": "10",
+		}, ""}, http.StatusOK, "image/png", "otpauth://hotp/alice?secret=JBSWY3DPEHPK3PXP&counter=10"},
+		{"generate", args{map[string]string{
+			"generate": "true",
+			"issuer":   "Example",
+			"account":  "alice",
+		}, "application/json"}, http.StatusOK, "application/json", ""},
+		{"generate with secret", args{map[string]string{
+			"generate": "true",
+			"secret":   "JBSWY3DPEHPK3PXP",
+			"account":  "alice",
+		}, ""}, http.StatusBadRequest, "", ""},
+		{"invalid secret", args{map[string]string{
+			"secret":  "not-base32",
+			"account": "alice",
+		}, ""}, http.StatusBadRequest, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := request.Get("%s/api/v1/otp", ts.URL).Queries(tt.args.query)
+			if tt.args.accept != "" {
+				req = req.Header(echo.HeaderAccept, tt.args.accept)
+			}
+
+			resp, err := req.Do(ctx)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equalf(t, tt.wantStatus, resp.StatusCode, "status=%d, wantStatus=%d", resp.StatusCode, tt.wantStatus)
+			if err := resp.Success(); err != nil {
+				return
+			}
+
+			mediaType, _, _ := mime.ParseMediaType(resp.Header.Get(request.HeaderContentType))
+			require.Equal(t, tt.wantContentType, mediaType)
+
+			if mediaType != echo.MIMEApplicationJSON {
+				img, _, err := image.Decode(resp.Body)
+				require.NoError(t, err)
+
+				got, err := qrcode.Decode(img)
+				require.NoError(t, err)
+				require.Equal(t, tt.want, got)
+				return
+			}
+
+			envelope := new(QRCodeEnvelope)
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(envelope))
+			require.Len(t, envelope.Secret, 32)
+			require.Equal(t, "image/png", envelope.ContentType)
+
+			img, _, err := image.Decode(bytes.NewReader(envelope.Image))
+			require.NoError(t, err)
+			got, err := qrcode.Decode(img)
+			require.NoError(t, err)
+			require.Equal(t, envelope.Content, got)
+			require.Equal(t, "otpauth://totp/Example:alice?secret="+envelope.Secret+"&issuer=Example", got)
+		})
+	}
+}
+
+func TestContactFormat(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ts := testutils.NewTestServer(ctx, NewAPIv1())
+
+	type args struct {
+		format  string
+		version string
+	}
+	tests := [...]struct {
+		name       string
+		args       args
+		wantStatus int
+		wantPrefix string
+	}{
+		{"default", args{"", ""}, http.StatusOK, "BEGIN:VCARD\r\nVERSION:4.0\r\n"},
+		{"vcard4", args{"vcard4", ""}, http.StatusOK, "BEGIN:VCARD\r\nVERSION:4.0\r\n"},
+		{"vcard3", args{"vcard3", ""}, http.StatusOK, "BEGIN:VCARD\r\nVERSION:3.0\r\n"},
+		{"vcard21", args{"vcard21", ""}, http.StatusOK, "BEGIN:VCARD\r\nVERSION:2.1\r\n"},
+		{"mecard", args{"mecard", ""}, http.StatusOK, "MECARD:N:lastname,firstname;TEL:010-1234-5678;;"},
+		{"unknown", args{"vcard2", ""}, http.StatusBadRequest, ""},
+		{"version 2.1", args{"", "2.1"}, http.StatusOK, "BEGIN:VCARD\r\nVERSION:2.1\r\n"},
+		{"version 3.0", args{"vcard3", "3.0"}, http.StatusOK, "BEGIN:VCARD\r\nVERSION:3.0\r\n"},
+		{"version mismatch", args{"mecard", "4.0"}, http.StatusBadRequest, ""},
+		{"unknown version", args{"", "5.0"}, http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := request.Get("%s/api/v1/contact", ts.URL).
+				Query("name[first]", "firstname").
+				Query("name[last]", "lastname").
+				Query("mobile", "010-1234-5678")
+			if tt.args.format != "" {
+				req = req.Query("format", tt.args.format)
+			}
+			if tt.args.version != "" {
+				req = req.Query("version", tt.args.version)
+			}
+
+			resp, err := req.Do(ctx)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equalf(t, tt.wantStatus, resp.StatusCode, "status=%d, wantStatus=%d", resp.StatusCode, tt.wantStatus)
+			if err := resp.Success(); err != nil {
+				return
+			}
+
+			img, _, err := image.Decode(resp.Body)
+			require.NoError(t, err)
+
+			got, err := qrcode.Decode(img)
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(got, tt.wantPrefix), got)
+		})
+	}
+}
+
+func TestContactFields(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ts := testutils.NewTestServer(ctx, NewAPIv1())
+
+	type args struct {
+		query map[string]string
+	}
+	tests := [...]struct {
+		name       string
+		args       args
+		wantStatus int
+		want       map[string]string
+	}{
+		{"fields", args{map[string]string{
+			"name[first]":     "John",
+			"name[last]":      "Doe",
+			"name[formatted]": "Mr. John Doe",
+			"nickname":        "Johnny",
+			"url":             "https://example.com",
+			"url[work]":       "https://work.example.com",
+			"social[twitter]": "johndoe",
+		}}, http.StatusOK, map[string]string{
+			vcard.FieldFormattedName: "Mr. John Doe",
+			vcard.FieldNickname:      "Johnny",
+			vcard.FieldURL:           "https://example.com",
+			"X-SOCIALPROFILE":        "https://twitter.com/johndoe",
+		}},
+		{"social url", args{map[string]string{
+			"name[first]":      "John",
+			"social[mastodon]": "https://mastodon.social/@johndoe",
+		}}, http.StatusOK, map[string]string{
+			"X-SOCIALPROFILE": "https://mastodon.social/@johndoe",
+		}},
+		{"unsupported social", args{map[string]string{
+			"name[first]":     "John",
+			"social[unknown]": "johndoe",
+		}}, http.StatusBadRequest, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := request.Get("%s/api/v1/contact", ts.URL).Queries(tt.args.query).Do(ctx)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equalf(t, tt.wantStatus, resp.StatusCode, "status=%d, wantStatus=%d", resp.StatusCode, tt.wantStatus)
+			if err := resp.Success(); err != nil {
+				return
+			}
+
+			img, _, err := image.Decode(resp.Body)
+			require.NoError(t, err)
+
+			got, err := qrcode.Decode(img)
+			require.NoError(t, err)
+
+			card, err := vcard.NewDecoder(strings.NewReader(got)).Decode()
+			require.NoError(t, err)
+			for k, v := range tt.want {
+				require.Equal(t, v, card.Value(k), k)
+			}
+		})
+	}
+}
+
+func TestContactPhoto(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ts := testutils.NewTestServer(ctx, NewAPIv1())
+
+	photo := new(bytes.Buffer)
+	src := image.NewGray(image.Rect(0, 0, 300, 200))
+	for i := range src.Pix {
+		src.Pix[i] = uint8(i)
+	}
+	require.NoError(t, png.Encode(photo, src))
+
+	type args struct {
+		method string
+		query  map[string]string
+		photo  []byte
+	}
+	tests := [...]struct {
+		name       string
+		args       args
+		wantStatus int
+		wantPhoto  string
+	}{
+		{"url", args{http.MethodGet, map[string]string{"photo": "https://example.com/photo.jpg"}, nil}, http.StatusOK, "https://example.com/photo.jpg"},
+		{"upload", args{http.MethodPost, map[string]string{}, photo.Bytes()}, http.StatusOK, "data:image/jpeg;base64,"},
+		{"upload without file", args{http.MethodPost, map[string]string{"photo": "https://example.com/photo.jpg"}, nil}, http.StatusOK, "https://example.com/photo.jpg"},
+		{"fallback to url", args{http.MethodPost, map[string]string{"photo": "https://example.com/photo.jpg", "photo[budget]": "10"}, photo.Bytes()}, http.StatusOK, "https://example.com/photo.jpg"},
+		{"exceed capacity", args{http.MethodPost, map[string]string{"photo[budget]": "10"}, photo.Bytes()}, http.StatusRequestEntityTooLarge, ""},
+		{"invalid image", args{http.MethodPost, map[string]string{}, []byte("not an image")}, http.StatusBadRequest, ""},
+		{"too large body", args{http.MethodPost, map[string]string{}, make([]byte, maxMultipartSize)}, http.StatusRequestEntityTooLarge, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.args.query["name[first]"] = "John"
+
+			var req *request.Request
+			if tt.args.method == http.MethodGet {
+				req = request.Get("%s/api/v1/contact", ts.URL)
+			} else {
+				body := new(bytes.Buffer)
+				w := multipart.NewWriter(body)
+				if tt.args.photo != nil {
+					part, err := w.CreateFormFile("photo", "photo.png")
+					require.NoError(t, err)
+					_, err = part.Write(tt.args.photo)
+					require.NoError(t, err)
+				}
+				require.NoError(t, w.Close())
+
+				req = request.Post("%s/api/v1/contact", ts.URL).ContentType(w.FormDataContentType()).Body(body)
+			}
+
+			resp, err := req.Queries(tt.args.query).Do(ctx)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equalf(t, tt.wantStatus, resp.StatusCode, "status=%d, wantStatus=%d", resp.StatusCode, tt.wantStatus)
+			if err := resp.Success(); err != nil {
+				return
+			}
+
+			img, _, err := image.Decode(resp.Body)
+			require.NoError(t, err)
+
+			got, err := qrcode.Decode(img)
+			require.NoError(t, err)
+
+			card, err := vcard.NewDecoder(strings.NewReader(got)).Decode()
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(card.Value(vcard.FieldPhoto), tt.wantPhoto), card.Value(vcard.FieldPhoto))
+		})
+	}
 }
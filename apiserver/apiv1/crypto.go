@@ -0,0 +1,54 @@
+package apiv1
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/whitekid/echox"
+
+	"qrcodeapi/pkg/qrcode"
+)
+
+func (api *APIv1) handleCrypto(c echo.Context) error {
+	req := &struct {
+		Coin    string `query:"coin" validate:"required,oneof=bitcoin ethereum"`
+		Address string `query:"address" validate:"required"`
+
+		// bitcoin
+		Amount  string `query:"amount"`
+		Label   string `query:"label"`
+		Message string `query:"message"`
+
+		// ethereum
+		ChainID uint64 `query:"chain"`
+		Value   string `query:"value"`
+	}{}
+
+	if err := echox.Bind(c, req); err != nil {
+		return err
+	}
+
+	var qr *qrcode.QR
+	var err error
+
+	switch req.Coin {
+	case "bitcoin":
+		qr, err = qrcode.Bitcoin(&qrcode.BitcoinPayment{
+			Address: req.Address,
+			Amount:  req.Amount,
+			Label:   req.Label,
+			Message: req.Message,
+		})
+	case "ethereum":
+		qr, err = qrcode.Ethereum(&qrcode.EthereumPayment{
+			Address: req.Address,
+			ChainID: req.ChainID,
+			Value:   req.Value,
+		})
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return api.renderQRCode(c, qr)
+}
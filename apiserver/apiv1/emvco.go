@@ -0,0 +1,135 @@
+package apiv1
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+
+	"github.com/labstack/echo/v4"
+	"github.com/whitekid/echox"
+
+	"qrcodeapi/pkg/qrcode"
+)
+
+var (
+	// account[26]=value, account[26][00]=guid
+	reMerchantAccount = regexp.MustCompile(`^account\[(\d{2})\](?:\[(\d{2})\])?$`)
+
+	emvcoMethods = map[string]string{
+		"":        "",
+		"static":  qrcode.EMVCoStatic,
+		"dynamic": qrcode.EMVCoDynamic,
+	}
+	emvcoTips = map[string]string{
+		"":           "",
+		"prompt":     qrcode.EMVCoTipPrompt,
+		"fixed":      qrcode.EMVCoTipFixed,
+		"percentage": qrcode.EMVCoTipPercentage,
+	}
+)
+
+func (api *APIv1) handleEMVCo(c echo.Context) error {
+	req := &struct {
+		Method   string `query:"method" validate:"omitempty,oneof=static dynamic"`
+		MCC      string `query:"mcc"`
+		Currency string `query:"currency"`
+		Amount   string `query:"amount"`
+		Tip      string `query:"tip" validate:"omitempty,oneof=prompt fixed percentage"`
+		Fee      string `query:"fee"`
+		FeePct   string `query:"fee[pct]"`
+		Country  string `query:"country"`
+		Name     string `query:"name"`
+		City     string `query:"city"`
+		PostCode string `query:"postcode"`
+
+		Bill     string `query:"bill"`
+		Mobile   string `query:"mobile"`
+		Store    string `query:"store"`
+		Loyalty  string `query:"loyalty"`
+		Ref      string `query:"ref"`
+		Customer string `query:"customer"`
+		Terminal string `query:"terminal"`
+		Purpose  string `query:"purpose"`
+
+		Lang    string `query:"lang"`
+		AltName string `query:"name[alt]"`
+		AltCity string `query:"city[alt]"`
+	}{}
+
+	if err := echox.Bind(c, req); err != nil {
+		return err
+	}
+
+	qr, err := qrcode.EMVCo(&qrcode.EMVCoPayload{
+		InitiationMethod:  emvcoMethods[req.Method],
+		MerchantAccounts:  merchantAccounts(c),
+		MerchantCategory:  req.MCC,
+		Currency:          req.Currency,
+		Amount:            req.Amount,
+		TipIndicator:      emvcoTips[req.Tip],
+		ConvenienceFee:    req.Fee,
+		ConvenienceFeePct: req.FeePct,
+		CountryCode:       req.Country,
+		MerchantName:      req.Name,
+		MerchantCity:      req.City,
+		PostalCode:        req.PostCode,
+		AdditionalData: qrcode.EMVCoAdditionalData{
+			BillNumber:     req.Bill,
+			MobileNumber:   req.Mobile,
+			StoreLabel:     req.Store,
+			LoyaltyNumber:  req.Loyalty,
+			ReferenceLabel: req.Ref,
+			CustomerLabel:  req.Customer,
+			TerminalLabel:  req.Terminal,
+			Purpose:        req.Purpose,
+		},
+		Language: qrcode.EMVCoLanguage{
+			Preference:   req.Lang,
+			MerchantName: req.AltName,
+			MerchantCity: req.AltCity,
+		},
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return api.renderQRCode(c, qr)
+}
+
+// merchantAccounts collect merchant accounts from query
+// - account[02]=4000123456789012: primitive value, 02~25
+// - account[26][00]=guid&account[26][01]=value: template, 26~51
+func merchantAccounts(c echo.Context) []qrcode.EMVCoMerchantAccount {
+	accounts := map[string]*qrcode.EMVCoMerchantAccount{}
+	for key, values := range c.QueryParams() {
+		m := reMerchantAccount.FindStringSubmatch(key)
+		if m == nil || len(values) == 0 {
+			continue
+		}
+
+		id, sub := m[1], m[2]
+		account, ok := accounts[id]
+		if !ok {
+			account = &qrcode.EMVCoMerchantAccount{ID: id}
+			accounts[id] = account
+		}
+
+		switch sub {
+		case "":
+			account.Value = values[0]
+		case "00":
+			account.GUID = values[0]
+		default:
+			account.Fields = append(account.Fields, qrcode.EMVCoTLV{ID: sub, Value: values[0]})
+		}
+	}
+
+	r := make([]qrcode.EMVCoMerchantAccount, 0, len(accounts))
+	for _, account := range accounts {
+		sort.Slice(account.Fields, func(i, j int) bool { return account.Fields[i].ID < account.Fields[j].ID })
+		r = append(r, *account)
+	}
+	sort.Slice(r, func(i, j int) bool { return r[i].ID < r[j].ID })
+
+	return r
+}
@@ -0,0 +1,242 @@
+package apiv1
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/emersion/go-vcard"
+	"github.com/labstack/echo/v4"
+	"github.com/whitekid/echox"
+	"github.com/whitekid/goxp/request"
+
+	"qrcodeapi/pkg/ical"
+	"qrcodeapi/pkg/qrcode"
+	"qrcodeapi/pkg/store"
+)
+
+// hostedKind kind of hosted files; bucket of the store and short link as prefix + id + ext
+type hostedKind struct {
+	bucket      string
+	prefix      string
+	ext         string
+	contentType string
+	decode      func(r io.Reader, mediaType string) ([]byte, error) // validate uploaded file and returns the file to host
+}
+
+var (
+	hostedEvents   = &hostedKind{bucket: "events", prefix: "/e/", ext: ".ics", contentType: mimeVCalendar, decode: decodeHostedCalendar}
+	hostedContacts = &hostedKind{bucket: "contacts", prefix: "/c/", ext: ".vcf", contentType: mimeVCard, decode: decodeHostedContacts}
+)
+
+// hostedFile stored file; secret is required to update the file
+type hostedFile struct {
+	Secret string `json:"secret"` // sha256 of the secret, hex encoded
+	Data   []byte `json:"data"`
+}
+
+// hostedSecretSize bytes of generated secret
+const hostedSecretSize = 16
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// renderHosted store the file and render QRCode of its short link as JSON envelope with the secret to update the file
+func (api *APIv1) renderHosted(c echo.Context, kind *hostedKind, data []byte) error {
+	if api.store == nil || api.baseURL == "" {
+		return echo.NewHTTPError(http.StatusNotImplemented, "hosted mode is not enabled; base_url and store_path are required")
+	}
+
+	b := make([]byte, hostedSecretSize)
+	if _, err := rand.Read(b); err != nil {
+		return err
+	}
+	secret := hex.EncodeToString(b)
+
+	record, err := json.Marshal(&hostedFile{Secret: hashSecret(secret), Data: data})
+	if err != nil {
+		return err
+	}
+
+	id, err := api.store.Put(c.Request().Context(), kind.bucket, record)
+	if err != nil {
+		if errors.Is(err, store.ErrQuotaExceeded) {
+			return echo.NewHTTPError(http.StatusTooManyRequests, "hosted files quota exceeded")
+		}
+		return err
+	}
+
+	qr, err := qrcode.Text(api.baseURL + kind.prefix + id + kind.ext)
+	if err != nil {
+		return err
+	}
+
+	return api.renderEnvelope(c, qr, &QRCodeEnvelope{Secret: secret})
+}
+
+// decodeHostedCalendar returns VCALENDAR of text/calendar, jCal, xCal or text/vevent
+func decodeHostedCalendar(r io.Reader, mediaType string) ([]byte, error) {
+	cal, err := decodeCalendar(r, mediaType)
+	if err != nil {
+		return nil, err
+	}
+	return encodeHostedCalendar(cal)
+}
+
+func encodeHostedCalendar(cal *ical.VCalendar) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := ical.NewCalendarEncoder(buf).Encode(cal); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeHostedContacts returns .vcf of every cards in text/vcard or the card of jCard
+func decodeHostedContacts(r io.Reader, mediaType string) ([]byte, error) {
+	return decodeContacts(r, mediaType, "")
+}
+
+// decodeContacts returns .vcf of the cards converted to the version; any invalid card fails
+func decodeContacts(r io.Reader, mediaType string, version string) ([]byte, error) {
+	cards := []vcard.Card{}
+	switch mediaType {
+	case mimeVCard:
+		dec := vcard.NewDecoder(r)
+		for {
+			card, err := dec.Decode()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, badRequest(err)
+			}
+			cards = append(cards, card)
+		}
+		if len(cards) == 0 {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "vcard required")
+		}
+
+	case mimeJCard:
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, badRequest(err)
+		}
+
+		card, err := qrcode.UnmarshalJCard(data)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		cards = append(cards, card)
+
+	default:
+		return nil, echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	buf := new(bytes.Buffer)
+	for _, card := range cards {
+		data, err := qrcode.MarshalVCard(card, version)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		buf.Write(data)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Hosted serves hosted files of short links out of /api/v1; /e/{id}.ics and /c/{id}.vcf
+//
+// PUT with the secret as bearer token replaces the file, so printed QRCode is kept
+type Hosted struct {
+	store store.Interface
+}
+
+var _ echox.Router = (*Hosted)(nil)
+
+// NewHosted returns router of hosted files; store should be shared with APIv1
+func NewHosted(s store.Interface) echox.Router { return &Hosted{store: s} }
+
+func (h *Hosted) Name() string { return "hosted" }
+func (h *Hosted) Path() string { return "" }
+
+func (h *Hosted) Route(g *echo.Group) {
+	for _, kind := range []*hostedKind{hostedEvents, hostedContacts} {
+		kind := kind
+		g.GET(kind.prefix+":file", func(c echo.Context) error { return h.handleGet(c, kind) })
+		g.PUT(kind.prefix+":file", func(c echo.Context) error { return h.handleUpdate(c, kind) })
+	}
+}
+
+// load returns id and stored file of the request path
+func (h *Hosted) load(c echo.Context, kind *hostedKind) (string, *hostedFile, error) {
+	id, ok := strings.CutSuffix(c.Param("file"), kind.ext)
+	if !ok {
+		return "", nil, echo.ErrNotFound
+	}
+
+	data, err := h.store.Get(c.Request().Context(), kind.bucket, id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return "", nil, echo.ErrNotFound
+		}
+		return "", nil, err
+	}
+
+	file := new(hostedFile)
+	if err := json.Unmarshal(data, file); err != nil {
+		return "", nil, err
+	}
+
+	return id, file, nil
+}
+
+// handleGet serves hosted file as text/calendar or text/vcard
+func (h *Hosted) handleGet(c echo.Context, kind *hostedKind) error {
+	_, file, err := h.load(c, kind)
+	if err != nil {
+		return err
+	}
+
+	return c.Blob(http.StatusOK, kind.contentType+"; charset=utf-8", file.Data)
+}
+
+// handleUpdate replace hosted file; secret is given as bearer token
+func (h *Hosted) handleUpdate(c echo.Context, kind *hostedKind) error {
+	id, file, err := h.load(c, kind)
+	if err != nil {
+		return err
+	}
+
+	secret, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(file.Secret)) != 1 {
+		return echo.ErrUnauthorized
+	}
+
+	body := limitBody(c)
+	defer body.Close()
+	mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(request.HeaderContentType))
+	if file.Data, err = kind.decode(body, mediaType); err != nil {
+		return err
+	}
+
+	record, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+
+	if err := h.store.Update(c.Request().Context(), kind.bucket, id, record); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,313 @@
+package apiv1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/whitekid/goxp/request"
+
+	"qrcodeapi/pkg/ical"
+	"qrcodeapi/pkg/qrcode"
+)
+
+// handleVEvent generate QRCode of text/vevent, text/calendar, application/calendar+json(jCal) or application/calendar+xml(xCal)
+// wrap=true encodes text/vevent in VCALENDAR; others are always encoded as VCALENDAR
+// decode=strict validates as RFC 5545, decode=lenient skips invalid content lines
+// mode=hosted encodes short link of the calendar hosted by this service as JSON envelope with the secret to update it,
+// mode=google, outlook, office365 or yahoo encodes "add to calendar" link of the first event
+func (api *APIv1) handleVEvent(c echo.Context) error {
+	req := &struct {
+		Wrap   bool   `query:"wrap"`
+		Decode string `query:"decode" validate:"omitempty,oneof=strict lenient"`
+		Mode   string `query:"mode" validate:"omitempty,oneof=vevent hosted google outlook office365 yahoo"`
+	}{}
+	if err := bindQuery(c, req); err != nil {
+		return err
+	}
+
+	var opts []ical.DecodeOption
+	switch req.Decode {
+	case "strict":
+		opts = append(opts, ical.Strict())
+	case "lenient":
+		opts = append(opts, ical.Lenient())
+	}
+
+	body := limitBody(c)
+	defer body.Close()
+
+	mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(request.HeaderContentType))
+	cal, err := decodeCalendar(body, mediaType, opts...)
+	if err != nil {
+		return err
+	}
+
+	var qr *qrcode.QR
+	switch req.Mode {
+	case "", modeVEvent:
+		if mediaType == mimeVEvent && !req.Wrap {
+			qr, err = qrcode.VEvent(cal.Events[0])
+		} else {
+			qr, err = qrcode.VCalendar(cal)
+		}
+	case modeHosted:
+		data, err := encodeHostedCalendar(cal)
+		if err != nil {
+			return err
+		}
+		return api.renderHosted(c, hostedEvents, data)
+	default:
+		qr, err = qrcode.VEventLink(cal.Events[0], req.Mode)
+	}
+
+	return api.renderCalendarQRCode(c, qr, err)
+}
+
+// decodeCalendar decode text/calendar, jCal, xCal or text/vevent as VCALENDAR; VEVENT is required
+func decodeCalendar(r io.Reader, mediaType string, opts ...ical.DecodeOption) (*ical.VCalendar, error) {
+	cal := new(ical.VCalendar)
+	switch mediaType {
+	case mimeVEvent:
+		evt := new(ical.VEvent)
+		if err := ical.NewEventDecoder(r, opts...).Decode(evt); err != nil {
+			return nil, decodeError(err)
+		}
+		cal.Events = []*ical.VEvent{evt}
+
+	case mimeVCalendar, mimeJCal, mimeXCal:
+		newDecoder := ical.NewCalendarDecoder
+		switch mediaType {
+		case mimeJCal:
+			newDecoder = ical.NewJCalDecoder
+		case mimeXCal:
+			newDecoder = ical.NewXCalDecoder
+		}
+
+		if err := newDecoder(r, opts...).Decode(cal); err != nil {
+			return nil, decodeError(err)
+		}
+		if len(cal.Events) == 0 {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "VEVENT required")
+		}
+
+	default:
+		return nil, echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	return cal, nil
+}
+
+// output modes of calendar QRCode; others are link formats of qrcode.EventLink
+const (
+	modeVEvent = "vevent"
+	modeHosted = "hosted"
+)
+
+// handleEvent generate QRCode of event given as JSON of friendly fields; wrap=true encodes it in VCALENDAR
+// invalid fields are returned as JSON list of field and message
+func (api *APIv1) handleEvent(c echo.Context) error {
+	if mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(request.HeaderContentType)); mediaType != echo.MIMEApplicationJSON {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	req := &struct {
+		Wrap bool `query:"wrap"`
+	}{}
+	if err := bindQuery(c, req); err != nil {
+		return err
+	}
+
+	body := limitBody(c)
+	defer body.Close()
+	event := new(qrcode.Event)
+	if err := json.NewDecoder(body).Decode(event); err != nil {
+		return badRequest(fmt.Errorf("invalid json: %w", err))
+	}
+
+	evt, err := event.VEvent()
+	if err != nil {
+		var errs qrcode.FieldErrors
+		if errors.As(err, &errs) {
+			return echo.NewHTTPError(http.StatusBadRequest, []*qrcode.FieldError(errs))
+		}
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	if req.Wrap {
+		qr, err := qrcode.VCalendar(&ical.VCalendar{Events: []*ical.VEvent{evt}})
+		return api.renderCalendarQRCode(c, qr, err)
+	}
+
+	qr, err := qrcode.VEvent(evt)
+	return api.renderCalendarQRCode(c, qr, err)
+}
+
+// maxSkippedOccurrences limits occurrences skipped before after; ex. SECONDLY rule started long ago
+const maxSkippedOccurrences = 100000
+
+// handleVEventOccurrences preview next occurrences of text/vevent or first event of text/calendar
+func (api *APIv1) handleVEventOccurrences(c echo.Context) error {
+	req := &struct {
+		Count int       `query:"count" validate:"min=0,max=100"`
+		After time.Time `query:"after"`
+	}{}
+	if err := bindQuery(c, req); err != nil {
+		return err
+	}
+	if req.Count == 0 {
+		req.Count = 10
+	}
+	if req.After.IsZero() {
+		req.After = time.Now()
+	}
+
+	body := limitBody(c)
+	defer body.Close()
+
+	evt := new(ical.VEvent)
+	switch mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(request.HeaderContentType)); mediaType {
+	case mimeVEvent:
+		if err := ical.NewEventDecoder(body).Decode(evt); err != nil {
+			return decodeError(err)
+		}
+
+	case mimeVCalendar:
+		cal := new(ical.VCalendar)
+		if err := ical.NewCalendarDecoder(body).Decode(cal); err != nil {
+			return decodeError(err)
+		}
+		if len(cal.Events) == 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "VEVENT required")
+		}
+		evt = cal.Events[0]
+
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	if evt.DtStart.IsZero() {
+		return echo.NewHTTPError(http.StatusBadRequest, "DTSTART required")
+	}
+
+	type occurrence struct {
+		Start string `json:"start"`
+		End   string `json:"end,omitempty"`
+	}
+	format := func(dt ical.DateTime) string {
+		if dt.IsZero() {
+			return ""
+		}
+		if dt.IsDate() {
+			return dt.Format("2006-01-02")
+		}
+		return dt.Format(time.RFC3339)
+	}
+
+	occurrences := []occurrence{}
+	it := evt.Occurrences()
+	for skipped := 0; len(occurrences) < req.Count && skipped < maxSkippedOccurrences; {
+		period, ok := it.Next()
+		if !ok {
+			break
+		}
+
+		if period.Start.Before(req.After) {
+			skipped++
+			continue
+		}
+
+		occurrences = append(occurrences, occurrence{Start: format(period.Start), End: format(period.End)})
+	}
+	if err := it.Err(); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"occurrences": occurrences})
+}
+
+// handleVTodo generate QRCode of text/vtodo; wrap=true encodes it in VCALENDAR
+func (api *APIv1) handleVTodo(c echo.Context) error {
+	if mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(request.HeaderContentType)); mediaType != mimeVTodo {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	req := &struct {
+		Wrap bool `query:"wrap"`
+	}{}
+	if err := bindQuery(c, req); err != nil {
+		return err
+	}
+
+	body := limitBody(c)
+	defer body.Close()
+	todo := new(ical.VTodo)
+	if err := ical.NewTodoDecoder(body).Decode(todo); err != nil {
+		return decodeError(err)
+	}
+
+	if req.Wrap {
+		qr, err := qrcode.VCalendar(&ical.VCalendar{Todos: []*ical.VTodo{todo}})
+		return api.renderCalendarQRCode(c, qr, err)
+	}
+
+	qr, err := qrcode.VTodo(todo)
+	return api.renderCalendarQRCode(c, qr, err)
+}
+
+// handleVJournal generate QRCode of text/vjournal; wrap=true encodes it in VCALENDAR
+func (api *APIv1) handleVJournal(c echo.Context) error {
+	if mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(request.HeaderContentType)); mediaType != mimeVJournal {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	req := &struct {
+		Wrap bool `query:"wrap"`
+	}{}
+	if err := bindQuery(c, req); err != nil {
+		return err
+	}
+
+	body := limitBody(c)
+	defer body.Close()
+	journal := new(ical.VJournal)
+	if err := ical.NewJournalDecoder(body).Decode(journal); err != nil {
+		return decodeError(err)
+	}
+
+	if req.Wrap {
+		qr, err := qrcode.VCalendar(&ical.VCalendar{Journals: []*ical.VJournal{journal}})
+		return api.renderCalendarQRCode(c, qr, err)
+	}
+
+	qr, err := qrcode.VJournal(journal)
+	return api.renderCalendarQRCode(c, qr, err)
+}
+
+// decodeError returns 400 error; errors of content lines are given as JSON list with their positions
+func decodeError(err error) error {
+	var errs ical.DecodeErrors
+	if errors.As(err, &errs) {
+		return echo.NewHTTPError(http.StatusBadRequest, []*ical.DecodeError(errs))
+	}
+	return badRequest(err)
+}
+
+// renderCalendarQRCode render QRCode of calendar component; encoding error is mapped to status
+func (api *APIv1) renderCalendarQRCode(c echo.Context, qr *qrcode.QR, err error) error {
+	if err != nil {
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			return err
+		}
+
+		return encodeError(err)
+	}
+
+	return api.renderQRCode(c, qr)
+}
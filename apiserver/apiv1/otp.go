@@ -0,0 +1,66 @@
+package apiv1
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/whitekid/echox"
+
+	"qrcodeapi/pkg/qrcode"
+)
+
+// handleOTP generate otpauth:// QRCode
+// if generate is set, random secret is generated and returned with image as JSON envelope
+func (api *APIv1) handleOTP(c echo.Context) error {
+	req := &struct {
+		Type       string `query:"type"`
+		Secret     string `query:"secret"`
+		Generate   bool   `query:"generate"`
+		SecretSize int    `query:"secret[size]"`
+		Issuer     string `query:"issuer"`
+		Account    string `query:"account"`
+		Algorithm  string `query:"algorithm"`
+		Digits     int    `query:"digits"`
+		Period     int    `query:"period"`
+		Counter    uint64 `query:"counter"`
+	}{
+		Type:       qrcode.OTPTypeTOTP,
+		SecretSize: 20,
+	}
+
+	if err := echox.Bind(c, req); err != nil {
+		return err
+	}
+
+	if req.Generate {
+		if req.Secret != "" {
+			return echo.NewHTTPError(http.StatusBadRequest, "secret and generate are exclusive")
+		}
+
+		secret, err := qrcode.GenerateOTPSecret(req.SecretSize)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		req.Secret = secret
+	}
+
+	qr, err := qrcode.OTP(&qrcode.OTPAuth{
+		Type:      req.Type,
+		Secret:    req.Secret,
+		Issuer:    req.Issuer,
+		Account:   req.Account,
+		Algorithm: req.Algorithm,
+		Digits:    req.Digits,
+		Period:    req.Period,
+		Counter:   req.Counter,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	if req.Generate {
+		return api.renderEnvelope(c, qr, &QRCodeEnvelope{Secret: req.Secret})
+	}
+
+	return api.renderQRCode(c, qr)
+}
@@ -0,0 +1,231 @@
+package apiv1
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/emersion/go-vcard"
+	"github.com/labstack/echo/v4"
+	"github.com/whitekid/goxp"
+	"github.com/whitekid/goxp/request"
+
+	"qrcodeapi/pkg/qrcode"
+)
+
+// handleContactVCard generate QRCode of text/vcard or application/vcard+json(jCard)
+// .vcf with several cards returns ZIP archive of QRCode images named by FN, with errors.json for failed cards
+// mode=hosted encodes short link of the cards hosted by this service as JSON envelope with the secret to update them
+func (api *APIv1) handleContactVCard(c echo.Context) error {
+	mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(request.HeaderContentType))
+	if mediaType != mimeVCard && mediaType != mimeJCard {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	req := &struct {
+		Version string `query:"version" validate:"omitempty,oneof=2.1 3.0 4.0"`
+		Mode    string `query:"mode" validate:"omitempty,oneof=vcard hosted"`
+	}{}
+	if err := bindQuery(c, req); err != nil {
+		return err
+	}
+
+	body := limitBody(c)
+	defer body.Close()
+	if req.Mode == modeHosted {
+		data, err := decodeContacts(body, mediaType, req.Version)
+		if err != nil {
+			return err
+		}
+		return api.renderHosted(c, hostedContacts, data)
+	}
+
+	if mediaType == mimeJCard {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return badRequest(err)
+		}
+
+		card, err := qrcode.UnmarshalJCard(data)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		qr, err := qrcode.VCardAs(card, req.Version)
+		if err != nil {
+			return encodeError(err)
+		}
+		return api.renderQRCode(c, qr)
+	}
+
+	entries, err := decodeVCards(body, req.Version)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	switch len(entries) {
+	case 0:
+		return echo.NewHTTPError(http.StatusBadRequest, "vcard required")
+	case 1:
+		if entries[0].err != nil {
+			return encodeError(entries[0].err)
+		}
+		return api.renderQRCode(c, entries[0].qr)
+	}
+
+	return api.renderVCardArchive(c, entries)
+}
+
+// vcardEntry a card of .vcf; qr or err is set
+type vcardEntry struct {
+	index int // 1-based position in .vcf
+	name  string
+	qr    *qrcode.QR
+	err   error
+}
+
+// vcardError per card error in errors.json
+type vcardError struct {
+	Index int    `json:"index"`
+	Name  string `json:"name,omitempty"`
+	Error string `json:"error"`
+}
+
+// decodeVCards decode every cards in r; fails on read error
+// decoder skips a line on error, so consecutive errors are reported once as the same broken card
+func decodeVCards(r io.Reader, version string) ([]*vcardEntry, error) {
+	entries := []*vcardEntry{}
+	rr := &readErrorReader{r: r}
+	dec := vcard.NewDecoder(rr)
+	for failed := false; ; {
+		card, err := dec.Decode()
+		if rr.err != nil {
+			return nil, rr.err
+		}
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			if !failed {
+				entries = append(entries, &vcardEntry{index: len(entries) + 1, err: err})
+			}
+			failed = true
+			continue
+		}
+		failed = false
+
+		entry := &vcardEntry{index: len(entries) + 1, name: card.PreferredValue(vcard.FieldFormattedName)}
+		entry.qr, entry.err = qrcode.VCardAs(card, version)
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// readErrorReader keeps read error except io.EOF; vcard decoder returns it as parse error of the card
+type readErrorReader struct {
+	r   io.Reader
+	err error
+}
+
+func (r *readErrorReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if err != nil && err != io.EOF {
+		r.err = err
+	}
+	return n, err
+}
+
+// renderVCardArchive render QRCode images of cards as ZIP archive
+func (api *APIv1) renderVCardArchive(c echo.Context, entries []*vcardEntry) error {
+	encode, contentType, err := imageEncoder(c.Request().Header.Get(echo.HeaderAccept))
+	if err != nil {
+		return err
+	}
+	ext := "." + strings.TrimPrefix(contentType, "image/")
+	width := goxp.ParseIntDef(c.QueryParam("w"), 200, 21, 200)
+	height := goxp.ParseIntDef(c.QueryParam("h"), 200, 21, 200)
+
+	buf := new(bytes.Buffer)
+	archive := zip.NewWriter(buf)
+	names := map[string]bool{}
+	errs := []vcardError{}
+	for _, entry := range entries {
+		if entry.err == nil {
+			entry.err = renderArchiveImage(archive, uniqueFileName(names, entry, ext), entry.qr, width, height, encode)
+		}
+
+		if entry.err != nil {
+			errs = append(errs, vcardError{Index: entry.index, Name: entry.name, Error: entry.err.Error()})
+		}
+	}
+
+	if len(errs) == len(entries) {
+		return echo.NewHTTPError(http.StatusBadRequest, errs)
+	}
+
+	if len(errs) > 0 {
+		w, err := archive.Create("errors.json")
+		if err != nil {
+			return err
+		}
+		if err := json.NewEncoder(w).Encode(errs); err != nil {
+			return err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="vcards.zip"`)
+	return c.Blob(http.StatusOK, "application/zip", buf.Bytes())
+}
+
+// renderArchiveImage render QRCode and add to archive; nothing is added if render failed
+func renderArchiveImage(archive *zip.Writer, name string, qr *qrcode.QR, width, height int, encode func(io.Writer, image.Image) error) error {
+	img, err := qr.Render(width, height)
+	if err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := encode(buf, img); err != nil {
+		return err
+	}
+
+	w, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// uniqueFileName returns file name from FN; card index is used if FN is empty, number is added if duplicated
+func uniqueFileName(names map[string]bool, entry *vcardEntry, ext string) string {
+	base := strings.TrimSpace(strings.Map(func(r rune) rune {
+		if r < ' ' || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, entry.name))
+	if base == "" {
+		base = fmt.Sprintf("card-%d", entry.index)
+	}
+
+	name := base + ext
+	for i := 2; names[name]; i++ {
+		name = fmt.Sprintf("%s (%d)%s", base, i, ext)
+	}
+	names[name] = true
+
+	return name
+}
@@ -14,6 +14,8 @@ import (
 
 	"qrcodeapi/apiserver/apiv1"
 	"qrcodeapi/config"
+	"qrcodeapi/pkg/qrcode"
+	"qrcodeapi/pkg/store"
 )
 
 func Run(ctx context.Context) error { return New().Serve(ctx) }
@@ -25,7 +27,23 @@ var _ service.Interface = (*qrcodeService)(nil)
 func New() service.Interface { return &qrcodeService{} }
 
 func (s *qrcodeService) Serve(ctx context.Context) error {
-	e := s.setup()
+	if err := qrcode.LoadSocialNetworks(config.SocialNetworks()); err != nil {
+		return err
+	}
+
+	// hosted links should be stable; host header is given by client and memory store is lost on restart
+	var files store.Interface
+	if config.BaseURL() != "" && config.StorePath() != "" {
+		var err error
+		if files, err = store.New(config.StorePath(), store.WithMaxRecords(config.StoreQuota())); err != nil {
+			return err
+		}
+		defer files.Close()
+	} else {
+		log.Infof("hosted mode is disabled; base_url and store_path are required")
+	}
+
+	e := s.setup(files)
 
 	go func() {
 		<-ctx.Done()
@@ -44,7 +62,8 @@ func (s *qrcodeService) Serve(ctx context.Context) error {
 	return http.ListenAndServe(config.BindAddr(), h)
 }
 
-func (s *qrcodeService) setup() *echox.Echo {
+// setup returns echo of APIv1 and hosted files of the store; hosted mode is disabled if files is nil
+func (s *qrcodeService) setup(files store.Interface) *echox.Echo {
 	e := echox.New(
 		middleware.RateLimiter(middleware.NewRateLimiterMemoryStore(rate.Limit(config.RateLimit()))),
 		middleware.CORS(),
@@ -53,7 +72,12 @@ func (s *qrcodeService) setup() *echox.Echo {
 	e.GET("/", func(c echo.Context) error {
 		return c.Redirect(http.StatusFound, "https://github.com/whitekid/qrcode")
 	})
-	e.Route(nil, apiv1.NewAPIv1())
+	if files == nil {
+		e.Route(nil, apiv1.NewAPIv1())
+	} else {
+		e.Route(nil, apiv1.NewAPIv1(apiv1.WithStore(files), apiv1.WithBaseURL(config.BaseURL())))
+		e.Route(nil, apiv1.NewHosted(files))
+	}
 
 	for _, r := range e.Routes() {
 		log.Debugf("%s %s => %s", r.Method, r.Path, r.Name)
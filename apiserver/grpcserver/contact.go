@@ -0,0 +1,99 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/whitekid/goxp/fx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"qrcodeapi/pkg/qrcode"
+	"qrcodeapi/proto"
+)
+
+func (s *v1alpha1ServiceImpl) Contact(ctx context.Context, in *proto.ContactRequest) (*proto.Response, error) {
+	if in.Card == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "card required")
+	}
+
+	q, err := qrcode.ContactAs(cardFromProto(in.Card), in.Format)
+	if err != nil {
+		var capacityErr *qrcode.CapacityError
+		if errors.As(err, &capacityErr) {
+			return nil, status.Errorf(codes.ResourceExhausted, err.Error())
+		}
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	return s.render(q, in.Width, in.Height, in.Accept)
+}
+
+func cardFromProto(card *proto.Card) *qrcode.Card {
+	return &qrcode.Card{
+		LastName:      card.LastName,
+		FirstName:     card.FirstName,
+		MiddleName:    card.MiddleName,
+		PrefixName:    card.PrefixName,
+		SuffixName:    card.SuffixName,
+		FormattedName: card.FormattedName,
+		NickName:      card.NickName,
+
+		Company:    card.Company,
+		Department: card.Department,
+		JobTitle:   card.JobTitle,
+
+		Email:     card.Email,
+		HomeEmail: card.HomeEmail,
+		WorkEmail: card.WorkEmail,
+
+		Tel:     card.Tel,
+		Mobile:  card.Mobile,
+		HomeTel: card.HomeTel,
+		WorkTel: card.WorkTel,
+
+		HomeFax: card.HomeFax,
+		WorkFax: card.WorkFax,
+
+		Pager: card.Pager,
+
+		HomeAddr: addressFromProto(card.HomeAddr),
+		WorkAddr: addressFromProto(card.WorkAddr),
+
+		Homepage:     card.Homepage,
+		WorkHomepage: card.WorkHomepage,
+		HomeHomepage: card.HomeHomepage,
+
+		Note: card.Note,
+
+		SocialProfiles: fx.Map(card.SocialProfiles, func(social *proto.SocialProfile) qrcode.SocialProfile {
+			return qrcode.SocialProfile{Type: social.Type, ID: social.Id}
+		}),
+
+		Photo: cardImageFromProto(card.Photo),
+		Logo:  cardImageFromProto(card.Logo),
+	}
+}
+
+func cardImageFromProto(img *proto.CardImage) qrcode.CardImage {
+	if img == nil {
+		return qrcode.CardImage{}
+	}
+
+	return qrcode.CardImage{URL: img.Url, Data: img.Data, Budget: int(img.Budget)}
+}
+
+func addressFromProto(addr *proto.Address) qrcode.Address {
+	if addr == nil {
+		return qrcode.Address{}
+	}
+
+	return qrcode.Address{
+		PostCode:        addr.PostCode,
+		CountryOrRegion: addr.CountryOrRegion,
+		Province:        addr.Province,
+		City:            addr.City,
+		Street:          addr.Street,
+		Street2:         addr.Street2,
+	}
+}
@@ -0,0 +1,39 @@
+package grpcserver
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"qrcodeapi/pkg/qrcode"
+	"qrcodeapi/proto"
+)
+
+func (s *v1alpha1ServiceImpl) Crypto(ctx context.Context, in *proto.CryptoRequest) (*proto.Response, error) {
+	var q *qrcode.QR
+	var err error
+
+	switch payment := in.Payment.(type) {
+	case *proto.CryptoRequest_Bitcoin:
+		q, err = qrcode.Bitcoin(&qrcode.BitcoinPayment{
+			Address: payment.Bitcoin.Address,
+			Amount:  payment.Bitcoin.Amount,
+			Label:   payment.Bitcoin.Label,
+			Message: payment.Bitcoin.Message,
+		})
+	case *proto.CryptoRequest_Ethereum:
+		q, err = qrcode.Ethereum(&qrcode.EthereumPayment{
+			Address: payment.Ethereum.Address,
+			ChainID: payment.Ethereum.ChainId,
+			Value:   payment.Ethereum.Value,
+		})
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "payment required")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	return s.render(q, in.Width, in.Height, in.Accept)
+}
@@ -0,0 +1,71 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/whitekid/goxp/fx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"qrcodeapi/pkg/qrcode"
+	"qrcodeapi/proto"
+)
+
+func (s *v1alpha1ServiceImpl) Emvco(ctx context.Context, in *proto.EMVCoRequest) (*proto.Response, error) {
+	payload := &qrcode.EMVCoPayload{
+		InitiationMethod: in.InitiationMethod,
+		MerchantAccounts: fx.Map(in.MerchantAccounts, func(x *proto.MerchantAccount) qrcode.EMVCoMerchantAccount {
+			return qrcode.EMVCoMerchantAccount{
+				ID:     x.Id,
+				Value:  x.Value,
+				GUID:   x.Guid,
+				Fields: tlvsFromProto(x.Fields),
+			}
+		}),
+		MerchantCategory:  in.MerchantCategory,
+		Currency:          in.Currency,
+		Amount:            in.Amount,
+		TipIndicator:      in.TipIndicator,
+		ConvenienceFee:    in.ConvenienceFee,
+		ConvenienceFeePct: in.ConvenienceFeePct,
+		CountryCode:       in.CountryCode,
+		MerchantName:      in.MerchantName,
+		MerchantCity:      in.MerchantCity,
+		PostalCode:        in.PostalCode,
+		Fields:            tlvsFromProto(in.Fields),
+	}
+
+	if data := in.AdditionalData; data != nil {
+		payload.AdditionalData = qrcode.EMVCoAdditionalData{
+			BillNumber:          data.BillNumber,
+			MobileNumber:        data.MobileNumber,
+			StoreLabel:          data.StoreLabel,
+			LoyaltyNumber:       data.LoyaltyNumber,
+			ReferenceLabel:      data.ReferenceLabel,
+			CustomerLabel:       data.CustomerLabel,
+			TerminalLabel:       data.TerminalLabel,
+			Purpose:             data.Purpose,
+			ConsumerDataRequest: data.ConsumerDataRequest,
+			Fields:              tlvsFromProto(data.Fields),
+		}
+	}
+
+	if lang := in.Language; lang != nil {
+		payload.Language = qrcode.EMVCoLanguage{
+			Preference:   lang.Preference,
+			MerchantName: lang.MerchantName,
+			MerchantCity: lang.MerchantCity,
+		}
+	}
+
+	q, err := qrcode.EMVCo(payload)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	return s.render(q, in.Width, in.Height, in.Accept)
+}
+
+func tlvsFromProto(tlvs []*proto.TLV) []qrcode.EMVCoTLV {
+	return fx.Map(tlvs, func(x *proto.TLV) qrcode.EMVCoTLV { return qrcode.EMVCoTLV{ID: x.Id, Value: x.Value} })
+}
@@ -0,0 +1,93 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/whitekid/goxp/fx"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"qrcodeapi/pkg/ical"
+	"qrcodeapi/pkg/qrcode"
+	"qrcodeapi/proto"
+)
+
+// Event invalid fields are given as BadRequest details with JSON path of the field, ex. attendees[0].email
+func (s *v1alpha1ServiceImpl) Event(ctx context.Context, in *proto.EventRequest) (*proto.Response, error) {
+	if in.Event == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "event required")
+	}
+
+	evt, err := eventFromProto(in.Event).VEvent()
+	if err != nil {
+		return nil, fieldViolations(err)
+	}
+
+	var q *qrcode.QR
+	if in.Wrap {
+		q, err = qrcode.VCalendar(&ical.VCalendar{Events: []*ical.VEvent{evt}})
+	} else {
+		q, err = qrcode.VEvent(evt)
+	}
+	if err != nil {
+		var capacityErr *qrcode.CapacityError
+		if errors.As(err, &capacityErr) {
+			return nil, status.Errorf(codes.ResourceExhausted, err.Error())
+		}
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	return s.render(q, in.Width, in.Height, in.Accept)
+}
+
+// fieldViolations returns InvalidArgument status with BadRequest details of FieldErrors
+func fieldViolations(err error) error {
+	var errs qrcode.FieldErrors
+	if !errors.As(err, &errs) {
+		return status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	st, detailErr := status.New(codes.InvalidArgument, err.Error()).WithDetails(&errdetails.BadRequest{
+		FieldViolations: fx.Map(errs, func(e *qrcode.FieldError) *errdetails.BadRequest_FieldViolation {
+			return &errdetails.BadRequest_FieldViolation{Field: e.Field, Description: e.Message}
+		}),
+	})
+	if detailErr != nil {
+		return status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	return st.Err()
+}
+
+func eventFromProto(evt *proto.Event) *qrcode.Event {
+	event := &qrcode.Event{
+		Title:       evt.Title,
+		Start:       evt.Start,
+		End:         evt.End,
+		Duration:    evt.Duration,
+		AllDay:      evt.AllDay,
+		TZ:          evt.Tz,
+		Location:    evt.Location,
+		Description: evt.Description,
+		URL:         evt.Url,
+
+		Attendees: fx.Map(evt.Attendees, func(attendee *proto.Attendee) qrcode.Attendee {
+			return qrcode.Attendee{Email: attendee.Email, Name: attendee.Name, Role: attendee.Role, RSVP: attendee.Rsvp}
+		}),
+	}
+
+	if r := evt.Recurrence; r != nil {
+		event.Recurrence = &qrcode.Recurrence{
+			Freq:       r.Freq,
+			Interval:   int(r.Interval),
+			Count:      int(r.Count),
+			Until:      r.Until,
+			ByDay:      r.ByDay,
+			ByMonthDay: fx.Map(r.ByMonthDay, func(v int32) int { return int(v) }),
+			ByMonth:    fx.Map(r.ByMonth, func(v int32) int { return int(v) }),
+		}
+	}
+
+	return event
+}
@@ -0,0 +1,52 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/whitekid/goxp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"qrcodeapi/pkg/qrcode"
+	"qrcodeapi/proto"
+)
+
+func (s *v1alpha1ServiceImpl) Otp(ctx context.Context, in *proto.OTPRequest) (*proto.OTPResponse, error) {
+	secret := in.Secret
+	if in.Generate {
+		if secret != "" {
+			return nil, status.Errorf(codes.InvalidArgument, "secret and generate are exclusive")
+		}
+
+		var err error
+		secret, err = qrcode.GenerateOTPSecret(goxp.Ternary(in.SecretSize == 0, 20, int(in.SecretSize)))
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	q, err := qrcode.OTP(&qrcode.OTPAuth{
+		Type:      goxp.Ternary(in.Type == "", qrcode.OTPTypeTOTP, in.Type),
+		Secret:    secret,
+		Issuer:    in.Issuer,
+		Account:   in.Account,
+		Algorithm: in.Algorithm,
+		Digits:    int(in.Digits),
+		Period:    int(in.Period),
+		Counter:   in.Counter,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	resp, err := s.render(q, in.Width, in.Height, in.Accept)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.OTPResponse{
+		Qrcode: resp,
+		Secret: secret,
+		Uri:    q.Content,
+	}, nil
+}
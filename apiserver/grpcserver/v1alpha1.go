@@ -38,8 +38,12 @@ func (s *v1alpha1ServiceImpl) Generate(ctx context.Context, in *proto.Request) (
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
 
-	width := goxp.Ternary(in.Width < 20, 200, int(in.Width))
-	height := goxp.Ternary(in.Width < 20, 200, int(in.Height))
+	return s.render(q, in.Width, in.Height, in.Accept)
+}
+
+func (s *v1alpha1ServiceImpl) render(q *qrcode.QR, w, h int32, accept string) (*proto.Response, error) {
+	width := goxp.Ternary(w < 20, 200, int(w))
+	height := goxp.Ternary(w < 20, 200, int(h))
 
 	width = fx.Min(fx.Max(20, width), 200)
 	height = fx.Min(fx.Max(20, height), 200)
@@ -51,7 +55,7 @@ func (s *v1alpha1ServiceImpl) Generate(ctx context.Context, in *proto.Request) (
 
 	var buf bytes.Buffer
 	contentType := "image/png"
-	accepts := strings.Split(strings.ToLower(in.Accept), ",")
+	accepts := strings.Split(strings.ToLower(accept), ",")
 	for _, accept := range accepts {
 		switch strings.ToLower(accept) {
 		case "image/jpeg", "image/jpg":
@@ -3,15 +3,20 @@ package grpcserver
 import (
 	"bytes"
 	"context"
+	"image"
 	"image/png"
 	"net"
 	"testing"
 
+	"qrcodeapi/pkg/qrcode"
 	"qrcodeapi/proto"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -84,3 +89,318 @@ func TestGenerate(t *testing.T) {
 		})
 	}
 }
+
+func TestEmvco(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := newTestClient(ctx, t)
+
+	type args struct {
+		req *proto.EMVCoRequest
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{`valid`, args{&proto.EMVCoRequest{
+			InitiationMethod: "11",
+			MerchantAccounts: []*proto.MerchantAccount{
+				{Id: "26", Guid: "A000000677010111", Fields: []*proto.TLV{{Id: "01", Value: "0066812345678"}}},
+			},
+			MerchantCategory: "5812",
+			Currency:         "410",
+			CountryCode:      "KR",
+			MerchantName:     "Coffee Shop",
+			MerchantCity:     "Seoul",
+			AdditionalData:   &proto.EMVCoAdditionalData{BillNumber: "INV-0001"},
+		}}, false},
+		{`missing merchant account`, args{&proto.EMVCoRequest{
+			MerchantCategory: "5812",
+			Currency:         "410",
+			CountryCode:      "KR",
+			MerchantName:     "Coffee Shop",
+			MerchantCity:     "Seoul",
+		}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.Emvco(ctx, tt.args.req)
+			require.Truef(t, (err != nil) == tt.wantErr, `Emvco() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+				return
+			}
+
+			img, err := png.Decode(bytes.NewReader(got.Image))
+			require.NoError(t, err)
+
+			payload, err := qrcode.DecodeEMVCo(img)
+			require.NoError(t, err)
+			require.Equal(t, "Coffee Shop", payload.MerchantName)
+			require.Equal(t, "A000000677010111", payload.MerchantAccounts[0].GUID)
+			require.Equal(t, "INV-0001", payload.AdditionalData.BillNumber)
+		})
+	}
+}
+
+func TestCrypto(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := newTestClient(ctx, t)
+
+	type args struct {
+		req *proto.CryptoRequest
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+		want    string
+	}{
+		{`bitcoin`, args{&proto.CryptoRequest{Payment: &proto.CryptoRequest_Bitcoin{Bitcoin: &proto.BitcoinPayment{
+			Address: "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", Amount: "0.5",
+		}}}}, false, "bitcoin:1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2?amount=0.5"},
+		{`ethereum`, args{&proto.CryptoRequest{Payment: &proto.CryptoRequest_Ethereum{Ethereum: &proto.EthereumPayment{
+			Address: "0xfb6916095ca1df60bb79ce92ce3ea74c37c5d359", ChainId: 1,
+		}}}}, false, "ethereum:0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359@1"},
+		{`invalid address`, args{&proto.CryptoRequest{Payment: &proto.CryptoRequest_Bitcoin{Bitcoin: &proto.BitcoinPayment{
+			Address: "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN3",
+		}}}}, true, ""},
+		{`missing payment`, args{&proto.CryptoRequest{}}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.Crypto(ctx, tt.args.req)
+			require.Truef(t, (err != nil) == tt.wantErr, `Crypto() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+				return
+			}
+
+			img, err := png.Decode(bytes.NewReader(got.Image))
+			require.NoError(t, err)
+
+			s, err := qrcode.Decode(img)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, s)
+		})
+	}
+}
+
+func TestOtp(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := newTestClient(ctx, t)
+
+	type args struct {
+		req *proto.OTPRequest
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{`valid`, args{&proto.OTPRequest{Secret: "JBSWY3DPEHPK3PXP", Issuer: "Example", Account: "alice"}}, false},
+		{`generate`, args{&proto.OTPRequest{Generate: true, Issuer: "Example", Account: "alice"}}, false},
+		{`generate with secret`, args{&proto.OTPRequest{Generate: true, Secret: "JBSWY3DPEHPK3PXP", Account: "alice"}}, true},
+		{`missing account`, args{&proto.OTPRequest{Secret: "JBSWY3DPEHPK3PXP"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.Otp(ctx, tt.args.req)
+			require.Truef(t, (err != nil) == tt.wantErr, `Otp() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+				return
+			}
+
+			require.NotEmpty(t, got.Secret)
+			require.Equal(t, "otpauth://totp/Example:alice?secret="+got.Secret+"&issuer=Example", got.Uri)
+
+			img, err := png.Decode(bytes.NewReader(got.Qrcode.Image))
+			require.NoError(t, err)
+
+			s, err := qrcode.Decode(img)
+			require.NoError(t, err)
+			require.Equal(t, got.Uri, s)
+		})
+	}
+}
+
+func TestContact(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := newTestClient(ctx, t)
+
+	type args struct {
+		req *proto.ContactRequest
+	}
+	tests := [...]struct {
+		name         string
+		args         args
+		wantErr      bool
+		wantContains string
+	}{
+		{`vcard`, args{&proto.ContactRequest{Card: &proto.Card{LastName: "Doe", FirstName: "John"}}}, false, "BEGIN:VCARD\r\nVERSION:4.0\r\n"},
+		{`vcard3`, args{&proto.ContactRequest{Card: &proto.Card{LastName: "Doe", FirstName: "John"}, Format: "vcard3"}}, false, "BEGIN:VCARD\r\nVERSION:3.0\r\n"},
+		{`mecard`, args{&proto.ContactRequest{Card: &proto.Card{
+			LastName: "Doe", FirstName: "John", WorkAddr: &proto.Address{City: "Seoul"},
+		}, Format: "mecard"}}, false, "MECARD:N:Doe,John;ADR:,,,Seoul,,,;;"},
+		{`social profile`, args{&proto.ContactRequest{Card: &proto.Card{
+			LastName: "Doe", SocialProfiles: []*proto.SocialProfile{{Type: "github", Id: "johndoe"}},
+		}}}, false, "\r\nX-SOCIALPROFILE;TYPE=github;x-user=johndoe:https://github.com/johndoe\r\n"},
+		{`unsupported social profile`, args{&proto.ContactRequest{Card: &proto.Card{
+			LastName: "Doe", SocialProfiles: []*proto.SocialProfile{{Type: "unknown", Id: "johndoe"}},
+		}}}, true, ""},
+		{`photo url`, args{&proto.ContactRequest{Card: &proto.Card{
+			LastName: "Doe", Photo: &proto.CardImage{Url: "https://example.com/photo.jpg"},
+		}}}, false, "\r\nPHOTO:https://example.com/photo.jpg\r\n"},
+		{`invalid photo`, args{&proto.ContactRequest{Card: &proto.Card{
+			LastName: "Doe", Photo: &proto.CardImage{Data: []byte("not an image")},
+		}}}, true, ""},
+		{`unknown format`, args{&proto.ContactRequest{Card: &proto.Card{LastName: "Doe"}, Format: "vcard2"}}, true, ""},
+		{`missing card`, args{&proto.ContactRequest{}}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.Contact(ctx, tt.args.req)
+			require.Truef(t, (err != nil) == tt.wantErr, `Contact() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+				return
+			}
+
+			img, err := png.Decode(bytes.NewReader(got.Image))
+			require.NoError(t, err)
+
+			s, err := qrcode.Decode(img)
+			require.NoError(t, err)
+			require.Contains(t, s, tt.wantContains)
+		})
+	}
+}
+
+func TestContactCapacity(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := newTestClient(ctx, t)
+
+	img := image.NewGray(image.Rect(0, 0, 100, 100))
+	buf := new(bytes.Buffer)
+	require.NoError(t, png.Encode(buf, img))
+
+	_, err := client.Contact(ctx, &proto.ContactRequest{Card: &proto.Card{
+		LastName: "Doe", Photo: &proto.CardImage{Data: buf.Bytes(), Budget: 10},
+	}})
+	require.Error(t, err)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestVcard(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := newTestClient(ctx, t)
+
+	type args struct {
+		req *proto.VCardRequest
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+		want    string
+	}{
+		{`vcard`, args{&proto.VCardRequest{Content: &proto.VCardRequest_Vcard{
+			Vcard: "BEGIN:VCARD\r\nVERSION:4.0\r\nN:Doe;John;;;\r\nEND:VCARD\r\n",
+		}}}, false, "BEGIN:VCARD\r\nVERSION:4.0\r\nN:Doe;John;;;\r\nEND:VCARD"},
+		{`jcard`, args{&proto.VCardRequest{Content: &proto.VCardRequest_Jcard{
+			Jcard: `["vcard", [["version", {}, "text", "4.0"], ["n", {}, "text", ["Doe", "John", "", "", ""]]]]`,
+		}}}, false, "BEGIN:VCARD\r\nVERSION:4.0\r\nN:Doe;John;;;\r\nEND:VCARD"},
+		{`jcard as 3.0`, args{&proto.VCardRequest{Content: &proto.VCardRequest_Jcard{
+			Jcard: `["vcard", [["version", {}, "text", "4.0"], ["tel", {"type": "cell", "pref": "1"}, "text", "555-1234"]]]`,
+		}, Version: "3.0"}}, false, "BEGIN:VCARD\r\nVERSION:3.0\r\nTEL;TYPE=CELL,PREF:555-1234\r\nEND:VCARD"},
+		{`invalid jcard`, args{&proto.VCardRequest{Content: &proto.VCardRequest_Jcard{Jcard: `{}`}}}, true, ""},
+		{`unsupported version`, args{&proto.VCardRequest{Content: &proto.VCardRequest_Vcard{
+			Vcard: "BEGIN:VCARD\r\nVERSION:4.0\r\nN:Doe;John;;;\r\nEND:VCARD\r\n",
+		}, Version: "5.0"}}, true, ""},
+		{`missing content`, args{&proto.VCardRequest{}}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.Vcard(ctx, tt.args.req)
+			require.Truef(t, (err != nil) == tt.wantErr, `Vcard() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+				return
+			}
+
+			img, err := png.Decode(bytes.NewReader(got.Image))
+			require.NoError(t, err)
+
+			s, err := qrcode.Decode(img)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, s)
+		})
+	}
+}
+
+func TestEvent(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := newTestClient(ctx, t)
+
+	type args struct {
+		req *proto.EventRequest
+	}
+	tests := [...]struct {
+		name         string
+		args         args
+		wantErr      bool
+		wantFields   []string
+		wantContains string
+	}{
+		{`event`, args{&proto.EventRequest{Event: &proto.Event{Title: "Meeting", Start: "2023-07-14T17:00", Duration: "PT1H", Tz: "Asia/Seoul",
+			Recurrence: &proto.Recurrence{Freq: "monthly", ByMonthDay: []int32{14}}}}}, false, nil,
+			"DTSTART;TZID=Asia/Seoul:20230714T170000\r\n"},
+		{`wrap`, args{&proto.EventRequest{Event: &proto.Event{Title: "Vacation", Start: "2023-07-14", AllDay: true}, Wrap: true}}, false, nil,
+			"BEGIN:VCALENDAR\r\n"},
+		{`invalid fields`, args{&proto.EventRequest{Event: &proto.Event{Start: "2023-07-14T17:00",
+			Attendees: []*proto.Attendee{{Email: "not an email"}}}}}, true, []string{"title", "attendees[0].email"}, ""},
+		{`missing event`, args{&proto.EventRequest{}}, true, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.Event(ctx, tt.args.req)
+			require.Truef(t, (err != nil) == tt.wantErr, `Event() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+				fields := []string{}
+				for _, detail := range status.Convert(err).Details() {
+					for _, violation := range detail.(*errdetails.BadRequest).FieldViolations {
+						fields = append(fields, violation.Field)
+					}
+				}
+				if tt.wantFields != nil {
+					require.Equal(t, tt.wantFields, fields)
+				}
+				return
+			}
+
+			img, err := png.Decode(bytes.NewReader(got.Image))
+			require.NoError(t, err)
+
+			s, err := qrcode.Decode(img)
+			require.NoError(t, err)
+			require.Contains(t, s, tt.wantContains)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/emersion/go-vcard"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"qrcodeapi/pkg/qrcode"
+	"qrcodeapi/proto"
+)
+
+func (s *v1alpha1ServiceImpl) Vcard(ctx context.Context, in *proto.VCardRequest) (*proto.Response, error) {
+	var card vcard.Card
+	var err error
+
+	switch content := in.Content.(type) {
+	case *proto.VCardRequest_Vcard:
+		card, err = vcard.NewDecoder(strings.NewReader(content.Vcard)).Decode()
+	case *proto.VCardRequest_Jcard:
+		card, err = qrcode.UnmarshalJCard([]byte(content.Jcard))
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "vcard or jcard required")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	q, err := qrcode.VCardAs(card, in.Version)
+	if err != nil {
+		var capacityErr *qrcode.CapacityError
+		if errors.As(err, &capacityErr) {
+			return nil, status.Errorf(codes.ResourceExhausted, err.Error())
+		}
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	return s.render(q, in.Width, in.Height, in.Accept)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/whitekid/goxp/log"
 
 	"qrcodeapi/apiserver/grpcserver"
+	"qrcodeapi/pkg/qrcode"
 )
 
 func init() {
@@ -15,6 +16,9 @@ func init() {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// TODO viper와 공존하려면?...
 			bindAddr := cobrax.Apply(cmd.Flags().GetString, "bind_addr")
+			if err := qrcode.LoadSocialNetworks(cobrax.Apply(cmd.Flags().GetString, "social_networks")); err != nil {
+				return err
+			}
 
 			if err := grpcserver.Run(cmd.Context(), bindAddr); err != nil {
 				log.Errorf("%+v", err)
@@ -24,5 +28,6 @@ func init() {
 		},
 	}, []flags.Flag{
 		{"bind_addr", "B", "127.0.0.1:9000", "bind addr"},
+		{"social_networks", "", "", "additional social networks for contact; name=url template with {id}, space separated"},
 	}, nil)
 }
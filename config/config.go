@@ -10,6 +10,11 @@ const (
 	keyBind      = "bind_addr"
 	keyRateLimit = "rate_limit"
 
+	keySocialNetworks = "social_networks"
+	keyBaseURL        = "base_url"
+	keyStorePath      = "store_path"
+	keyStoreQuota     = "store_quota"
+
 	keyGrpcBind = "bind_addr"
 )
 
@@ -17,6 +22,10 @@ var configs = map[string][]flags.Flag{
 	"qrcodeapi": {
 		{keyBind, "B", "127.0.0.1:8000", "bind address"},
 		{keyRateLimit, "", "20", "rate limit"},
+		{keySocialNetworks, "", "", "additional social networks for contact; name=url template with {id}, space separated"},
+		{keyBaseURL, "", "", "public base url of hosted file links; hosted mode is disabled if empty"},
+		{keyStorePath, "", "", "bbolt database file of hosted files; hosted mode is disabled if empty"},
+		{keyStoreQuota, "", "100000", "max hosted files of events and contacts each; 0 for unlimited"},
 	},
 	"grpc-server": {
 		{keyGrpcBind, "B", "127.0.0.1:9000", "bind address"},
@@ -34,3 +43,15 @@ func InitFlagSet(use string, fs *pflag.FlagSet) { flags.InitFlagSet(nil, configs
 
 func BindAddr() string { return viper.GetString(keyBind) }
 func RateLimit() int   { return viper.GetInt(keyRateLimit) }
+
+// SocialNetworks additional social networks; ex) mastodon=https://mastodon.social/@{id} signal=sgnl:{id}
+func SocialNetworks() string { return viper.GetString(keySocialNetworks) }
+
+// BaseURL public base url of hosted file links; ex) https://qr.example.com
+func BaseURL() string { return viper.GetString(keyBaseURL) }
+
+// StorePath bbolt database file of hosted events and contacts; ex) /var/lib/qrcodeapi/qrcodeapi.db
+func StorePath() string { return viper.GetString(keyStorePath) }
+
+// StoreQuota max hosted files of events and contacts each; hosted files are kept forever, so the quota bounds the store
+func StoreQuota() int { return viper.GetInt(keyStoreQuota) }
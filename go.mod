@@ -8,6 +8,7 @@ require (
 	github.com/chai2010/webp v0.0.0-00010101000000-000000000000
 	github.com/emersion/go-vcard v0.0.0-20230626131229-38c18b295bbd
 	github.com/go-openapi/runtime v0.26.0
+	github.com/go-playground/validator/v10 v10.14.1
 	github.com/grpc-ecosystem/go-grpc-middleware v1.4.0
 	github.com/labstack/echo/v4 v4.11.1
 	github.com/makiuchi-d/gozxing v0.1.1
@@ -20,8 +21,11 @@ require (
 	github.com/whitekid/echox v0.0.0-20230717010146-1a326f8ff018
 	github.com/whitekid/goxp v0.0.0-20230803113103-cb3e9964e00a
 	github.com/whitekid/iter v0.0.0-20230727022917-a28e6cf0ed40
+	go.etcd.io/bbolt v1.3.7
 	go.uber.org/zap v1.25.0
+	golang.org/x/crypto v0.11.0
 	golang.org/x/time v0.3.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20230525234030-28d5490b6b19
 	google.golang.org/grpc v1.57.0
 	google.golang.org/protobuf v1.31.0
 	gopkg.in/yaml.v3 v3.0.1
@@ -43,7 +47,6 @@ require (
 	github.com/go-openapi/validate v0.22.1 // indirect
 	github.com/go-playground/locales v0.14.1 // indirect
 	github.com/go-playground/universal-translator v0.18.1 // indirect
-	github.com/go-playground/validator/v10 v10.14.1 // indirect
 	github.com/golang-jwt/jwt v3.2.2+incompatible // indirect
 	github.com/golang/protobuf v1.5.3 // indirect
 	github.com/hashicorp/hcl v1.0.0 // indirect
@@ -68,13 +71,11 @@ require (
 	github.com/valyala/fasttemplate v1.2.2 // indirect
 	go.mongodb.org/mongo-driver v1.11.3 // indirect
 	go.uber.org/multierr v1.10.0 // indirect
-	golang.org/x/crypto v0.11.0 // indirect
 	golang.org/x/exp v0.0.0-20230801115018-d63ba01acd4b // indirect
 	golang.org/x/net v0.13.0 // indirect
 	golang.org/x/sync v0.3.0 // indirect
 	golang.org/x/sys v0.10.0 // indirect
 	golang.org/x/text v0.11.0 // indirect
 	golang.org/x/xerrors v0.0.0-20220907171357-04be3eba64a2 // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20230525234030-28d5490b6b19 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
 )
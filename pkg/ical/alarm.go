@@ -0,0 +1,164 @@
+package ical
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/whitekid/goxp"
+)
+
+// alarm actions
+const (
+	ActionAudio   = "AUDIO"
+	ActionDisplay = "DISPLAY"
+	ActionEmail   = "EMAIL"
+)
+
+// VAlarm rfc5545 3.6.6 Alarm Component
+type VAlarm struct {
+	Action      string   `validate:"required,max=100"` // AUDIO, DISPLAY, EMAIL
+	Trigger     Trigger  // required
+	Description string   `validate:"max=500"` // required for DISPLAY, EMAIL
+	Summary     string   `validate:"max=100"` // required for EMAIL
+	Attendee    string   `validate:"max=100"` // required for EMAIL
+	Duration    Duration // delay between repeats; with Repeat
+	Repeat      int      `validate:"min=0"` // additional repetitions; with Duration
+	Attach      string   `validate:"max=100"`
+
+	Extensions []Property // unknown and X- properties
+}
+
+// Trigger 3.8.6.3 Trigger; relative duration to start or end, or absolute date-time
+//
+//	TRIGGER:-PT15M
+//	TRIGGER;RELATED=END:PT5M
+//	TRIGGER;VALUE=DATE-TIME:19980101T050000Z
+type Trigger struct {
+	Duration Duration
+	Related  string   // START(*), END; for relative trigger
+	DateTime DateTime // absolute trigger, as UTC
+}
+
+const (
+	RelatedStart = "START"
+	RelatedEnd   = "END"
+)
+
+func parseTrigger(params Params, value string) (trigger Trigger, err error) {
+	if strings.EqualFold(params.Get("VALUE"), "DATE-TIME") {
+		trigger.DateTime, err = parseDateTime(value, params, nil)
+		return
+	}
+
+	trigger.Related = strings.ToUpper(params.Get("RELATED"))
+	trigger.Duration, err = parseDuration(value)
+	return
+}
+
+// String returns trigger with parameters, without property name
+func (t *Trigger) String() string {
+	if !t.DateTime.IsZero() {
+		return ";VALUE=DATE-TIME:" + t.DateTime.String()
+	}
+
+	s := ""
+	if t.Related != "" && t.Related != RelatedStart {
+		s = ";RELATED=" + t.Related
+	}
+
+	if t.Duration.Duration == 0 {
+		return s + ":PT0S"
+	}
+	return s + ":" + t.Duration.String()
+}
+
+func (alarm *VAlarm) validate() error {
+	switch alarm.Action {
+	case ActionAudio:
+	case ActionDisplay:
+		if alarm.Description == "" {
+			return errors.New("VALARM: DESCRIPTION required for DISPLAY")
+		}
+	case ActionEmail:
+		if alarm.Description == "" || alarm.Summary == "" || alarm.Attendee == "" {
+			return errors.New("VALARM: DESCRIPTION, SUMMARY and ATTENDEE required for EMAIL")
+		}
+	default:
+		return fmt.Errorf("VALARM: unsupported action %s", alarm.Action)
+	}
+
+	if (alarm.Duration.Duration != 0) != (alarm.Repeat != 0) {
+		return errors.New("VALARM: DURATION and REPEAT must occur together")
+	}
+
+	return nil
+}
+
+// decodeAlarm decode unfolded content lines of VALARM
+func decodeAlarm(s *decodeState, alarm *VAlarm, lines []textLine) error {
+	seen, err := s.decodeComponent("VALARM", lines, &alarm.Extensions, func(cl *contentLine) (bool, error) {
+		name, params, value := cl.name, cl.params, cl.value
+
+		var err error
+		switch name {
+		case "ACTION":
+			alarm.Action = strings.ToUpper(value)
+		case "TRIGGER":
+			alarm.Trigger, err = parseTrigger(params, value)
+		case "DESCRIPTION":
+			alarm.Description = unescape(value)
+		case "SUMMARY":
+			alarm.Summary = unescape(value)
+		case "ATTENDEE":
+			alarm.Attendee = unescape(value)
+		case "DURATION":
+			alarm.Duration, err = parseDuration(value)
+		case "REPEAT":
+			alarm.Repeat, err = parseInteger(value)
+		case "ATTACH":
+			alarm.Attach = unescape(value)
+		default:
+			return false, nil
+		}
+
+		return true, err
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	s.validate(lines, "VALARM", seen, []string{"ACTION", "TRIGGER"}, nil)
+	if s.mode == decodeStrict && seen["ACTION"] {
+		if err := alarm.validate(); err != nil {
+			s.report(beginLine(lines), 1, "", err)
+		}
+	}
+	return nil
+}
+
+func encodeAlarm(w io.Writer, alarm *VAlarm) error {
+	if err := alarm.validate(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "BEGIN:VALARM\r\n")
+	if err := writeFields(w, []*goxp.Tuple2[string, any]{
+		{"ACTION", alarm.Action},
+		{"TRIGGER", alarm.Trigger},
+		{"DESCRIPTION", alarm.Description},
+		{"SUMMARY", alarm.Summary},
+		{"ATTENDEE", alarm.Attendee},
+		{"DURATION", alarm.Duration},
+		{"REPEAT", alarm.Repeat},
+		{"ATTACH", alarm.Attach},
+	}); err != nil {
+		return err
+	}
+	if err := writeExtensions(w, alarm.Extensions); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "END:VALARM\r\n")
+	return nil
+}
@@ -0,0 +1,124 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventAlarm(t *testing.T) {
+	type args struct {
+		data string
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+		want    []*VAlarm
+	}{
+		{`relative`, args{`BEGIN:VEVENT
+SUMMARY:Training session
+BEGIN:VALARM
+ACTION:DISPLAY
+TRIGGER:-PT15M
+DESCRIPTION:Training starts in 15 minutes
+END:VALARM
+END:VEVENT
+`}, false, []*VAlarm{
+			{Action: ActionDisplay, Trigger: Trigger{Duration: Duration{-15 * time.Minute}}, Description: "Training starts in 15 minutes"},
+		}},
+		{`related end with repeat`, args{`BEGIN:VEVENT
+SUMMARY:Training session
+BEGIN:VALARM
+TRIGGER;RELATED=END:PT5M
+REPEAT:4
+DURATION:PT15M
+ACTION:AUDIO
+ATTACH:ftp://example.com/pub/sounds/bell-01.aud
+END:VALARM
+BEGIN:VALARM
+ACTION:EMAIL
+TRIGGER;VALUE=DATE-TIME:19970317T133000Z
+ATTENDEE:mailto:john_doe@example.com
+SUMMARY:*** REMINDER: SEND AGENDA FOR WEEKLY STAFF MEETING ***
+DESCRIPTION:A draft agenda needs to be sent out to the attendees
+END:VALARM
+END:VEVENT
+`}, false, []*VAlarm{
+			{
+				Action:   ActionAudio,
+				Trigger:  Trigger{Duration: Duration{5 * time.Minute}, Related: RelatedEnd},
+				Repeat:   4,
+				Duration: Duration{15 * time.Minute},
+				Attach:   "ftp://example.com/pub/sounds/bell-01.aud",
+			},
+			{
+				Action:      ActionEmail,
+				Trigger:     Trigger{DateTime: mustParseDateTime("19970317T133000Z")},
+				Attendee:    "mailto:john_doe@example.com",
+				Summary:     "*** REMINDER: SEND AGENDA FOR WEEKLY STAFF MEETING ***",
+				Description: "A draft agenda needs to be sent out to the attendees",
+			},
+		}},
+		{`invalid trigger`, args{"BEGIN:VEVENT\nBEGIN:VALARM\nACTION:AUDIO\nTRIGGER:soon\nEND:VALARM\nEND:VEVENT\n"}, true, nil},
+		{`missing alarm end`, args{"BEGIN:VEVENT\nBEGIN:VALARM\nACTION:AUDIO\nEND:VEVENT\n"}, true, nil},
+		{`unsupported component`, args{"BEGIN:VEVENT\nBEGIN:VTODO\nEND:VTODO\nEND:VEVENT\n"}, true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := new(VEvent)
+			err := NewEventDecoder(strings.NewReader(tt.args.data)).Decode(got)
+			require.Truef(t, (err != nil) == tt.wantErr, `Decode() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			require.Equal(t, "Training session", got.Summary.Value)
+			require.Equal(t, tt.want, got.Alarms)
+
+			buf := new(bytes.Buffer)
+			require.NoError(t, NewEventEncoder(buf).Encode(got))
+
+			decoded := new(VEvent)
+			require.NoError(t, NewEventDecoder(buf).Decode(decoded))
+			require.Equal(t, got, decoded)
+		})
+	}
+}
+
+func TestAlarmEncode(t *testing.T) {
+	type args struct {
+		alarm *VAlarm
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+		want    string
+	}{
+		{`at start`, args{&VAlarm{Action: ActionAudio}}, false, "BEGIN:VALARM\nACTION:AUDIO\nTRIGGER:PT0S\nEND:VALARM\n"},
+		{`related end`, args{&VAlarm{Action: ActionDisplay, Description: "x", Trigger: Trigger{Duration: Duration{-5 * time.Minute}, Related: RelatedEnd}}}, false,
+			"BEGIN:VALARM\nACTION:DISPLAY\nTRIGGER;RELATED=END:-PT5M\nDESCRIPTION:x\nEND:VALARM\n"},
+		{`absolute`, args{&VAlarm{Action: ActionAudio, Trigger: Trigger{DateTime: mustParseDateTime("19970317T133000Z")}}}, false,
+			"BEGIN:VALARM\nACTION:AUDIO\nTRIGGER;VALUE=DATE-TIME:19970317T133000Z\nEND:VALARM\n"},
+		{`display without description`, args{&VAlarm{Action: ActionDisplay}}, true, ""},
+		{`email without attendee`, args{&VAlarm{Action: ActionEmail, Description: "x", Summary: "x"}}, true, ""},
+		{`repeat without duration`, args{&VAlarm{Action: ActionAudio, Repeat: 2}}, true, ""},
+		{`unsupported action`, args{&VAlarm{Action: "PROCEDURE"}}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := encodeAlarm(buf, tt.args.alarm)
+			require.Truef(t, (err != nil) == tt.wantErr, `encodeAlarm() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			require.Equal(t, strings.ReplaceAll(tt.want, "\n", "\r\n"), buf.String())
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package ical
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/whitekid/goxp"
+)
+
+const (
+	DefaultProdID  = "-//whitekid//qrcodeapi//EN"
+	DefaultVersion = "2.0"
+)
+
+// VCalendar rfc5545 3.4 iCalendar Object
+type VCalendar struct {
+	ProdID   string `validate:"max=100"` // 3.7.3 Product Identifier; DefaultProdID if empty
+	Version  string `validate:"max=10"`  // 3.7.4 Version; DefaultVersion if empty
+	CalScale string `validate:"max=100"` // 3.7.1 Calendar Scale, GREGORIAN(*)
+	Method   string `validate:"max=100"` // 3.7.2 Method, PUBLISH, REQUEST, ...
+
+	// Timezones are written as is; VTIMEZONE of TZID referenced by components are generated if not given
+	Timezones []*VTimezone
+	Events    []*VEvent
+	Todos     []*VTodo
+	Journals  []*VJournal
+
+	Extensions []Property // unknown and X- properties; ex. X-WR-CALNAME
+}
+
+type calendarDecoder struct {
+	r    io.Reader
+	opts []DecodeOption
+}
+
+var _ Decoder = (*calendarDecoder)(nil)
+
+func NewCalendarDecoder(r io.Reader, opts ...DecodeOption) Decoder {
+	return &calendarDecoder{r: r, opts: opts}
+}
+
+func (d *calendarDecoder) Decode(v any) error {
+	cal, ok := v.(*VCalendar)
+	if !ok {
+		return fmt.Errorf("v must be pointer of VCalendar")
+	}
+
+	lines, err := readLines(d.r)
+	if err != nil {
+		return err
+	}
+
+	// not a calendar even in lenient mode
+	if len(lines) == 0 || lines[0].text != "BEGIN:VCALENDAR" {
+		return DecodeErrors{{Line: beginLine(lines).no, Column: 1, Message: "BEGIN:VCALENDAR required"}}
+	}
+
+	s := newDecodeState(d.opts)
+	if last := lines[len(lines)-1]; len(lines) == 1 || last.text != "END:VCALENDAR" {
+		if err := s.report(last, 1, "", errors.New("END:VCALENDAR required")); err != nil {
+			return err
+		}
+		lines = append(lines, textLine{text: "END:VCALENDAR", no: last.no + 1})
+	}
+
+	s.timezones = calendarTimezones(lines)
+
+	seen, err := s.decodeComponent("VCALENDAR", lines, &cal.Extensions, func(cl *contentLine) (bool, error) {
+		switch cl.name {
+		case "PRODID":
+			cal.ProdID = unescape(cl.value)
+		case "VERSION":
+			cal.Version = unescape(cl.value)
+		case "CALSCALE":
+			cal.CalScale = unescape(cl.value)
+		case "METHOD":
+			cal.Method = unescape(cl.value)
+		default:
+			return false, nil
+		}
+		return true, nil
+	}, func(name string, component []textLine) (bool, error) {
+		switch name {
+		case "VTIMEZONE":
+			tz := new(VTimezone)
+			if err := decodeTimezone(s, tz, component); err != nil {
+				return true, err
+			}
+			cal.Timezones = append(cal.Timezones, tz)
+		case "VEVENT":
+			evt := new(VEvent)
+			if err := decodeEvent(s, evt, component); err != nil {
+				return true, err
+			}
+			cal.Events = append(cal.Events, evt)
+		case "VTODO":
+			todo := new(VTodo)
+			if err := decodeTodo(s, todo, component); err != nil {
+				return true, err
+			}
+			cal.Todos = append(cal.Todos, todo)
+		case "VJOURNAL":
+			journal := new(VJournal)
+			if err := decodeJournal(s, journal, component); err != nil {
+				return true, err
+			}
+			cal.Journals = append(cal.Journals, journal)
+		default:
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.validate(lines, "VCALENDAR", seen, []string{"PRODID", "VERSION"}, nil)
+	return s.err()
+}
+
+// calendarTimezones returns locations of VTIMEZONE of TZID unknown to tzdata; VTIMEZONE may follow components referencing it
+// invalid VTIMEZONE is skipped here and reported when it is decoded
+func calendarTimezones(lines []textLine) timezones {
+	tzs := timezones{}
+	for i := 0; i < len(lines); i++ {
+		if lines[i].text != "BEGIN:VTIMEZONE" {
+			continue
+		}
+
+		component, _, err := cutComponent(lines[i:])
+		if err != nil {
+			break
+		}
+		i += len(component) - 1
+
+		tz := new(VTimezone)
+		if err := decodeTimezone(&decodeState{mode: decodeLenient}, tz, component); err != nil || tz.TZID == "" {
+			continue
+		}
+		if _, err := time.LoadLocation(tz.TZID); err == nil {
+			continue
+		}
+		if loc, err := tz.location(); err == nil {
+			tzs[tz.TZID] = loc
+		}
+	}
+
+	return tzs
+}
+
+// cutComponent returns lines of the component starts with BEGIN and the rest lines
+func cutComponent(lines []textLine) (component []textLine, rest []textLine, err error) {
+	name := strings.TrimPrefix(lines[0].text, "BEGIN:")
+	depth := 0
+	for i, line := range lines {
+		switch line.text {
+		case "BEGIN:" + name:
+			depth++
+		case "END:" + name:
+			depth--
+			if depth == 0 {
+				return lines[:i+1], lines[i+1:], nil
+			}
+		}
+	}
+
+	return nil, nil, fmt.Errorf("END:%s required", name)
+}
+
+type calendarEncoder struct {
+	w io.Writer
+}
+
+var _ Encoder = (*calendarEncoder)(nil)
+
+func NewCalendarEncoder(w io.Writer) Encoder {
+	return &calendarEncoder{w: w}
+}
+
+func (enc *calendarEncoder) Encode(v any) error {
+	cal, ok := v.(*VCalendar)
+	if !ok {
+		return fmt.Errorf("want *VCalendar")
+	}
+
+	fmt.Fprintf(enc.w, "BEGIN:VCALENDAR\r\n")
+	if err := writeFields(enc.w, []*goxp.Tuple2[string, any]{
+		{"PRODID", goxp.Ternary(cal.ProdID != "", cal.ProdID, DefaultProdID)},
+		{"VERSION", goxp.Ternary(cal.Version != "", cal.Version, DefaultVersion)},
+		{"CALSCALE", cal.CalScale},
+		{"METHOD", cal.Method},
+	}); err != nil {
+		return err
+	}
+	if err := writeExtensions(enc.w, cal.Extensions); err != nil {
+		return err
+	}
+
+	timezones := append([]*VTimezone{}, cal.Timezones...)
+	given := map[string]bool{}
+	for _, tz := range cal.Timezones {
+		given[tz.TZID] = true
+	}
+	for _, tz := range timezonesOf(cal) {
+		if !given[tz.TZID] {
+			timezones = append(timezones, tz)
+		}
+	}
+	for _, tz := range timezones {
+		if err := encodeTimezone(enc.w, tz); err != nil {
+			return err
+		}
+	}
+
+	for _, evt := range cal.Events {
+		if err := NewEventEncoder(enc.w).Encode(evt); err != nil {
+			return err
+		}
+	}
+	for _, todo := range cal.Todos {
+		if err := NewTodoEncoder(enc.w).Encode(todo); err != nil {
+			return err
+		}
+	}
+	for _, journal := range cal.Journals {
+		if err := NewJournalEncoder(enc.w).Encode(journal); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(enc.w, "END:VCALENDAR\r\n")
+
+	return nil
+}
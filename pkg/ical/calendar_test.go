@@ -0,0 +1,239 @@
+package ical
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarDecode(t *testing.T) {
+	type args struct {
+		data string
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+		want    *VCalendar
+	}{
+		{`valid`, args{`BEGIN:VCALENDAR
+PRODID:-//xyz Corp//NONSGML PDA Calendar Version 1.0//EN
+VERSION:2.0
+CALSCALE:GREGORIAN
+METHOD:PUBLISH
+BEGIN:VEVENT
+UID:19970610T172345Z-AF23B2@example.com
+DTSTAMP:19970610T172345Z
+DTSTART:19970714T170000Z
+DTEND:19970715T040000Z
+SUMMARY:Bastille Day Party
+END:VEVENT
+BEGIN:VEVENT
+UID:19970610T172345Z-AF23B3@example.com
+DTSTAMP:19970610T172345Z
+DTSTART:19970715T170000Z
+SUMMARY:After Party
+END:VEVENT
+BEGIN:VTODO
+UID:20070313T123432Z-456553@example.com
+DTSTAMP:20070313T123432Z
+SUMMARY:Clean up
+END:VTODO
+BEGIN:VJOURNAL
+UID:19970901T130000Z-123405@example.com
+DTSTAMP:19970901T130000Z
+SUMMARY:Minutes
+END:VJOURNAL
+END:VCALENDAR
+`}, false, &VCalendar{
+			ProdID:   "-//xyz Corp//NONSGML PDA Calendar Version 1.0//EN",
+			Version:  "2.0",
+			CalScale: "GREGORIAN",
+			Method:   "PUBLISH",
+			Events: []*VEvent{
+				{
+					UID:     "19970610T172345Z-AF23B2@example.com",
+					DtStamp: DateTime{Time: time.Date(1997, 6, 10, 17, 23, 45, 0, time.UTC)},
+					DtStart: DateTime{Time: time.Date(1997, 7, 14, 17, 0, 0, 0, time.UTC)},
+					DtEnd:   DateTime{Time: time.Date(1997, 7, 15, 4, 0, 0, 0, time.UTC)},
+					Summary: Text{Value: "Bastille Day Party"},
+				},
+				{
+					UID:     "19970610T172345Z-AF23B3@example.com",
+					DtStamp: DateTime{Time: time.Date(1997, 6, 10, 17, 23, 45, 0, time.UTC)},
+					DtStart: DateTime{Time: time.Date(1997, 7, 15, 17, 0, 0, 0, time.UTC)},
+					Summary: Text{Value: "After Party"},
+				},
+			},
+			Todos: []*VTodo{{
+				UID:     "20070313T123432Z-456553@example.com",
+				DtStamp: DateTime{Time: time.Date(2007, 3, 13, 12, 34, 32, 0, time.UTC)},
+				Summary: "Clean up",
+			}},
+			Journals: []*VJournal{{
+				UID:     "19970901T130000Z-123405@example.com",
+				DtStamp: DateTime{Time: time.Date(1997, 9, 1, 13, 0, 0, 0, time.UTC)},
+				Summary: "Minutes",
+			}},
+		}},
+		{`missing begin`, args{"PRODID:-//xyz//EN\nEND:VCALENDAR\n"}, true, nil},
+		{`missing end`, args{"BEGIN:VCALENDAR\nPRODID:-//xyz//EN\n"}, true, nil},
+		{`missing component end`, args{"BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:x\nEND:VCALENDAR\n"}, true, nil},
+		{`unknown field`, args{"BEGIN:VCALENDAR\nUNKNOWN:x\nX-WR-CALNAME;VALUE=TEXT:Work\nEND:VCALENDAR\n"}, false, &VCalendar{
+			Extensions: []Property{
+				{Name: "UNKNOWN", Value: "x"},
+				{Name: "X-WR-CALNAME", Params: ";VALUE=TEXT", Value: "Work"},
+			},
+		}},
+		{`unsupported component`, args{"BEGIN:VCALENDAR\nBEGIN:VUNKNOWN\nEND:VUNKNOWN\nEND:VCALENDAR\n"}, true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := new(VCalendar)
+			err := NewCalendarDecoder(strings.NewReader(tt.args.data)).Decode(got)
+			require.Truef(t, (err != nil) == tt.wantErr, `Decode() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCalendarEncode(t *testing.T) {
+	cal := &VCalendar{
+		Method: "PUBLISH",
+		Events: []*VEvent{
+			{
+				UID:     "uid1@example.com",
+				DtStamp: DateTime{Time: time.Date(1997, 6, 10, 17, 23, 45, 0, time.UTC)},
+				DtStart: DateTime{Time: time.Date(1997, 7, 14, 17, 0, 0, 0, time.UTC)},
+				Summary: Text{Value: "Bastille Day Party"},
+			},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, NewCalendarEncoder(buf).Encode(cal))
+	require.Equal(t, strings.ReplaceAll(`BEGIN:VCALENDAR
+PRODID:-//whitekid//qrcodeapi//EN
+VERSION:2.0
+METHOD:PUBLISH
+BEGIN:VEVENT
+DTSTART:19970714T170000Z
+DTSTAMP:19970610T172345Z
+SUMMARY:Bastille Day Party
+UID:uid1@example.com
+END:VEVENT
+END:VCALENDAR
+`, "\n", "\r\n"), buf.String())
+
+	got := new(VCalendar)
+	require.NoError(t, NewCalendarDecoder(buf).Decode(got))
+
+	cal.ProdID, cal.Version = DefaultProdID, DefaultVersion
+	require.Equal(t, cal, got)
+}
+
+func TestCalendarEncodeTimezone(t *testing.T) {
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	require.NoError(t, err)
+
+	cal := &VCalendar{
+		Events: []*VEvent{
+			{
+				UID:     "uid1@example.com",
+				DtStamp: DateTime{Time: time.Date(2023, 6, 10, 17, 23, 45, 0, time.UTC)},
+				DtStart: DateTime{Time: time.Date(2023, 7, 14, 17, 0, 0, 0, seoul)},
+				DtEnd:   DateTime{Time: time.Date(2023, 7, 14, 18, 0, 0, 0, seoul)},
+				Summary: Text{Value: "Meeting"},
+			},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, NewCalendarEncoder(buf).Encode(cal))
+	require.Equal(t, strings.ReplaceAll(`BEGIN:VCALENDAR
+PRODID:-//whitekid//qrcodeapi//EN
+VERSION:2.0
+BEGIN:VTIMEZONE
+TZID:Asia/Seoul
+BEGIN:STANDARD
+DTSTART:19700101T000000
+TZOFFSETFROM:+0900
+TZOFFSETTO:+0900
+TZNAME:KST
+END:STANDARD
+END:VTIMEZONE
+BEGIN:VEVENT
+DTSTART;TZID=Asia/Seoul:20230714T170000
+DTSTAMP:20230610T172345Z
+SUMMARY:Meeting
+UID:uid1@example.com
+DTEND;TZID=Asia/Seoul:20230714T180000
+END:VEVENT
+END:VCALENDAR
+`, "\n", "\r\n"), buf.String())
+
+	got := new(VCalendar)
+	require.NoError(t, NewCalendarDecoder(bytes.NewReader(buf.Bytes())).Decode(got))
+	require.Len(t, got.Timezones, 1)
+	require.Equal(t, cal.Events, got.Events)
+
+	// given timezone is not generated again
+	buf.Reset()
+	require.NoError(t, NewCalendarEncoder(buf).Encode(got))
+	require.Equal(t, 1, strings.Count(buf.String(), "BEGIN:VTIMEZONE"))
+}
+
+// outlook uses windows time zone names with VTIMEZONE
+func TestCalendarDecodeOutlook(t *testing.T) {
+	losAngeles, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+
+	for _, opts := range [][]DecodeOption{nil, {Strict()}, {Lenient()}} {
+		f, err := os.Open("fixtures/outlook.ics")
+		require.NoError(t, err)
+		defer f.Close()
+
+		cal := new(VCalendar)
+		require.NoError(t, NewCalendarDecoder(f, opts...).Decode(cal))
+		require.Len(t, cal.Events, 1)
+
+		evt := cal.Events[0]
+		require.Equal(t, "Pacific Standard Time", evt.DtStart.TZID())
+		require.True(t, time.Date(2024, 1, 8, 10, 0, 0, 0, losAngeles).Equal(evt.DtStart.Time), evt.DtStart.UTC())
+		require.True(t, time.Date(2024, 1, 8, 11, 30, 0, 0, losAngeles).Equal(evt.DtEnd.Time), evt.DtEnd.UTC())
+		require.Len(t, evt.ExDates, 1)
+		require.True(t, time.Date(2024, 2, 12, 10, 0, 0, 0, losAngeles).Equal(evt.ExDates[0].Time))
+
+		// offsets of the rules
+		for _, tm := range []time.Time{
+			time.Date(2024, 3, 10, 1, 59, 0, 0, losAngeles),
+			time.Date(2024, 3, 10, 3, 0, 0, 0, losAngeles),
+			time.Date(2024, 7, 1, 12, 0, 0, 0, losAngeles),
+			time.Date(2024, 11, 3, 1, 30, 0, 0, losAngeles).Add(time.Hour),
+			time.Date(2024, 11, 3, 2, 0, 0, 0, losAngeles),
+			time.Date(2035, 7, 1, 12, 0, 0, 0, losAngeles),
+		} {
+			_, want := tm.Zone()
+			_, got := tm.In(evt.DtStart.Location()).Zone()
+			require.Equal(t, want, got, tm)
+		}
+
+		// given VTIMEZONE is written as is with TZID
+		buf := new(bytes.Buffer)
+		require.NoError(t, NewCalendarEncoder(buf).Encode(cal))
+		require.Contains(t, buf.String(), "DTSTART;TZID=Pacific Standard Time:20240108T100000\r\n")
+		require.Equal(t, 1, strings.Count(buf.String(), "BEGIN:VTIMEZONE"))
+
+		got := new(VCalendar)
+		require.NoError(t, NewCalendarDecoder(buf).Decode(got))
+		require.True(t, evt.DtStart.Equal(got.Events[0].DtStart.Time))
+	}
+}
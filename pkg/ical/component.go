@@ -0,0 +1,462 @@
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// rawComponent component of content lines; jCal and xCal are converted through it to reuse text decoders and encoders
+type rawComponent struct {
+	name       string // upper case, ex. VEVENT
+	properties []*contentLine
+	components []*rawComponent
+}
+
+// parseRawComponent parse lines of a component starts with BEGIN and ends with END
+func parseRawComponent(lines []textLine) (*rawComponent, error) {
+	if len(lines) == 0 {
+		return nil, errors.New("component required")
+	}
+
+	name, ok := strings.CutPrefix(lines[0].text, "BEGIN:")
+	if !ok {
+		return nil, fmt.Errorf("line %d: BEGIN required", lines[0].no)
+	}
+
+	lines, rest, err := cutComponent(lines)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("line %d: unexpected content after END:%s", rest[0].no, name)
+	}
+
+	c := &rawComponent{name: name}
+	lines = lines[1 : len(lines)-1]
+	for i := 0; i < len(lines); i++ {
+		if strings.HasPrefix(lines[i].text, "BEGIN:") {
+			sub, rest, err := cutComponent(lines[i:])
+			if err != nil {
+				return nil, err
+			}
+			i = len(lines) - len(rest) - 1
+
+			child, err := parseRawComponent(sub)
+			if err != nil {
+				return nil, err
+			}
+			c.components = append(c.components, child)
+			continue
+		}
+
+		cl, err := parseContentLine(lines[i].text)
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", lines[i].no)
+		}
+		c.properties = append(c.properties, cl)
+	}
+
+	return c, nil
+}
+
+// write write the component as content lines
+func (c *rawComponent) write(w io.Writer) error {
+	if !isName(c.name) {
+		return fmt.Errorf("invalid component name: %s", c.name)
+	}
+
+	if err := writeLine(w, "BEGIN:"+c.name); err != nil {
+		return err
+	}
+
+	for _, p := range c.properties {
+		if !isName(p.name) || strings.ContainsAny(p.value, "\r\n") {
+			return fmt.Errorf("invalid property: %s", p.name)
+		}
+		for name, values := range p.params {
+			if !isName(name) || strings.ContainsAny(strings.Join(values, ""), "\r\n") {
+				return fmt.Errorf("invalid parameter: %s", name)
+			}
+		}
+
+		if err := writeLine(w, p.name+p.params.String()+":"+p.value); err != nil {
+			return err
+		}
+	}
+
+	for _, sub := range c.components {
+		if err := sub.write(w); err != nil {
+			return err
+		}
+	}
+
+	return writeLine(w, "END:"+c.name)
+}
+
+// value data types of jCal and xCal, 3.3 Property Value Data Types in lower case
+const (
+	typeBinary     = "binary"
+	typeBoolean    = "boolean"
+	typeCalAddress = "cal-address"
+	typeDate       = "date"
+	typeDateTime   = "date-time"
+	typeDuration   = "duration"
+	typeFloat      = "float"
+	typeInteger    = "integer"
+	typePeriod     = "period"
+	typeRecur      = "recur"
+	typeText       = "text"
+	typeTime       = "time"
+	typeURI        = "uri"
+	typeUTCOffset  = "utc-offset"
+	typeUnknown    = "unknown" // value of unknown property, as is
+)
+
+// defaultValueTypes default value type of properties; properties not listed are unknown
+var defaultValueTypes = map[string]string{
+	"ACTION":           typeText,
+	"ATTACH":           typeURI,
+	"ATTENDEE":         typeCalAddress,
+	"CALSCALE":         typeText,
+	"CATEGORIES":       typeText,
+	"CLASS":            typeText,
+	"COMMENT":          typeText,
+	"COMPLETED":        typeDateTime,
+	"CONTACT":          typeText,
+	"CREATED":          typeDateTime,
+	"DESCRIPTION":      typeText,
+	"DTEND":            typeDateTime,
+	"DTSTAMP":          typeDateTime,
+	"DTSTART":          typeDateTime,
+	"DUE":              typeDateTime,
+	"DURATION":         typeDuration,
+	"EXDATE":           typeDateTime,
+	"EXRULE":           typeRecur,
+	"FREEBUSY":         typePeriod,
+	"GEO":              typeFloat,
+	"LAST-MODIFIED":    typeDateTime,
+	"LOCATION":         typeText,
+	"METHOD":           typeText,
+	"ORGANIZER":        typeCalAddress,
+	"PERCENT-COMPLETE": typeInteger,
+	"PRIORITY":         typeInteger,
+	"PRODID":           typeText,
+	"RDATE":            typeDateTime,
+	"RECURRENCE-ID":    typeDateTime,
+	"RELATED-TO":       typeText,
+	"REPEAT":           typeInteger,
+	"REQUEST-STATUS":   typeText,
+	"RESOURCES":        typeText,
+	"RRULE":            typeRecur,
+	"SEQUENCE":         typeInteger,
+	"STATUS":           typeText,
+	"SUMMARY":          typeText,
+	"TRANSP":           typeText,
+	"TRIGGER":          typeDuration,
+	"TZID":             typeText,
+	"TZNAME":           typeText,
+	"TZOFFSETFROM":     typeUTCOffset,
+	"TZOFFSETTO":       typeUTCOffset,
+	"TZURL":            typeURI,
+	"UID":              typeText,
+	"URL":              typeURI,
+	"VERSION":          typeText,
+}
+
+// listProperties properties of comma separated values; each value is an element in jCal and xCal
+var listProperties = map[string]bool{
+	"CATEGORIES": true,
+	"EXDATE":     true,
+	"FREEBUSY":   true,
+	"RDATE":      true,
+	"RESOURCES":  true,
+}
+
+// valueType returns value type of the property; VALUE parameter or default type
+func valueType(cl *contentLine) string {
+	if v := cl.params.Get("VALUE"); v != "" {
+		return strings.ToLower(v)
+	}
+	if typ, ok := defaultValueTypes[cl.name]; ok {
+		return typ
+	}
+	return typeUnknown
+}
+
+// setValueType set VALUE parameter if the type is not default type of the property
+func setValueType(cl *contentLine, typ string) {
+	delete(cl.params, "VALUE")
+	if typ == typeUnknown || typ == defaultValueTypes[cl.name] {
+		return
+	}
+	cl.params["VALUE"] = []string{strings.ToUpper(typ)}
+}
+
+// splitValues returns values of the property; comma separated values of list properties or a value
+func splitValues(cl *contentLine) []string {
+	if !listProperties[cl.name] {
+		return []string{cl.value}
+	}
+	return splitUnescaped(cl.value, ',')
+}
+
+// splitUnescaped split s by sep which is not escaped by backslash
+func splitUnescaped(s string, sep byte) []string {
+	values := []string{}
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case sep:
+			values = append(values, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(values, s[start:])
+}
+
+// layouts of basic format in iCalendar and extended format in jCal and xCal
+var valueLayouts = map[string][2]string{
+	typeDate:     {"20060102", "2006-01-02"},
+	typeDateTime: {"20060102T150405", "2006-01-02T15:04:05"},
+	typeTime:     {"150405", "15:04:05"},
+}
+
+// toExtended convert iCalendar value to the value of jCal and xCal
+//
+//	DATE-TIME: 19970903T163000Z -> 1997-09-03T16:30:00Z
+//	UTC-OFFSET: -0500 -> -05:00
+//	TEXT: unescaped
+func toExtended(typ, value string) (string, error) {
+	switch typ {
+	case typeDate, typeDateTime, typeTime:
+		return convertLayout(value, valueLayouts[typ][0], valueLayouts[typ][1])
+	case typeUTCOffset:
+		offset, err := parseUTCOffset(value)
+		if err != nil {
+			return "", err
+		}
+		s := offset.String()
+		for i := len(s) - 2; i > 1; i -= 2 {
+			s = s[:i] + ":" + s[i:]
+		}
+		return s, nil
+	case typePeriod:
+		start, end, ok := strings.Cut(value, "/")
+		if !ok {
+			return "", fmt.Errorf("invalid period: %s", value)
+		}
+		return convertPeriod(start, end, func(v string) (string, error) { return toExtended(typeDateTime, v) })
+	case typeText:
+		return unescape(value), nil
+	}
+	return value, nil
+}
+
+// fromExtended convert the value of jCal and xCal to iCalendar value
+func fromExtended(typ, value string) (string, error) {
+	switch typ {
+	case typeDate, typeDateTime, typeTime:
+		return convertLayout(value, valueLayouts[typ][1], valueLayouts[typ][0])
+	case typeUTCOffset:
+		offset, err := parseUTCOffset(strings.ReplaceAll(value, ":", ""))
+		if err != nil {
+			return "", err
+		}
+		return offset.String(), nil
+	case typePeriod:
+		start, end, ok := strings.Cut(value, "/")
+		if !ok {
+			return "", fmt.Errorf("invalid period: %s", value)
+		}
+		return convertPeriod(start, end, func(v string) (string, error) { return fromExtended(typeDateTime, v) })
+	case typeText:
+		return escape(value), nil
+	}
+	return value, nil
+}
+
+// convertLayout convert date or time value between layouts; UTC designator Z is kept
+func convertLayout(value, from, to string) (string, error) {
+	s, utc := strings.CutSuffix(value, "Z")
+	tm, err := time.Parse(from, s)
+	if err != nil {
+		return "", fmt.Errorf("invalid value: %s", value)
+	}
+
+	s = tm.Format(to)
+	if utc {
+		s += "Z"
+	}
+	return s, nil
+}
+
+// convertPeriod convert start and end of period; end may be duration
+func convertPeriod(start, end string, convert func(string) (string, error)) (string, error) {
+	start, err := convert(start)
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.HasPrefix(strings.TrimLeft(end, "+-"), "P") {
+		if end, err = convert(end); err != nil {
+			return "", err
+		}
+	}
+	return start + "/" + end, nil
+}
+
+// recurPart part of RECUR value; key is lower case
+type recurPart struct {
+	key    string
+	values []string
+}
+
+// recurIntegerParts parts of integer values in RECUR
+var recurIntegerParts = map[string]bool{
+	"count":      true,
+	"interval":   true,
+	"bysecond":   true,
+	"byminute":   true,
+	"byhour":     true,
+	"bymonthday": true,
+	"byyearday":  true,
+	"byweekno":   true,
+	"bymonth":    true,
+	"bysetpos":   true,
+}
+
+// splitRecur split RECUR value to parts; UNTIL is converted to the extended format
+//
+//	FREQ=WEEKLY;UNTIL=19971007T000000Z;BYDAY=TU,TH
+func splitRecur(value string) ([]recurPart, error) {
+	parts := []recurPart{}
+	for _, part := range strings.Split(value, ";") {
+		key, v, ok := strings.Cut(part, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid recur: %s", value)
+		}
+		key = strings.ToLower(key)
+
+		if key == "until" {
+			typ := typeDateTime
+			if !strings.Contains(v, "T") {
+				typ = typeDate
+			}
+			until, err := toExtended(typ, v)
+			if err != nil {
+				return nil, err
+			}
+			v = until
+		}
+		parts = append(parts, recurPart{key: key, values: strings.Split(v, ",")})
+	}
+	return parts, nil
+}
+
+// joinRecur join parts to RECUR value; FREQ first
+func joinRecur(parts []recurPart) (string, error) {
+	values := []string{}
+	for _, part := range parts {
+		if len(part.values) == 0 || !isName(part.key) {
+			return "", fmt.Errorf("invalid recur part: %s", part.key)
+		}
+
+		v := strings.Join(part.values, ",")
+		if part.key == "until" {
+			typ := typeDateTime
+			if !strings.Contains(v, "T") {
+				typ = typeDate
+			}
+			until, err := fromExtended(typ, v)
+			if err != nil {
+				return "", err
+			}
+			v = until
+		}
+
+		v = strings.ToUpper(part.key) + "=" + v
+		if part.key == "freq" {
+			values = append([]string{v}, values...)
+		} else {
+			values = append(values, v)
+		}
+	}
+	return strings.Join(values, ";"), nil
+}
+
+// structuredProperties properties of structured value separated by ';'; array in jCal, child elements in xCal
+var structuredProperties = map[string]bool{
+	"GEO":            true,
+	"REQUEST-STATUS": true,
+}
+
+// encodeRawComponent encode component by the text encoder and parse it to rawComponent
+func encodeRawComponent(v any) (*rawComponent, error) {
+	var enc func(io.Writer) Encoder
+	switch v.(type) {
+	case *VCalendar:
+		enc = NewCalendarEncoder
+	case *VEvent:
+		enc = NewEventEncoder
+	case *VTodo:
+		enc = NewTodoEncoder
+	case *VJournal:
+		enc = NewJournalEncoder
+	default:
+		return nil, fmt.Errorf("unsupported type: %T", v)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := enc(buf).Encode(v); err != nil {
+		return nil, err
+	}
+
+	lines, err := readLines(buf)
+	if err != nil {
+		return nil, err
+	}
+	return parseRawComponent(lines)
+}
+
+// decodeRawComponent write rawComponent as content lines and decode it by the text decoder
+// positions of DecodeError are cleared because they are of the generated content lines
+func decodeRawComponent(c *rawComponent, v any, opts []DecodeOption) error {
+	var dec func(io.Reader, ...DecodeOption) Decoder
+	var name string
+	switch v.(type) {
+	case *VCalendar:
+		dec, name = NewCalendarDecoder, "VCALENDAR"
+	case *VEvent:
+		dec, name = NewEventDecoder, "VEVENT"
+	case *VTodo:
+		dec, name = NewTodoDecoder, "VTODO"
+	case *VJournal:
+		dec, name = NewJournalDecoder, "VJOURNAL"
+	default:
+		return fmt.Errorf("unsupported type: %T", v)
+	}
+	if c.name != name {
+		return fmt.Errorf("%s required but %s", name, c.name)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := c.write(buf); err != nil {
+		return err
+	}
+
+	err := dec(buf, opts...).Decode(v)
+	var errs DecodeErrors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			e.Line, e.Column = 0, 0
+		}
+	}
+	return err
+}
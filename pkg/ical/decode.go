@@ -0,0 +1,248 @@
+package ical
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// textLine unfolded content line with line number of the first folded line
+type textLine struct {
+	text string
+	no   int
+}
+
+// DecodeError error of content line; Line and Column are 1-based and column is counted in characters of unfolded line
+// Line and Column are zero for jCal and xCal
+type DecodeError struct {
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Property string `json:"property,omitempty"`
+	Message  string `json:"message"`
+}
+
+func (e *DecodeError) Error() string {
+	if e.Line == 0 {
+		if e.Property == "" {
+			return e.Message
+		}
+		return fmt.Sprintf("%s: %s", e.Property, e.Message)
+	}
+
+	if e.Property == "" {
+		return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("line %d, column %d: %s: %s", e.Line, e.Column, e.Property, e.Message)
+}
+
+// DecodeErrors errors of decoding; all errors in strict mode, otherwise the first error
+type DecodeErrors []*DecodeError
+
+func (e DecodeErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+type decodeMode int
+
+const (
+	decodeDefault decodeMode = iota // stops at the first error
+	decodeStrict
+	decodeLenient
+)
+
+// DecodeOption option of decoders
+type DecodeOption func(*decodeState)
+
+// Strict validates components as RFC 5545 and reports all errors with position;
+// unknown properties are errors except X- properties
+func Strict() DecodeOption {
+	return func(s *decodeState) { s.mode = decodeStrict }
+}
+
+// Lenient never fails on malformed content lines, invalid values or unterminated components; they are skipped
+func Lenient() DecodeOption {
+	return func(s *decodeState) { s.mode = decodeLenient }
+}
+
+type decodeState struct {
+	mode      decodeMode
+	errs      DecodeErrors
+	timezones timezones // locations of VTIMEZONE of the calendar
+}
+
+func newDecodeState(opts []DecodeOption) *decodeState {
+	s := &decodeState{}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// report records error at the column of the line; returns non nil error if decoding should stop
+func (s *decodeState) report(l textLine, column int, property string, err error) error {
+	if s.mode == decodeLenient {
+		return nil
+	}
+
+	s.errs = append(s.errs, &DecodeError{Line: l.no, Column: column, Property: property, Message: err.Error()})
+	if s.mode == decodeStrict {
+		return nil
+	}
+	return s.errs
+}
+
+// err returns reported errors
+func (s *decodeState) err() error {
+	if len(s.errs) == 0 {
+		return nil
+	}
+	return s.errs
+}
+
+// multipleProperties properties may occur more than once in a component
+var multipleProperties = map[string]bool{
+	"ATTACH":         true,
+	"ATTENDEE":       true,
+	"CATEGORIES":     true,
+	"COMMENT":        true,
+	"CONTACT":        true,
+	"EXDATE":         true,
+	"EXRULE":         true,
+	"REQUEST-STATUS": true,
+	"RELATED-TO":     true,
+	"RESOURCES":      true,
+	"RDATE":          true,
+	"TZNAME":         true,
+}
+
+// decodeComponent decode properties and nested components of the component; BEGIN and END of the component may be omitted
+// property returns false if the property is unknown, and unknown properties are kept in extensions in order
+// nested returns false if the component is not supported
+// returns names of properties of the component
+func (s *decodeState) decodeComponent(name string, lines []textLine, extensions *[]Property,
+	property func(cl *contentLine) (bool, error),
+	nested func(name string, lines []textLine) (bool, error),
+) (map[string]bool, error) {
+	if len(lines) > 0 && lines[0].text == "BEGIN:"+name {
+		lines = lines[1:]
+		if len(lines) > 0 && lines[len(lines)-1].text == "END:"+name {
+			lines = lines[:len(lines)-1]
+		}
+	}
+
+	seen := map[string]bool{}
+	for i := 0; i < len(lines); i++ {
+		l := lines[i]
+
+		if sub, ok := strings.CutPrefix(l.text, "BEGIN:"); ok {
+			component, rest, err := cutComponent(lines[i:])
+			if err != nil {
+				if err := s.report(l, 1, "", err); err != nil {
+					return seen, err
+				}
+				component, rest = lines[i:], nil
+			}
+			i = len(lines) - len(rest) - 1
+
+			supported := false
+			if nested != nil {
+				if supported, err = nested(sub, component); err != nil {
+					return seen, err
+				}
+			}
+			if !supported {
+				if err := s.report(l, 1, "", fmt.Errorf("unsupported component %s", sub)); err != nil {
+					return seen, err
+				}
+			}
+			continue
+		}
+
+		cl, err := parseContentLine(l.text)
+		if err != nil {
+			if err := s.report(l, 1, "", err); err != nil {
+				return seen, err
+			}
+			continue
+		}
+
+		if cl.name == "BEGIN" || cl.name == "END" {
+			if err := s.report(l, 1, cl.name, fmt.Errorf("unexpected %s", l.text)); err != nil {
+				return seen, err
+			}
+			continue
+		}
+
+		if s.mode == decodeStrict && seen[cl.name] && !multipleProperties[cl.name] && !strings.HasPrefix(cl.name, "X-") {
+			if err := s.report(l, 1, cl.name, errors.New("duplicated property")); err != nil {
+				return seen, err
+			}
+		}
+		seen[cl.name] = true
+
+		known, err := property(cl)
+		if err != nil {
+			if err := s.report(l, valueColumn(l.text, cl.value), cl.name, errors.Wrap(err, "invalid format")); err != nil {
+				return seen, err
+			}
+			continue
+		}
+		if known {
+			continue
+		}
+
+		if s.mode == decodeStrict && !strings.HasPrefix(cl.name, "X-") {
+			if err := s.report(l, 1, cl.name, errors.New("unsupported property")); err != nil {
+				return seen, err
+			}
+			continue
+		}
+
+		*extensions = append(*extensions, Property{
+			Name:   cl.name,
+			Params: l.text[len(cl.name) : len(l.text)-len(cl.value)-1],
+			Value:  cl.value,
+		})
+	}
+
+	return seen, nil
+}
+
+// valueColumn returns column of the value in the content line
+func valueColumn(text, value string) int {
+	return utf8.RuneCountInString(text[:len(text)-len(value)]) + 1
+}
+
+// beginLine returns the first line of the component, where violations of the component are reported
+func beginLine(lines []textLine) textLine {
+	if len(lines) == 0 {
+		return textLine{no: 1}
+	}
+	return lines[0]
+}
+
+// validate reports missing required properties and exclusive properties of the component in strict mode
+func (s *decodeState) validate(lines []textLine, name string, seen map[string]bool, required []string, exclusive [][2]string) {
+	if s.mode != decodeStrict {
+		return
+	}
+
+	begin := beginLine(lines)
+	for _, property := range required {
+		if !seen[property] {
+			s.report(begin, 1, property, fmt.Errorf("required in %s", name))
+		}
+	}
+
+	for _, pair := range exclusive {
+		if seen[pair[0]] && seen[pair[1]] {
+			s.report(begin, 1, pair[1], fmt.Errorf("must not occur with %s in %s", pair[0], name))
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package ical
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeMode(t *testing.T) {
+	data := `BEGIN:VEVENT
+UID:19970901T130000Z-123401@example.com
+DTSTAMP:19970901T130000Z
+DTSTART:19970903T163000Z
+SEQUENCE:2
+X-MS-OLK-FORCEINSPECTOROPEN:TRUE
+FOO;X-A=1:bar
+DTEND:yesterday
+SUMMARY:Annual Employee Review
+ with a folded line
+this is not a content line
+BEGIN:VUNKNOWN
+END:VUNKNOWN
+END:VEVENT
+`
+
+	type args struct {
+		data string
+		opts []DecodeOption
+	}
+	tests := [...]struct {
+		name     string
+		args     args
+		wantErrs []string // line:column:property
+		want     *VEvent
+	}{
+		{`default`, args{data, nil}, []string{"8:7:DTEND"}, nil},
+		{`strict`, args{data, []DecodeOption{Strict()}}, []string{"7:1:FOO", "8:7:DTEND", "11:1:", "12:1:"}, nil},
+		{`lenient`, args{data, []DecodeOption{Lenient()}}, nil, &VEvent{
+			UID:     "19970901T130000Z-123401@example.com",
+			DtStamp: DateTime{Time: time.Date(1997, 9, 1, 13, 0, 0, 0, time.UTC)},
+			DtStart: DateTime{Time: time.Date(1997, 9, 3, 16, 30, 0, 0, time.UTC)},
+			Seq:     2,
+			Summary: Text{Value: "Annual Employee Reviewwith a folded line"},
+			Extensions: []Property{
+				{Name: "X-MS-OLK-FORCEINSPECTOROPEN", Value: "TRUE"},
+				{Name: "FOO", Params: ";X-A=1", Value: "bar"},
+			},
+		}},
+		{`default keeps unknown properties`, args{"BEGIN:VEVENT\nSEQ:1\nX-A:1\nEND:VEVENT", nil}, nil, &VEvent{
+			Extensions: []Property{{Name: "SEQ", Value: "1"}, {Name: "X-A", Value: "1"}},
+		}},
+		{`strict required`, args{"BEGIN:VEVENT\nSUMMARY:a\nX-A:1\nEND:VEVENT", []DecodeOption{Strict()}}, []string{"1:1:UID", "1:1:DTSTAMP"}, nil},
+		{`strict duplicated`, args{"BEGIN:VEVENT\nUID:1\nDTSTAMP:19970901T130000Z\nSUMMARY:a\nSUMMARY:b\nCOMMENT:a\nCOMMENT:b\nEND:VEVENT", []DecodeOption{Strict()}},
+			[]string{"5:1:SUMMARY"}, nil},
+		{`strict exclusive`, args{"BEGIN:VEVENT\nUID:1\nDTSTAMP:19970901T130000Z\nDTSTART:19970903T163000Z\nDTEND:19970903T190000Z\nDURATION:PT1H\nEND:VEVENT", []DecodeOption{Strict()}},
+			[]string{"1:1:DURATION"}, nil},
+		{`strict dtend`, args{"BEGIN:VEVENT\nUID:1\nDTSTAMP:19970901T130000Z\nDTSTART:19970903T163000Z\nDTEND:19970903T150000Z\nEND:VEVENT", []DecodeOption{Strict()}},
+			[]string{"1:1:DTEND"}, nil},
+		{`strict alarm`, args{"BEGIN:VEVENT\nUID:1\nDTSTAMP:19970901T130000Z\nBEGIN:VALARM\nACTION:DISPLAY\nEND:VALARM\nEND:VEVENT", []DecodeOption{Strict()}},
+			[]string{"4:1:TRIGGER", "4:1:"}, nil},
+		{`strict request status`, args{"BEGIN:VEVENT\nUID:1\nDTSTAMP:19970901T130000Z\nREQUEST-STATUS:2.0;Success\nEND:VEVENT", []DecodeOption{Strict()}}, nil, &VEvent{
+			UID:     "1",
+			DtStamp: DateTime{Time: time.Date(1997, 9, 1, 13, 0, 0, 0, time.UTC)},
+			RStatus: "2.0;Success",
+		}},
+		{`strict rstatus`, args{"BEGIN:VEVENT\nUID:1\nDTSTAMP:19970901T130000Z\nRSTATUS:2.0;Success\nEND:VEVENT", []DecodeOption{Strict()}},
+			[]string{"4:1:RSTATUS"}, nil},
+		{`unterminated component`, args{"BEGIN:VEVENT\nUID:1\nBEGIN:VALARM\nACTION:AUDIO\nEND:VEVENT", nil}, []string{"3:1:"}, nil},
+		{`lenient unterminated component`, args{"BEGIN:VEVENT\nUID:1\nBEGIN:VALARM\nACTION:AUDIO\nEND:VEVENT", []DecodeOption{Lenient()}}, nil, &VEvent{
+			UID:    "1",
+			Alarms: []*VAlarm{{Action: ActionAudio}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := new(VEvent)
+			err := NewEventDecoder(strings.NewReader(tt.args.data), tt.args.opts...).Decode(got)
+			if tt.wantErrs == nil {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, got)
+				return
+			}
+
+			var errs DecodeErrors
+			require.Truef(t, errors.As(err, &errs), "DecodeErrors expected: %+v", err)
+
+			positions := []string{}
+			for _, e := range errs {
+				positions = append(positions, fmt.Sprintf("%d:%d:%s", e.Line, e.Column, e.Property))
+			}
+			require.Equal(t, tt.wantErrs, positions, err.Error())
+		})
+	}
+}
+
+func TestCalendarDecodeMode(t *testing.T) {
+	data := `BEGIN:VCALENDAR
+VERSION:2.0
+X-WR-CALNAME:Work
+BEGIN:VTIMEZONE
+TZID:Asia/Seoul
+X-LIC-LOCATION:Asia/Seoul
+BEGIN:STANDARD
+DTSTART:19700101T000000
+TZOFFSETFROM:+0900
+TZOFFSETTO:+0900
+TZNAME:KST
+END:STANDARD
+END:VTIMEZONE
+BEGIN:VEVENT
+UID:1
+DTSTAMP:19970901T130000Z
+END:VEVENT
+`
+
+	type args struct {
+		opts []DecodeOption
+	}
+	tests := [...]struct {
+		name     string
+		args     args
+		wantErrs []string
+	}{
+		{`default`, args{nil}, []string{"17:1:"}},
+		{`strict`, args{[]DecodeOption{Strict()}}, []string{"17:1:", "1:1:PRODID"}},
+		{`lenient`, args{[]DecodeOption{Lenient()}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := new(VCalendar)
+			err := NewCalendarDecoder(strings.NewReader(data), tt.args.opts...).Decode(got)
+			if tt.wantErrs == nil {
+				require.NoError(t, err)
+				require.Equal(t, []Property{{Name: "X-WR-CALNAME", Value: "Work"}}, got.Extensions)
+				require.Equal(t, []Property{{Name: "X-LIC-LOCATION", Value: "Asia/Seoul"}}, got.Timezones[0].Extensions)
+				require.Len(t, got.Events, 1)
+				return
+			}
+
+			var errs DecodeErrors
+			require.Truef(t, errors.As(err, &errs), "DecodeErrors expected: %+v", err)
+
+			positions := []string{}
+			for _, e := range errs {
+				positions = append(positions, fmt.Sprintf("%d:%d:%s", e.Line, e.Column, e.Property))
+			}
+			require.Equal(t, tt.wantErrs, positions, err.Error())
+		})
+	}
+}
+
+func FuzzDecodeLenient(f *testing.F) {
+	f.Add("BEGIN:VEVENT\r\nUID:1\r\nDTSTART;TZID=Asia/Seoul:19970903T163000\r\nEND:VEVENT\r\n")
+	f.Add("BEGIN:VCALENDAR\nBEGIN:VEVENT\nBEGIN:VALARM\nTRIGGER:-PT15M\nEND:VEVENT\n")
+	f.Add("BEGIN:VCALENDAR\nBEGIN:VTIMEZONE\nBEGIN:DAYLIGHT\nTZOFFSETFROM:+09\nEND:VCALENDAR")
+	f.Add("DTSTART;VALUE=PERIOD:x\nRDATE;VALUE=PERIOD:19970101T180000Z/PT\nRRULE:FREQ=;BYDAY=\nDURATION:\n")
+	f.Add("ATTENDEE;CN=\"a:mailto:a\nX-;=:\n:\n;\n")
+
+	f.Fuzz(func(t *testing.T, data string) {
+		require.NoError(t, NewEventDecoder(strings.NewReader(data), Lenient()).Decode(new(VEvent)))
+		NewCalendarDecoder(strings.NewReader(data), Lenient()).Decode(new(VCalendar))
+		NewTodoDecoder(strings.NewReader(data), Lenient()).Decode(new(VTodo))
+		NewJournalDecoder(strings.NewReader(data), Lenient()).Decode(new(VJournal))
+	})
+}
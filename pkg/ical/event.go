@@ -1,118 +1,113 @@
 package ical
 
 import (
-	"bufio"
 	"fmt"
 	"io"
-	"strconv"
-	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/whitekid/goxp"
-	"github.com/whitekid/iter"
 )
 
 // VEvent rfc2455 4.6.1 Event Component
 type VEvent struct {
 	Class        string   `validate:"max=100"` // classification, PUBLIC(*), PRIVATE, CONFIDENTIAL
 	Created      DateTime // 4.8.7.1 Date/Time Created, 서버 리소스 생성 시점
-	Description  string   `validate:"max=500"`
+	Description  Text
 	DtStart      DateTime // or Date
 	Geo          string   `validate:"max=100"`
 	LastModied   DateTime
-	Location     string   `validate:"max=100"`
-	Organizer    string   `validate:"max=100"`
+	Location     Text
+	Organizer    CalAddress
 	Priority     string   `validate:"max=100"`
 	DtStamp      DateTime `validate:"required"` // 4.8.7.2 Date/Time Stamp; as UTC,
 	Seq          int      // 4.8.7.4 Sequence Number, revision number
 	Status       string   `validate:"max=100"`
-	Summary      string   `validate:"max=100"`
+	Summary      Text
 	Transp       string   `validate:"max=100"`
 	UID          string   `validate:"max=100"`
 	URL          string   `validate:"max=100"`
-	RecurrenceID string   `validate:"max=100"`
+	RecurrenceID DateTime // 3.8.4.4 Recurrence ID, DTSTART of the overridden instance
 	DtEnd        DateTime // or Date
 	Duration     Duration
-	Attatch      string   `validate:"max=100"`
-	Attendee     string   `validate:"max=100"`
-	Categories   []string `validate:"max=100"`
-	Comment      string   `validate:"max=100"`
-	Contact      string   `validate:"max=100"`
-	ExDate       DateTime // or DateTime
-	ExRule       string   `validate:"max=100"`
-	RStatus      string   `validate:"max=100"`
-	Related      string   `validate:"max=100"`
-	Resources    string   `validate:"max=100"`
-	RDate        DateTime // or DateTime
-	RRule        string   `validate:"max=100"` // RecurrenceRule
-	XProp        string   `validate:"max=100"` // TODO
+	Attatch      Attachment
+	Attendees    []CalAddress
+	Categories   Categories
+	Comments     []Text
+	Contact      Text
+	ExDates      []DateTime      // 3.8.5.1 Exception Date-Times
+	ExRule       *RecurrenceRule // deprecated in rfc5545
+	RStatus      string          `validate:"max=100"` // 3.8.8.3 Request Status, statcode;description[;data]
+	RelatedTo    []Text          // 3.8.4.5 Related To, with RELTYPE
+	Resources    string          `validate:"max=100"`
+	RDates       []Period        // 3.8.5.2 Recurrence Date-Times
+	RRule        *RecurrenceRule
+	Alarms       []*VAlarm
+
+	Extensions []Property // unknown and X- properties; ex. X-MICROSOFT-CDO-BUSYSTATUS
 }
 
 type eventDecoder struct {
-	r io.Reader
+	r    io.Reader
+	opts []DecodeOption
 }
 
 var _ Decoder = (*eventDecoder)(nil)
 
-func NewEventDecoder(r io.Reader) Decoder {
-	return &eventDecoder{r: r}
+func NewEventDecoder(r io.Reader, opts ...DecodeOption) Decoder {
+	return &eventDecoder{r: r, opts: opts}
 }
 
-func (d *eventDecoder) Decode(v any) (err error) {
+func (d *eventDecoder) Decode(v any) error {
 	e, ok := v.(*VEvent)
 	if !ok {
 		return fmt.Errorf("v must be pointer of VEvent")
 	}
 
-	feed := func(s string) error {
-		p := strings.SplitN(s, ":", 2)
-		if len(p) < 2 {
-			panic(s)
-		}
-		key, value := p[0], p[1]
+	lines, err := readLines(d.r)
+	if err != nil {
+		return err
+	}
+
+	s := newDecodeState(d.opts)
+	if err := decodeEvent(s, e, lines); err != nil {
+		return err
+	}
+	return s.err()
+}
 
+// decodeEvent decode unfolded content lines of VEVENT
+func decodeEvent(s *decodeState, e *VEvent, lines []textLine) error {
+	seen, err := s.decodeComponent("VEVENT", lines, &e.Extensions, func(cl *contentLine) (bool, error) {
+		key, params, value := cl.name, cl.params, cl.value
+
+		var err error
 		switch key {
-		case "BEGIN":
-		case "END":
-			return nil
 		case "CLASS":
 			e.Class = unescape(value)
 		case "CREATED":
-			e.Created, err = parseDateTime(value)
-			if err != nil {
-				return errors.Wrap(err, "invalid format: CREATED")
-			}
+			e.Created, err = parseDateTime(value, params, s.timezones)
 		case "DESCRIPTION":
-			e.Description = unescape(value)
+			e.Description = Text{Value: unescape(value), Parameters: newParameters(params)}
 		case "DTSTART":
-			e.DtStart, err = parseDateTime(value)
-			if err != nil {
-				return errors.Wrap(err, "invalid format: DTSTART")
-			}
+			e.DtStart, err = parseDateTime(value, params, s.timezones)
 		case "GEO":
 			e.Geo = unescape(value)
 		case "LAST-MODIFIED":
-			e.LastModied, err = parseDateTime(value)
-			if err != nil {
-				return errors.Wrap(err, "invalid format: LAST-MODIFIED")
-			}
+			e.LastModied, err = parseDateTime(value, params, s.timezones)
 		case "LOCATION":
-			e.Location = unescape(value)
+			e.Location = Text{Value: unescape(value), Parameters: newParameters(params)}
 		case "ORGANIZER":
-			e.Organizer = unescape(value)
+			e.Organizer = CalAddress{Value: value, Parameters: newParameters(params)}
 		case "PRIORITY":
 			e.Priority = unescape(value)
 		case "DTSTAMP":
-			e.DtStamp, err = parseDateTime(value)
-			if err != nil {
-				return errors.Wrap(err, "invalid format: DISTAMP")
-			}
-		case "SEQ":
-			e.Seq, _ = strconv.Atoi(value)
+			e.DtStamp, err = parseDateTime(value, params, s.timezones)
+		case "SEQUENCE":
+			e.Seq, err = parseInteger(value)
 		case "STATUS":
 			e.Status = unescape(value)
 		case "SUMMARY":
-			e.Summary = unescape(value)
+			e.Summary = Text{Value: unescape(value), Parameters: newParameters(params)}
 		case "TRANSP":
 			e.Transp = unescape(value)
 		case "UID":
@@ -120,91 +115,73 @@ func (d *eventDecoder) Decode(v any) (err error) {
 		case "URL":
 			e.URL = unescape(value)
 		case "RECURRENCE-ID":
-			e.RecurrenceID = unescape(value)
+			e.RecurrenceID, err = parseDateTime(value, params, s.timezones)
 		case "DTEND":
-			e.DtEnd, err = parseDateTime(value)
-			if err != nil {
-				return errors.Wrap(err, "invalid format: DTEND")
-			}
+			e.DtEnd, err = parseDateTime(value, params, s.timezones)
 		case "DURATION":
 			e.Duration, err = parseDuration(value)
-			if err != nil {
-				return errors.Wrap(err, "invalid format: DURATION")
-			}
 		case "ATTACH":
-			e.Attatch = unescape(value)
+			e.Attatch = Attachment{Value: value, Parameters: newParameters(params)}
 		case "ATTENDEE":
-			e.Attendee = unescape(value)
+			e.Attendees = append(e.Attendees, CalAddress{Value: value, Parameters: newParameters(params)})
 		case "CATEGORIES":
 			if len(value) != 0 {
-				e.Categories = strings.Split(value, ",")
+				categories := newCategories(value, params)
+				e.Categories.Values = append(e.Categories.Values, categories.Values...)
+				e.Categories.Parameters = categories.Parameters
 			}
 		case "COMMENT":
-			e.Comment = unescape(value)
+			e.Comments = append(e.Comments, Text{Value: unescape(value), Parameters: newParameters(params)})
 		case "CONTACT":
-			e.Contact = unescape(value)
+			e.Contact = Text{Value: unescape(value), Parameters: newParameters(params)}
 		case "EXDATE":
-			e.ExDate, err = parseDateTime(value)
-			if err != nil {
-				return errors.Wrap(err, "invalid format: EXDATE")
+			var dates []DateTime
+			if dates, err = parseDateTimeList(value, params, s.timezones); err == nil {
+				e.ExDates = append(e.ExDates, dates...)
 			}
 		case "EXRULE":
-			e.ExRule = unescape(value)
-		case "RSTATUS":
-			e.RStatus = unescape(value)
-		case "RELATED":
-			e.Related = unescape(value)
+			e.ExRule, err = ParseRecurrenceRule(value)
+		case "REQUEST-STATUS":
+			e.RStatus = value
+		case "RELATED-TO":
+			e.RelatedTo = append(e.RelatedTo, Text{Value: unescape(value), Parameters: newParameters(params)})
 		case "RESOURCES":
 			e.Resources = unescape(value)
 		case "RDATE":
-			e.RDate, err = parseDateTime(value)
-			if err != nil {
-				return errors.Wrap(err, "invalid format: RDATE")
+			var periods []Period
+			if periods, err = parseRDate(value, params, s.timezones); err == nil {
+				e.RDates = append(e.RDates, periods...)
 			}
 		case "RRULE":
-			e.RRule = unescape(value)
-		case "X-PROP":
-			e.XProp = unescape(value)
-
+			e.RRule, err = ParseRecurrenceRule(value)
 		default:
-			return fmt.Errorf("unsupported field %s", key)
+			return false, nil
 		}
 
-		return nil
-	}
-
-	s := bufio.NewScanner(d.r)
-
-	inFolding := false
-	line := ""
-	for s.Scan() {
-		text := s.Text()
-		if strings.HasPrefix(text, "  ") {
-			line += text[2:]
-			inFolding = true
-			continue
+		return true, err
+	}, func(name string, component []textLine) (bool, error) {
+		if name != "VALARM" {
+			return false, nil
 		}
 
-		if inFolding {
-			if err := feed(line); err != nil {
-				return err
-			}
-			if err := feed(text); err != nil {
-				return err
-			}
-
-			line = ""
-			inFolding = false
-			continue
+		alarm := new(VAlarm)
+		if err := decodeAlarm(s, alarm, component); err != nil {
+			return true, err
 		}
+		e.Alarms = append(e.Alarms, alarm)
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
 
-		if line != "" {
-			if err := feed(line); err != nil {
-				return err
-			}
+	s.validate(lines, "VEVENT", seen, []string{"UID", "DTSTAMP"}, [][2]string{{"DTEND", "DURATION"}})
+	if s.mode == decodeStrict && !e.DtEnd.IsZero() && !e.DtStart.IsZero() {
+		if e.DtEnd.IsDate() != e.DtStart.IsDate() {
+			s.report(beginLine(lines), 1, "DTEND", errors.New("value type must be the same as DTSTART"))
+		} else if !e.DtEnd.After(e.DtStart.Time) {
+			s.report(beginLine(lines), 1, "DTEND", errors.New("must be later than DTSTART"))
 		}
-
-		line = text
 	}
 
 	return nil
@@ -227,19 +204,18 @@ func (enc *eventEncoder) Encode(v any) error {
 	}
 
 	fmt.Fprintf(enc.w, "BEGIN:VEVENT\r\n")
-	if err := enc.writeFields([]*goxp.Tuple2[string, any]{
+	if err := writeFields(enc.w, []*goxp.Tuple2[string, any]{
 		{"CLASS", evt.Class},
 		{"CREATED", evt.Created},
 		{"DESCRIPTION", evt.Description},
 		{"DTSTART", evt.DtStart},
-		{"GEO", evt.Geo},
+		{"GEO", rawValue(evt.Geo)},
 		{"LAST-MODIFIED", evt.LastModied},
 		{"LOCATION", evt.Location},
 		{"ORGANIZER", evt.Organizer},
-		{"ORGANIZER", evt.Organizer},
 		{"PRIORITY", evt.Priority},
 		{"DTSTAMP", evt.DtStamp},
-		{"SEQ", evt.Seq},
+		{"SEQUENCE", evt.Seq},
 		{"STATUS", evt.Status},
 		{"SUMMARY", evt.Summary},
 		{"TRANSP", evt.Transp},
@@ -249,82 +225,29 @@ func (enc *eventEncoder) Encode(v any) error {
 		{"DTEND", evt.DtEnd},
 		{"DURATION", evt.Duration},
 		{"ATTACH", evt.Attatch},
-		{"ATTENDEE", evt.Attendee},
+		{"ATTENDEE", evt.Attendees},
 		{"CATEGORIES", evt.Categories},
-		{"COMMENT", evt.Comment},
+		{"COMMENT", evt.Comments},
 		{"CONTACT", evt.Contact},
-		{"EXDATE", evt.ExDate},
+		{"EXDATE", evt.ExDates},
 		{"EXRULE", evt.ExRule},
-		{"RSTATUS", evt.RStatus},
-		{"RELATED", evt.Related},
+		{"REQUEST-STATUS", rawValue(evt.RStatus)},
+		{"RELATED-TO", evt.RelatedTo},
 		{"RESOURCES", evt.Resources},
-		{"RDATE", evt.RDate},
+		{"RDATE", evt.RDates},
 		{"RRULE", evt.RRule},
-		{"X-PROP", evt.XProp},
 	}); err != nil {
 		return err
 	}
-	fmt.Fprintf(enc.w, "END:VEVENT\r\n")
-	return nil
-}
+	if err := writeExtensions(enc.w, evt.Extensions); err != nil {
+		return err
+	}
 
-func (enc *eventEncoder) writeFields(values []*goxp.Tuple2[string, any]) (err error) {
-	for _, value := range values {
-		if err := enc.writeField(value.V1, value.V2); err != nil {
+	for _, alarm := range evt.Alarms {
+		if err := encodeAlarm(enc.w, alarm); err != nil {
 			return err
 		}
 	}
-
+	fmt.Fprintf(enc.w, "END:VEVENT\r\n")
 	return nil
 }
-
-func (enc *eventEncoder) writeField(field string, value any) (err error) {
-	if value == nil {
-		return nil
-	}
-
-	switch v := value.(type) {
-	case string:
-		if v == "" {
-			break
-		}
-
-		v = escape(v)
-
-		if len(v)+len(field) < 78 {
-			_, err = fmt.Fprintf(enc.w, "%s:%s\r\n", field, v)
-			break
-		}
-
-		i := 0
-		iter.Chunk(iter.Of([]rune(fmt.Sprintf("%s:%s", field, v))...), 78).Each(
-			func(r []rune) {
-				if i != 0 {
-					fmt.Fprintf(enc.w, "  ")
-				}
-				fmt.Fprintf(enc.w, "%s\r\n", string(r))
-				i++
-			})
-
-	case []string:
-		if len(v) != 0 {
-			_, err = fmt.Fprintf(enc.w, "%s:%s\r\n", field, strings.Join(v, ","))
-		}
-	case DateTime:
-		if !v.IsZero() {
-			_, err = fmt.Fprintf(enc.w, "%s:%s\r\n", field, v.String())
-		}
-	case Duration:
-		if v.Duration != 0 {
-			_, err = fmt.Fprintf(enc.w, "%s:%s\r\n", field, v.String())
-		}
-	case int:
-		if v != 0 {
-			_, err = fmt.Fprintf(enc.w, "%s:%d\r\n", field, v)
-		}
-	default:
-		err = fmt.Errorf("unsupported data type: %T", v)
-	}
-
-	return err
-}
@@ -16,16 +16,20 @@ import (
 type Examples struct {
 	Events map[string]struct {
 		Event struct {
-			UID        string `yaml:"UID"`
-			DTSTAMP    string `yaml:"DTSTAMP"`
-			DTSTART    string `yaml:"DTSTART"`
-			DTEND      string `yaml:"DTEND"`
-			SUMMARY    string `yaml:"SUMMARY"`
-			COMMENT    string `yaml:"COMMENT"`
-			CLASS      string `yaml:"CLASS"`
-			CATEGORIES string `yaml:"CATEGORIES"`
-			RRULE      string `yaml:"RRULE"`
-			TRANSP     string `yaml:"TRANSP"`
+			UID        string   `yaml:"UID"`
+			DTSTAMP    string   `yaml:"DTSTAMP"`
+			DTSTART    string   `yaml:"DTSTART"`
+			DTEND      string   `yaml:"DTEND"`
+			SUMMARY    string   `yaml:"SUMMARY"`
+			COMMENT    string   `yaml:"COMMENT"`
+			CLASS      string   `yaml:"CLASS"`
+			CATEGORIES string   `yaml:"CATEGORIES"`
+			RRULE      string   `yaml:"RRULE"`
+			TRANSP     string   `yaml:"TRANSP"`
+			ATTENDEE   []string `yaml:"ATTENDEE"`
+			EXDATE     string   `yaml:"EXDATE"`
+			RDATE      string   `yaml:"RDATE"`
+			RELATEDTO  []string `yaml:"RELATED-TO"`
 		} `yaml:"event"`
 		Data string
 	} `yaml:"events"`
@@ -57,6 +61,7 @@ func TestEventEncode(t *testing.T) {
 		{"example2", args{"example2"}},
 		{"example3", args{"example3"}},
 		{"example4", args{"example4"}},
+		{"example5", args{"example5"}},
 	}
 
 	for _, tt := range tests {
@@ -67,13 +72,33 @@ func TestEventEncode(t *testing.T) {
 				DtStamp:    mustParseDateTime(example.Event.DTSTAMP),
 				DtStart:    mustParseDateTime(example.Event.DTSTART),
 				DtEnd:      mustParseDateTime(example.Event.DTEND),
-				Summary:    example.Event.SUMMARY,
-				Comment:    example.Event.COMMENT,
+				Summary:    Text{Value: example.Event.SUMMARY},
+				Comments:   goxp.Ternary(example.Event.COMMENT != "", []Text{{Value: example.Event.COMMENT}}, nil),
 				Class:      example.Event.CLASS,
-				Categories: goxp.Ternary(example.Event.CATEGORIES != "", strings.Split(example.Event.CATEGORIES, ","), nil),
-				RRule:      example.Event.RRULE,
+				Categories: Categories{Values: goxp.Ternary(example.Event.CATEGORIES != "", strings.Split(example.Event.CATEGORIES, ","), nil)},
 				Transp:     example.Event.TRANSP,
 			}
+			if example.Event.RRULE != "" {
+				rrule, err := ParseRecurrenceRule(example.Event.RRULE)
+				require.NoError(t, err)
+				want.RRule = rrule
+			}
+			for _, attendee := range example.Event.ATTENDEE {
+				want.Attendees = append(want.Attendees, CalAddress{Value: attendee})
+			}
+			for _, related := range example.Event.RELATEDTO {
+				want.RelatedTo = append(want.RelatedTo, Text{Value: related})
+			}
+			if example.Event.EXDATE != "" {
+				exdates, err := parseDateTimeList(example.Event.EXDATE, nil, nil)
+				require.NoError(t, err)
+				want.ExDates = exdates
+			}
+			if example.Event.RDATE != "" {
+				rdates, err := parseRDate(example.Event.RDATE, Params{"VALUE": {"PERIOD"}}, nil)
+				require.NoError(t, err)
+				want.RDates = rdates
+			}
 
 			buf := new(bytes.Buffer)
 			err := NewEventEncoder(buf).Encode(want)
@@ -97,8 +122,8 @@ func FuzzEventEncode(f *testing.F) {
 	f.Add("summary", "description", time.Now().Unix())
 	f.Fuzz(func(t *testing.T, summary, description string, dtStamp int64) {
 		evt := &VEvent{
-			Summary:     summary,
-			Description: description,
+			Summary:     Text{Value: summary},
+			Description: Text{Value: description},
 			DtStart:     DateTime{Time: time.Unix(dtStamp, 0)},
 		}
 		buf := new(bytes.Buffer)
@@ -109,3 +134,111 @@ func FuzzEventEncode(f *testing.F) {
 		require.Equal(t, evt, got)
 	})
 }
+
+func TestEventMultiValued(t *testing.T) {
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	require.NoError(t, err)
+
+	data := `BEGIN:VEVENT
+UID:19970901T130000Z-123404@example.com
+DTSTAMP:19970901T130000Z
+DTSTART;TZID=Asia/Seoul:19970105T083000
+ATTENDEE;CN=John:mailto:john@example.com
+ATTENDEE;CN=Jane;ROLE=OPT-PARTICIPANT:mailto:jane@example.com
+COMMENT:first
+COMMENT;LANGUAGE=ko:두번째
+EXDATE;TZID=Asia/Seoul:19970112T083000,19970119T083000
+EXDATE:19970126T003000Z
+RDATE;VALUE=DATE:19970201,19970202
+RDATE;VALUE=PERIOD:19970301T180000Z/PT2H
+RELATED-TO;RELTYPE=PARENT:parent@example.com
+END:VEVENT
+`
+	want := &VEvent{
+		UID:     "19970901T130000Z-123404@example.com",
+		DtStamp: DateTime{Time: time.Date(1997, 9, 1, 13, 0, 0, 0, time.UTC)},
+		DtStart: DateTime{Time: time.Date(1997, 1, 5, 8, 30, 0, 0, seoul)},
+		Attendees: []CalAddress{
+			{Value: "mailto:john@example.com", Parameters: Parameters{CN: "John"}},
+			{Value: "mailto:jane@example.com", Parameters: Parameters{CN: "Jane", Role: RoleOptParticipant}},
+		},
+		Comments: []Text{
+			{Value: "first"},
+			{Value: "두번째", Parameters: Parameters{Language: "ko"}},
+		},
+		ExDates: []DateTime{
+			{Time: time.Date(1997, 1, 12, 8, 30, 0, 0, seoul)},
+			{Time: time.Date(1997, 1, 19, 8, 30, 0, 0, seoul)},
+			{Time: time.Date(1997, 1, 26, 0, 30, 0, 0, time.UTC)},
+		},
+		RDates: []Period{
+			{Start: DateTime{Time: time.Date(1997, 2, 1, 0, 0, 0, 0, time.UTC), isDate: true}},
+			{Start: DateTime{Time: time.Date(1997, 2, 2, 0, 0, 0, 0, time.UTC), isDate: true}},
+			{Start: DateTime{Time: time.Date(1997, 3, 1, 18, 0, 0, 0, time.UTC)}, Duration: Duration{2 * time.Hour}},
+		},
+		RelatedTo: []Text{{Value: "parent@example.com", Parameters: Parameters{RelType: "PARENT"}}},
+	}
+
+	got := new(VEvent)
+	require.NoError(t, NewEventDecoder(strings.NewReader(data)).Decode(got))
+	require.Equal(t, want, got)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, NewEventEncoder(buf).Encode(got))
+	require.Contains(t, buf.String(), "EXDATE;TZID=Asia/Seoul:19970112T083000,19970119T083000\r\nEXDATE:19970126T003000Z\r\n")
+	require.Contains(t, buf.String(), "RDATE;VALUE=DATE:19970201,19970202\r\nRDATE;VALUE=PERIOD:19970301T180000Z/PT2H\r\n")
+
+	reparsed := new(VEvent)
+	require.NoError(t, NewEventDecoder(buf).Decode(reparsed))
+	require.Equal(t, want, reparsed)
+}
+
+func TestEventExtensions(t *testing.T) {
+	data := strings.Join([]string{
+		"BEGIN:VEVENT",
+		"UID:040000008200E00074C5B7101A82E00800000000@example.com",
+		"X-MICROSOFT-CDO-BUSYSTATUS:BUSY",
+		`X-ALT-DESC;FMTTYPE=text/html:<html><body>Agenda\, items</body></html>`,
+		"SUMMARY:Review",
+		"X-GOOGLE-CONFERENCE:https://meet.google.com/abc-defg-hij",
+		`X-CUSTOM;X-Param="a:b",c:value;with;semicolons`,
+		"NEWPROP:1",
+		"X-MOZ-GENERATION:3",
+		"END:VEVENT",
+		"",
+	}, "\r\n")
+
+	got := new(VEvent)
+	require.NoError(t, NewEventDecoder(strings.NewReader(data)).Decode(got))
+	require.Equal(t, []Property{
+		{Name: "X-MICROSOFT-CDO-BUSYSTATUS", Value: "BUSY"},
+		{Name: "X-ALT-DESC", Params: ";FMTTYPE=text/html", Value: `<html><body>Agenda\, items</body></html>`},
+		{Name: "X-GOOGLE-CONFERENCE", Value: "https://meet.google.com/abc-defg-hij"},
+		{Name: "X-CUSTOM", Params: `;X-Param="a:b",c`, Value: "value;with;semicolons"},
+		{Name: "NEWPROP", Value: "1"},
+		{Name: "X-MOZ-GENERATION", Value: "3"},
+	}, got.Extensions)
+
+	params, err := got.Extensions[3].Parameters()
+	require.NoError(t, err)
+	require.Equal(t, Params{"X-PARAM": {"a:b", "c"}}, params)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, NewEventEncoder(buf).Encode(got))
+	require.Contains(t, buf.String(), strings.Join([]string{
+		"X-MICROSOFT-CDO-BUSYSTATUS:BUSY",
+		`X-ALT-DESC;FMTTYPE=text/html:<html><body>Agenda\, items</body></html>`,
+		"X-GOOGLE-CONFERENCE:https://meet.google.com/abc-defg-hij",
+		`X-CUSTOM;X-Param="a:b",c:value;with;semicolons`,
+		"NEWPROP:1",
+		"X-MOZ-GENERATION:3",
+		"END:VEVENT",
+	}, "\r\n"))
+
+	reparsed := new(VEvent)
+	require.NoError(t, NewEventDecoder(buf).Decode(reparsed))
+	require.Equal(t, got, reparsed)
+
+	got.Extensions = append(got.Extensions, Property{Name: "X-INVALID NAME", Value: "x"})
+	require.Error(t, NewEventEncoder(new(bytes.Buffer)).Encode(got))
+}
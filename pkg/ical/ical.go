@@ -1,19 +1,21 @@
 package ical
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
-	"github.com/whitekid/goxp/log"
+	"github.com/whitekid/goxp"
 )
 
 // SPEC
 // https://icalendar.org/
 // https://www.ietf.org/rfc/rfc2445.txt
-type VCalendar struct {
-}
+// https://www.rfc-editor.org/rfc/rfc5545
 
 type Encoder interface {
 	Encode(v any) error
@@ -25,6 +27,32 @@ type Decoder interface {
 
 // Component: VEVENT, VTODO, VJOURNAL, VFREEBUS?y, VTIMEZONE, x-name, iana-token
 
+// readLines returns unfolded content lines
+// 3.1 Content Lines: CRLF followed by a single white space(SPACE or HTAB) is removed
+func readLines(r io.Reader) ([]textLine, error) {
+	lines := []textLine{}
+	br := bufio.NewReader(r)
+	for no := 1; ; no++ {
+		text, err := br.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		text = strings.TrimSuffix(strings.TrimSuffix(text, "\n"), "\r")
+
+		switch {
+		case text == "":
+		case (text[0] == ' ' || text[0] == '\t') && len(lines) > 0:
+			lines[len(lines)-1].text += text[1:]
+		default:
+			lines = append(lines, textLine{text: text, no: no})
+		}
+
+		if err == io.EOF {
+			return lines, nil
+		}
+	}
+}
+
 func escape(s string) string {
 	replacer := strings.NewReplacer(
 		"\n", `\n`,
@@ -38,6 +66,7 @@ func escape(s string) string {
 func unescape(s string) string {
 	replacer := strings.NewReplacer(
 		`\n`, "\n",
+		`\N`, "\n",
 		`\\`, "\\",
 		`\;`, `;`,
 		`\,`, `,`,
@@ -45,234 +74,320 @@ func unescape(s string) string {
 	return replacer.Replace(s)
 }
 
-func parseDateTime(value string) (tm DateTime, err error) {
-	// Date
-	if tm.Time, err = time.Parse("20060102", value); err == nil {
-		tm.isDate = true
-		return
-	}
+// rawValue value written without escaping; ex. GEO of FLOAT;FLOAT
+type rawValue string
 
-	loc := time.Local
-	withTZID := false
-	if strings.HasPrefix(value, "TZID=") {
-		withTZID = true
-		idx := strings.Index(value, ":")
-		if idx == -1 {
-			return tm, fmt.Errorf("invalid date format: %s", value)
-		}
-		tzid := strings.SplitN(value[0:idx], "=", 2)
-		if tzid[1] != "" {
-			loc, err = time.LoadLocation(strings.Replace(tzid[1], "-", "/", 1))
-			if err != nil {
-				return tm, fmt.Errorf("invalid timezone: %s", tzid[1])
-			}
+// writeFields write content lines of non zero values
+func writeFields(w io.Writer, values []*goxp.Tuple2[string, any]) (err error) {
+	for _, value := range values {
+		if err := writeField(w, value.V1, value.V2); err != nil {
+			return err
 		}
-
-		value = value[idx+1:]
 	}
 
-	// UTC
-	if tm.Time, err = timeParse([]string{"20060102T150405Z", "20060102T1504Z"}, value, time.UTC); err == nil {
-		return
-	}
+	return nil
+}
 
-	// local, with timezone
-	if tm.Time, err = timeParse([]string{"20060102T150405", "20060102T1504"}, value, loc); err == nil {
-		tm.withTZID = withTZID
-		return tm, nil
+func writeField(w io.Writer, field string, value any) (err error) {
+	if value == nil {
+		return nil
 	}
 
-	return tm, err
-}
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			err = writeLine(w, field+":"+escape(v))
+		}
+	case rawValue:
+		if v != "" {
+			err = writeLine(w, field+":"+string(v))
+		}
+	case Text:
+		if v.Value != "" {
+			err = writeLine(w, field+v.Parameters.String()+":"+escape(v.Value))
+		}
+	case CalAddress:
+		if v.Value != "" {
+			err = writeLine(w, field+v.Parameters.String()+":"+v.Value)
+		}
+	case []string:
+		if len(v) != 0 {
+			err = writeLine(w, field+":"+strings.Join(v, ","))
+		}
+	case Attachment:
+		if v.Value != "" {
+			err = writeLine(w, field+v.Parameters.String()+":"+v.Value)
+		}
+	case Categories:
+		if len(v.Values) != 0 {
+			values := make([]string, len(v.Values))
+			for i := range v.Values {
+				values[i] = escape(v.Values[i])
+			}
+			err = writeLine(w, field+v.Parameters.String()+":"+strings.Join(values, ","))
+		}
+	case DateTime:
+		if v.IsZero() {
+			break
+		}
 
-func timeParse(layouts []string, s string, loc *time.Location) (tm time.Time, err error) {
-	for _, layout := range layouts {
-		tm, err = time.ParseInLocation(layout, s, loc)
-		if err == nil {
-			return
+		err = writeLine(w, field+v.params()+":"+v.String())
+	case []DateTime:
+		// values with same parameters are written as a list
+		values := make([]string, len(v))
+		params := make([]string, len(v))
+		for i := range v {
+			values[i], params[i] = v[i].String(), v[i].params()
+		}
+		err = writeList(w, field, params, values)
+	case []Period:
+		values := make([]string, len(v))
+		params := make([]string, len(v))
+		for i := range v {
+			if v[i].IsPeriod() {
+				values[i], params[i] = v[i].String(), ";VALUE=PERIOD"+strings.TrimPrefix(v[i].Start.params(), ";VALUE=DATE")
+			} else {
+				values[i], params[i] = v[i].Start.String(), v[i].Start.params()
+			}
+		}
+		err = writeList(w, field, params, values)
+	case []Text:
+		for _, text := range v {
+			if err = writeField(w, field, text); err != nil {
+				break
+			}
+		}
+	case []CalAddress:
+		for _, address := range v {
+			if err = writeField(w, field, address); err != nil {
+				break
+			}
+		}
+	case Duration:
+		if v.Duration != 0 {
+			err = writeLine(w, field+":"+v.String())
+		}
+	case Trigger:
+		err = writeLine(w, field+v.String())
+	case *RecurrenceRule:
+		if v == nil {
+			break
 		}
-	}
-	return
-}
 
-// DateTime represents DATE-TIME or DATE
-// - 4.3.4 Date
-// - 4.3.5 DateTime
-//
-// TODO: TZID
-type DateTime struct {
-	time.Time
+		if err = v.Validate(); err == nil {
+			err = writeLine(w, field+":"+v.String())
+		}
+	case UTCOffset:
+		err = writeLine(w, field+":"+v.String())
+	case int:
+		if v != 0 {
+			err = writeLine(w, field+":"+strconv.Itoa(v))
+		}
+	default:
+		err = fmt.Errorf("unsupported data type: %T", v)
+	}
 
-	isDate   bool
-	withTZID bool
+	return err
 }
 
-func (dt *DateTime) String() string {
-	if dt.isDate {
-		return dt.Format("20060102")
-	}
+// writeList write values as comma separated list; consecutive values with the same parameters are written in a line
+func writeList(w io.Writer, field string, params []string, values []string) error {
+	for i := 0; i < len(values); {
+		j := i + 1
+		for j < len(values) && params[j] == params[i] {
+			j++
+		}
 
-	if dt.withTZID {
-		tzid := strings.Replace(dt.Location().String(), "/", "-", 1)
-		return fmt.Sprintf("TZID=%s:%s", tzid, dt.Format("20060102T150405"))
+		if err := writeLine(w, field+params[i]+":"+strings.Join(values[i:j], ",")); err != nil {
+			return err
+		}
+		i = j
 	}
 
-	if dt.Time.Location() == time.Local {
-		return dt.Format("20060102T150405")
+	return nil
+}
+
+// writeExtensions write X- and unknown properties as is
+func writeExtensions(w io.Writer, extensions []Property) error {
+	for _, p := range extensions {
+		if !isName(p.Name) || strings.ContainsAny(p.Params+p.Value, "\r\n") {
+			return fmt.Errorf("invalid property: %s", p.Name)
+		}
+
+		if err := writeLine(w, p.String()); err != nil {
+			return err
+		}
 	}
 
-	return dt.Format("20060102T150405Z07:00")
+	return nil
 }
 
-// Duration 4.3.6 Duration
-type Duration struct {
-	time.Duration
-}
+// maxLineOctets max length of content line excluding CRLF
+const maxLineOctets = 75
 
-const (
-	Day  = time.Hour * 24
-	Week = Day * 7
-)
+// writeLine write content line, folded if it is longer than 75 octets
+// lines are folded at the boundary of UTF-8 character and continued with a SPACE
+func writeLine(w io.Writer, line string) error {
+	s := new(strings.Builder)
+	limit := maxLineOctets
+	for len(line) > limit {
+		i := 0
+		for i < len(line) {
+			_, size := utf8.DecodeRuneInString(line[i:])
+			if i+size > limit {
+				break
+			}
+			i += size
+		}
 
-// Examples:
-//
-//	P15DT5H0M20S
-//	P7W
-func parseDuration(s string) (d Duration, err error) {
-	orig := s
-	neg := false
-	if s != "" && (s[0] == '-' || s[0] == '+') {
-		neg = s[0] == '-'
-		s = s[1:]
+		s.WriteString(line[:i])
+		s.WriteString("\r\n ")
+		line = line[i:]
+		limit = maxLineOctets - 1
 	}
+	s.WriteString(line)
+	s.WriteString("\r\n")
 
-	if len(s) == 0 && s[0] != 'P' {
-		return d, fmt.Errorf("invalid duration: P required %s", orig)
-	}
-	s = s[1:]
+	_, err := io.WriteString(w, s.String())
+	return err
+}
 
-	for s != "" {
-		if s[0] == 'T' {
-			s = s[1:]
-			continue
-		}
+// parseDateTime parse DATE-TIME or DATE with VALUE and TZID parameter of the property
+// TZID is resolved with VTIMEZONE of the calendar first, then tzdata
+func parseDateTime(value string, params Params, tzs timezones) (tm DateTime, err error) {
+	valueType := strings.ToUpper(params.Get("VALUE"))
 
-		if !('0' <= s[0] && s[0] <= '9') {
-			log.Debugf("digit required: %s, %s, %v", s, orig)
-			return d, fmt.Errorf("invalid duration: %s", orig)
+	// Date
+	if valueType == "" || valueType == "DATE" {
+		if tm.Time, err = time.Parse("20060102", value); err == nil || valueType == "DATE" {
+			tm.isDate = err == nil
+			return
 		}
-		v := 0
-		v, s = leadingInt(s)
+	}
 
-		if len(s) == 0 {
-			log.Debugf("unit required: %s, %s", s, orig)
-			return d, fmt.Errorf("invalid duration: %s", orig)
-		}
+	if valueType != "" && valueType != "DATE-TIME" {
+		return tm, fmt.Errorf("unsupported value type: %s", valueType)
+	}
 
-		switch s[0] {
-		case 'W':
-			d.Duration += time.Duration(v) * Week
-		case 'D':
-			d.Duration += time.Duration(v) * Day
-		case 'H':
-			d.Duration += time.Duration(v) * time.Hour
-		case 'M':
-			d.Duration += time.Duration(v) * time.Minute
-		case 'S':
-			d.Duration += time.Duration(v) * time.Second
-		default:
-			log.Debugf("invalid unit: %s, %s", s, orig)
-			return d, fmt.Errorf("invalid duration: %s", orig)
+	// UTC
+	if tm.Time, err = timeParse([]string{"20060102T150405Z", "20060102T1504Z"}, value, time.UTC); err == nil {
+		return
+	}
+
+	loc := time.Local
+	if tzid := params.Get("TZID"); tzid != "" {
+		if loc, err = tzs.location(tzid); err != nil {
+			return tm, fmt.Errorf("invalid timezone: %s", tzid)
 		}
-		s = s[1:]
 	}
 
-	if neg {
-		d.Duration = -d.Duration
+	// local, with timezone
+	if tm.Time, err = timeParse([]string{"20060102T150405", "20060102T1504"}, value, loc); err == nil {
+		return tm, nil
 	}
 
-	return d, nil
+	return tm, err
 }
 
-func leadingInt(s string) (int, string) {
-	i := 0
-	for ; i < len(s); i++ {
-		if '0' <= s[i] && s[i] <= '9' {
-			continue
+// parseDateTimeList parse comma separated DATE-TIME or DATE list; EXDATE, RDATE
+func parseDateTimeList(value string, params Params, tzs timezones) ([]DateTime, error) {
+	dates := []DateTime{}
+	for _, v := range strings.Split(value, ",") {
+		dt, err := parseDateTime(v, params, tzs)
+		if err != nil {
+			return nil, err
 		}
-		break
+		dates = append(dates, dt)
 	}
 
-	v, _ := strconv.Atoi(s[0:i])
-	return v, s[i:]
+	return dates, nil
 }
 
-func (d *Duration) String() string {
-	du := d.Duration
-	s := new(strings.Builder)
-
-	if du < 0 {
-		s.WriteString("-")
-		du = -du
+func timeParse(layouts []string, s string, loc *time.Location) (tm time.Time, err error) {
+	for _, layout := range layouts {
+		tm, err = time.ParseInLocation(layout, s, loc)
+		if err == nil {
+			return
+		}
 	}
+	return
+}
 
-	s.WriteString("P")
+// DateTime represents DATE-TIME or DATE
+// - 4.3.4 Date
+// - 4.3.5 DateTime
+//
+// time in UTC is written as UTC, in time.Local as floating time, in other location with TZID parameter
+type DateTime struct {
+	time.Time
 
-	if du > Week {
-		weeks := du / Week
-		du = du % Week
+	isDate bool
+}
 
-		fmt.Fprintf(s, "%dW", weeks)
-	}
+// NewDate returns DATE value
+func NewDate(year int, month time.Month, day int) DateTime {
+	return DateTime{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC), isDate: true}
+}
 
-	if du > Day {
-		days := du / Day
-		du = du % Day
+// IsDate returns true if it is DATE value; written with VALUE=DATE parameter
+func (dt *DateTime) IsDate() bool { return dt.isDate }
 
-		fmt.Fprintf(s, "%dD", days)
+func (dt *DateTime) String() string {
+	if dt.isDate {
+		return dt.Format("20060102")
 	}
 
-	if du > 0 {
-		s.WriteRune('T')
+	if dt.Location() == time.UTC {
+		return dt.Format("20060102T150405Z")
 	}
 
-	if du > time.Hour {
-		hours := du / time.Hour
-		du = du % time.Hour
+	return dt.Format("20060102T150405")
+}
 
-		fmt.Fprintf(s, "%dH", hours)
+// TZID returns TZID parameter; empty for date, UTC and floating time
+func (dt *DateTime) TZID() string {
+	if dt.isDate || dt.Location() == time.UTC || dt.Location() == time.Local {
+		return ""
 	}
 
-	if du > time.Minute {
-		minutes := du / time.Minute
-		du = du % time.Minute
+	return dt.Location().String()
+}
 
-		fmt.Fprintf(s, "%dM", minutes)
+// params returns VALUE and TZID parameters of the date-time
+func (dt *DateTime) params() string {
+	s := ""
+	if dt.isDate {
+		s += ";VALUE=DATE"
 	}
-
-	if du > time.Second {
-		seconds := du / time.Minute
-
-		fmt.Fprintf(s, "%dS", seconds)
+	if tzid := dt.TZID(); tzid != "" {
+		s += ";TZID=" + tzid
 	}
-
-	return s.String()
+	return s
 }
 
-// Time
-// 4.3.12 Time
-type Time struct {
-	time.Time
-}
+// parseRDate parse RDATE value list; DATE, DATE-TIME or PERIOD
+func parseRDate(value string, params Params, tzs timezones) ([]Period, error) {
+	if !strings.EqualFold(params.Get("VALUE"), "PERIOD") {
+		dates, err := parseDateTimeList(value, params, tzs)
+		if err != nil {
+			return nil, err
+		}
 
-func parseTime(s string) (tm Time, err error) {
-	if tm.Time, err = time.Parse("150405Z", s); err == nil {
-		return
+		periods := make([]Period, len(dates))
+		for i, dt := range dates {
+			periods[i] = Period{Start: dt}
+		}
+		return periods, nil
 	}
 
-	if tm.Time, err = time.ParseInLocation("150405", s, time.Local); err == nil {
-		return
+	periods := []Period{}
+	for _, v := range strings.Split(value, ",") {
+		period, err := parsePeriod(v, params, tzs)
+		if err != nil {
+			return nil, err
+		}
+		periods = append(periods, period)
 	}
 
-	return
+	return periods, nil
 }
@@ -1,8 +1,11 @@
 package ical
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/require"
 )
@@ -12,7 +15,7 @@ func mustParseDateTime(s string) DateTime {
 		return DateTime{}
 	}
 
-	tm, err := parseDateTime(s)
+	tm, err := parseDateTime(s, nil, nil)
 	if err != nil {
 		panic(err)
 	}
@@ -30,6 +33,7 @@ func TestEscapeText(t *testing.T) {
 	}{
 		{`valid`, args{"\\"}, `\\`},
 		{`valid`, args{"\\n"}, `\\n`},
+		{`new line`, args{"a\nb"}, `a\nb`},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -41,100 +45,166 @@ func TestEscapeText(t *testing.T) {
 	}
 }
 
-func TestDateTime(t *testing.T) {
-	tzEastern, _ := time.LoadLocation("US/Eastern")
-	_ = tzEastern
-
+func TestWriteLine(t *testing.T) {
 	type args struct {
-		s string
+		line string
 	}
 	tests := [...]struct {
-		name    string
-		args    args
-		want    DateTime
-		wantStr string
-		wantErr bool
+		name string
+		args args
+		want string
 	}{
-		{`valid date`, args{"19970714"},
-			DateTime{
-				Time:   time.Date(1997, 7, 14, 0, 0, 0, 0, time.UTC),
-				isDate: true},
-			"19970714", false},
-		{`valid local date-time`, args{"19980118T230000"},
-			DateTime{Time: time.Date(1998, 1, 18, 23, 0, 0, 0, time.Local)},
-			"19980118T230000", false},
-		{`valid utc date-time`, args{"19980119T070000Z"},
-			DateTime{Time: time.Date(1998, 1, 19, 7, 0, 0, 0, time.UTC)},
-			"19980119T070000Z", false},
-		{`valid tzid date-time`, args{"TZID=US-Eastern:19970714T133000"},
-			DateTime{Time: time.Date(1997, 7, 14, 13, 30, 0, 0, tzEastern), withTZID: true},
-			"TZID=US-Eastern:19970714T133000", false},
-		{`valid date-time`, args{"19970901T1300Z"},
-			DateTime{Time: time.Date(1997, 9, 1, 13, 0, 0, 0, time.UTC)},
-			"19970901T130000Z", false},
+		{`short`, args{"SUMMARY:hello"}, "SUMMARY:hello\r\n"},
+		{`75 octets`, args{strings.Repeat("a", 75)}, strings.Repeat("a", 75) + "\r\n"},
+		{`76 octets`, args{strings.Repeat("a", 76)}, strings.Repeat("a", 75) + "\r\n a\r\n"},
+		{`continued twice`, args{strings.Repeat("a", 75+74+1)}, strings.Repeat("a", 75) + "\r\n " + strings.Repeat("a", 74) + "\r\n a\r\n"},
+		{`multi bytes`, args{"SUMMARY:" + strings.Repeat("가", 30)}, "SUMMARY:" + strings.Repeat("가", 22) + "\r\n " + strings.Repeat("가", 8) + "\r\n"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := parseDateTime(tt.args.s)
-			require.Truef(t, (err != nil) == tt.wantErr, `parseDateTime() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
-			if tt.wantErr {
-				return
-			}
-			require.Equal(t, tt.want, got, "want %v but got %v", tt.want.Time.String(), got.Time.String())
-			require.Equal(t, tt.wantStr, got.String())
+			buf := new(bytes.Buffer)
+			require.NoError(t, writeLine(buf, tt.args.line))
+			require.Equal(t, tt.want, buf.String())
+
+			lines, err := readLines(buf)
+			require.NoError(t, err)
+			require.Equal(t, []textLine{{tt.args.line, 1}}, lines)
 		})
 	}
 }
 
-func TestDuration(t *testing.T) {
+func TestReadLines(t *testing.T) {
 	type args struct {
-		s string
+		data string
 	}
 	tests := [...]struct {
-		name    string
-		args    args
-		want    time.Duration
-		wantStr string
-		wantErr bool
+		name string
+		args args
+		want []textLine
 	}{
-		{`valid`, args{"P15DT5H0M20S"}, 15*Day + 5*time.Hour + 20*time.Second, "P2W1DT5H0S", false},
-		{`valid`, args{"-P15DT5H0M20S"}, -(15*Day + 5*time.Hour + 20*time.Second), "-P2W1DT5H0S", false},
-		{`valid`, args{"P7W"}, 7 * Week, "P7W", false},
+		{`crlf`, args{"BEGIN:VEVENT\r\nSUMMARY:a\r\nEND:VEVENT\r\n"}, []textLine{{"BEGIN:VEVENT", 1}, {"SUMMARY:a", 2}, {"END:VEVENT", 3}}},
+		{`lf`, args{"BEGIN:VEVENT\nSUMMARY:a\nEND:VEVENT"}, []textLine{{"BEGIN:VEVENT", 1}, {"SUMMARY:a", 2}, {"END:VEVENT", 3}}},
+		{`folded with space`, args{"DESCRIPTION:This is a lo\r\n ng description\r\n  that exists on a long line.\r\nEND:VEVENT"},
+			[]textLine{{"DESCRIPTION:This is a long description that exists on a long line.", 1}, {"END:VEVENT", 4}}},
+		{`folded with tab`, args{"SUMMARY:a\r\n\tb\r\n"}, []textLine{{"SUMMARY:ab", 1}}},
+		{`folded last line`, args{"SUMMARY:a\r\n b"}, []textLine{{"SUMMARY:ab", 1}}},
+		{`folded multi bytes`, args{"SUMMARY:\xea\xb0\r\n \x80"}, []textLine{{"SUMMARY:가", 1}}},
+		{`empty lines`, args{"\r\nSUMMARY:a\r\n\r\n"}, []textLine{{"SUMMARY:a", 2}}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := parseDuration(tt.args.s)
-			require.Truef(t, (err != nil) == tt.wantErr, `parseDuration() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
-			if tt.wantErr {
-				return
-			}
-			require.Equal(t, tt.want, got.Duration)
-			require.Equal(t, tt.wantStr, got.String())
+			got, err := readLines(strings.NewReader(tt.args.data))
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
 		})
 	}
 }
 
-func TestTime(t *testing.T) {
+func FuzzFolding(f *testing.F) {
+	f.Add("SUMMARY:hello")
+	f.Add("DESCRIPTION:" + strings.Repeat("가나다", 40))
+	f.Add(strings.Repeat(" ", 200))
+	f.Add("X-EMOJI:" + strings.Repeat("😀", 30))
+
+	f.Fuzz(func(t *testing.T, line string) {
+		if line == "" || strings.ContainsAny(line, "\r\n") || line[0] == ' ' || line[0] == '\t' {
+			t.Skip()
+		}
+
+		buf := new(bytes.Buffer)
+		require.NoError(t, writeLine(buf, line))
+
+		folded := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+		for i, l := range folded {
+			require.LessOrEqual(t, len(l), maxLineOctets)
+			if i > 0 {
+				require.Equal(t, byte(' '), l[0])
+			}
+			if utf8.ValidString(line) {
+				require.True(t, utf8.ValidString(l), "UTF-8 character was split: %q", l)
+			}
+		}
+
+		got, err := readLines(buf)
+		require.NoError(t, err)
+		require.Equal(t, []textLine{{line, 1}}, got)
+	})
+}
+
+func FuzzEventText(f *testing.F) {
+	f.Add("Summer Vacation!", "long long description")
+	f.Add("회의;장소,시간", strings.Repeat("여러 줄\n설명 ", 20))
+	f.Add(`back\slash\n`, "  leading spaces")
+
+	f.Fuzz(func(t *testing.T, summary string, description string) {
+		evt := &VEvent{
+			UID:         "19970901T130000Z-123401@example.com",
+			DtStart:     DateTime{Time: time.Date(1997, 9, 3, 16, 30, 0, 0, time.UTC)},
+			Summary:     Text{Value: summary},
+			Description: Text{Value: description},
+		}
+
+		buf := new(bytes.Buffer)
+		require.NoError(t, NewEventEncoder(buf).Encode(evt))
+
+		got := new(VEvent)
+		require.NoError(t, NewEventDecoder(buf).Decode(got))
+		require.Equal(t, evt, got)
+	})
+}
+
+func TestDateTime(t *testing.T) {
+	tzEastern, _ := time.LoadLocation("US/Eastern")
+
 	type args struct {
-		s string
+		s      string
+		params Params
 	}
 	tests := [...]struct {
 		name    string
 		args    args
-		want    Time
+		want    DateTime
+		wantStr string
 		wantErr bool
 	}{
-		{`valid`, args{"230000Z"}, Time{Time: time.Date(0, 1, 1, 23, 0, 0, 0, time.UTC)}, false},
-		{`valid`, args{"230000"}, Time{Time: time.Date(0, 1, 1, 23, 0, 0, 0, time.Local)}, false},
+		{`valid date`, args{"19970714", nil},
+			DateTime{
+				Time:   time.Date(1997, 7, 14, 0, 0, 0, 0, time.UTC),
+				isDate: true},
+			"19970714", false},
+		{`valid local date-time`, args{"19980118T230000", nil},
+			DateTime{Time: time.Date(1998, 1, 18, 23, 0, 0, 0, time.Local)},
+			"19980118T230000", false},
+		{`valid utc date-time`, args{"19980119T070000Z", nil},
+			DateTime{Time: time.Date(1998, 1, 19, 7, 0, 0, 0, time.UTC)},
+			"19980119T070000Z", false},
+		{`valid tzid date-time`, args{"19970714T133000", Params{"TZID": {"US/Eastern"}}},
+			DateTime{Time: time.Date(1997, 7, 14, 13, 30, 0, 0, tzEastern)},
+			"19970714T133000", false},
+		{`valid tzid utc date-time`, args{"19970714T133000Z", Params{"TZID": {"US/Eastern"}}},
+			DateTime{Time: time.Date(1997, 7, 14, 13, 30, 0, 0, time.UTC)},
+			"19970714T133000Z", false},
+		{`invalid tzid`, args{"19970714T133000", Params{"TZID": {"US-Eastern"}}}, DateTime{}, "", true},
+		{`valid value date`, args{"19970714", Params{"VALUE": {"DATE"}}},
+			DateTime{
+				Time:   time.Date(1997, 7, 14, 0, 0, 0, 0, time.UTC),
+				isDate: true},
+			"19970714", false},
+		{`invalid value date`, args{"19970714T133000", Params{"VALUE": {"DATE"}}}, DateTime{}, "", true},
+		{`unsupported value type`, args{"19970714", Params{"VALUE": {"PERIOD"}}}, DateTime{}, "", true},
+		{`valid date-time`, args{"19970901T1300Z", nil},
+			DateTime{Time: time.Date(1997, 9, 1, 13, 0, 0, 0, time.UTC)},
+			"19970901T130000Z", false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := parseTime(tt.args.s)
-			require.Truef(t, (err != nil) == tt.wantErr, `parseTime() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			got, err := parseDateTime(tt.args.s, tt.args.params, nil)
+			require.Truef(t, (err != nil) == tt.wantErr, `parseDateTime() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
 			if tt.wantErr {
 				return
 			}
-			require.Equalf(t, tt.want, got, "%s %s", tt.want.String(), got.Time.String())
+			require.Equal(t, tt.want, got, "want %v but got %v", tt.want.Time.String(), got.Time.String())
+			require.Equal(t, tt.wantStr, got.String())
 		})
 	}
 }
@@ -0,0 +1,361 @@
+package ical
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// jCal(RFC 7265) JSON format of iCalendar; component is [name, [properties...], [components...]]
+//
+//	["vcalendar", [
+//	  ["version", {}, "text", "2.0"],
+//	  ["prodid", {}, "text", "-//Example Inc.//Example Calendar//EN"]
+//	], [
+//	  ["vevent", [
+//	    ["dtstart", {"tzid": "Asia/Seoul"}, "date-time", "2023-06-01T09:00:00"],
+//	    ["rrule", {}, "recur", {"freq": "WEEKLY", "byday": ["MO", "WE"]}]
+//	  ], []]
+//	]]
+//
+// VCalendar is encoded as vcalendar; VEvent, VTodo and VJournal are encoded as the component
+
+type jcalEncoder struct {
+	w io.Writer
+}
+
+var _ Encoder = (*jcalEncoder)(nil)
+
+func NewJCalEncoder(w io.Writer) Encoder {
+	return &jcalEncoder{w: w}
+}
+
+func (enc *jcalEncoder) Encode(v any) error {
+	c, err := encodeRawComponent(v)
+	if err != nil {
+		return err
+	}
+
+	jcal, err := jcalComponent(c)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(enc.w).Encode(jcal)
+}
+
+func jcalComponent(c *rawComponent) ([]any, error) {
+	properties := make([]any, len(c.properties))
+	for i, cl := range c.properties {
+		prop, err := jcalProperty(cl)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s", cl.name)
+		}
+		properties[i] = prop
+	}
+
+	components := make([]any, len(c.components))
+	for i, sub := range c.components {
+		component, err := jcalComponent(sub)
+		if err != nil {
+			return nil, err
+		}
+		components[i] = component
+	}
+
+	return []any{strings.ToLower(c.name), properties, components}, nil
+}
+
+// jcalProperty returns [name, {params}, type, value...]
+func jcalProperty(cl *contentLine) ([]any, error) {
+	typ := valueType(cl)
+
+	params := map[string]any{}
+	for name, values := range cl.params {
+		switch {
+		case name == "VALUE":
+		case len(values) == 1:
+			params[strings.ToLower(name)] = values[0]
+		default:
+			params[strings.ToLower(name)] = values
+		}
+	}
+
+	prop := []any{strings.ToLower(cl.name), params, typ}
+	switch {
+	case typ == typeRecur:
+		parts, err := splitRecur(cl.value)
+		if err != nil {
+			return nil, err
+		}
+
+		recur := map[string]any{}
+		for _, part := range parts {
+			values := make([]any, len(part.values))
+			for i, v := range part.values {
+				values[i] = v
+				if recurIntegerParts[part.key] {
+					if values[i], err = strconv.Atoi(v); err != nil {
+						return nil, fmt.Errorf("invalid recur %s: %s", part.key, v)
+					}
+				}
+			}
+
+			if len(values) == 1 {
+				recur[part.key] = values[0]
+			} else {
+				recur[part.key] = values
+			}
+		}
+		return append(prop, recur), nil
+
+	case structuredProperties[cl.name]:
+		components := splitUnescaped(cl.value, ';')
+		value := make([]any, len(components))
+		for i, v := range components {
+			component, err := jcalValue(typ, v)
+			if err != nil {
+				return nil, err
+			}
+			value[i] = component
+		}
+		return append(prop, value), nil
+	}
+
+	for _, v := range splitValues(cl) {
+		value, err := jcalValue(typ, v)
+		if err != nil {
+			return nil, err
+		}
+		prop = append(prop, value)
+	}
+	return prop, nil
+}
+
+// jcalValue returns JSON value of the iCalendar value
+func jcalValue(typ, v string) (any, error) {
+	switch typ {
+	case typeInteger:
+		return parseInteger(v)
+	case typeFloat:
+		return parseFloat(v)
+	case typeBoolean:
+		return parseBoolean(v)
+	}
+	return toExtended(typ, v)
+}
+
+type jcalDecoder struct {
+	r    io.Reader
+	opts []DecodeOption
+}
+
+var _ Decoder = (*jcalDecoder)(nil)
+
+// NewJCalDecoder returns decoder of jCal; options are applied as the text decoder
+func NewJCalDecoder(r io.Reader, opts ...DecodeOption) Decoder {
+	return &jcalDecoder{r: r, opts: opts}
+}
+
+func (d *jcalDecoder) Decode(v any) error {
+	var raw json.RawMessage
+	if err := json.NewDecoder(d.r).Decode(&raw); err != nil {
+		return errors.Wrap(err, "invalid jcal")
+	}
+
+	c, err := parseJCalComponent(raw)
+	if err != nil {
+		return errors.Wrap(err, "invalid jcal")
+	}
+
+	return decodeRawComponent(c, v, d.opts)
+}
+
+// parseJCalComponent parse [name, [properties...], [components...]]
+func parseJCalComponent(data json.RawMessage) (*rawComponent, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil || len(raw) != 3 {
+		return nil, errors.New("component must be [name, [properties...], [components...]]")
+	}
+
+	var name string
+	if err := json.Unmarshal(raw[0], &name); err != nil || name == "" {
+		return nil, errors.New("component name must be string")
+	}
+	c := &rawComponent{name: strings.ToUpper(name)}
+
+	var properties [][]json.RawMessage
+	if err := json.Unmarshal(raw[1], &properties); err != nil {
+		return nil, errors.Errorf("%s: properties must be array", name)
+	}
+	for i, prop := range properties {
+		cl, err := parseJCalProperty(prop)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s: property #%d", name, i)
+		}
+		c.properties = append(c.properties, cl)
+	}
+
+	var components []json.RawMessage
+	if err := json.Unmarshal(raw[2], &components); err != nil {
+		return nil, errors.Errorf("%s: components must be array", name)
+	}
+	for _, component := range components {
+		sub, err := parseJCalComponent(component)
+		if err != nil {
+			return nil, err
+		}
+		c.components = append(c.components, sub)
+	}
+
+	return c, nil
+}
+
+// parseJCalProperty parse [name, {params}, type, value...]
+func parseJCalProperty(prop []json.RawMessage) (*contentLine, error) {
+	if len(prop) < 4 {
+		return nil, errors.New("must be [name, params, type, value...]")
+	}
+
+	var name, typ string
+	if err := json.Unmarshal(prop[0], &name); err != nil || name == "" {
+		return nil, errors.New("name must be string")
+	}
+	if err := json.Unmarshal(prop[2], &typ); err != nil {
+		return nil, errors.New("type must be string")
+	}
+	typ = strings.ToLower(typ)
+
+	var rawParams map[string]json.RawMessage
+	if err := json.Unmarshal(prop[1], &rawParams); err != nil {
+		return nil, errors.New("params must be object")
+	}
+
+	cl := &contentLine{name: strings.ToUpper(name), params: Params{}}
+	for k, v := range rawParams {
+		values, err := jcalStrings(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "param %s", k)
+		}
+		cl.params[strings.ToUpper(k)] = values
+	}
+	setValueType(cl, typ)
+
+	switch {
+	case typ == typeRecur:
+		var recur map[string]json.RawMessage
+		if len(prop) != 4 || json.Unmarshal(prop[3], &recur) != nil {
+			return nil, errors.New("recur must be object")
+		}
+
+		keys := make([]string, 0, len(recur))
+		for k := range recur {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		parts := make([]recurPart, len(keys))
+		for i, k := range keys {
+			values, err := jcalStrings(recur[k])
+			if err != nil {
+				return nil, errors.Wrapf(err, "recur %s", k)
+			}
+			parts[i] = recurPart{key: strings.ToLower(k), values: values}
+		}
+
+		value, err := joinRecur(parts)
+		if err != nil {
+			return nil, err
+		}
+		cl.value = value
+		return cl, nil
+
+	case structuredProperties[cl.name]:
+		components, err := jcalStrings(prop[3])
+		if err != nil || len(prop) != 4 {
+			return nil, errors.New("structured value must be array")
+		}
+		for i, v := range components {
+			if typ == typeText {
+				components[i] = escape(v)
+			}
+		}
+		cl.value = strings.Join(components, ";")
+		return cl, nil
+	}
+
+	values := make([]string, len(prop)-3)
+	for i, raw := range prop[3:] {
+		v, err := jcalScalar(raw)
+		if err != nil {
+			return nil, err
+		}
+		if typ == typeBoolean {
+			v = strings.ToUpper(v)
+		}
+
+		if values[i], err = fromExtended(typ, v); err != nil {
+			return nil, err
+		}
+	}
+	cl.value = strings.Join(values, ",")
+
+	return cl, nil
+}
+
+// jcalStrings returns string or array of strings
+func jcalStrings(raw json.RawMessage) ([]string, error) {
+	var values []json.RawMessage
+	if err := json.Unmarshal(raw, &values); err != nil {
+		s, err := jcalScalar(raw)
+		if err != nil {
+			return nil, err
+		}
+		return []string{s}, nil
+	}
+
+	result := make([]string, len(values))
+	for i, v := range values {
+		s, err := jcalScalar(v)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = s
+	}
+	return result, nil
+}
+
+// jcalScalar returns string of string, number, boolean or null value; boolean is TRUE or FALSE
+func jcalScalar(raw json.RawMessage) (string, error) {
+	var v any
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return "", err
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		// exponent is not allowed in FLOAT
+		if strings.ContainsAny(v.String(), "eE") {
+			f, err := v.Float64()
+			if err != nil {
+				return "", err
+			}
+			return formatFloat(f), nil
+		}
+		return v.String(), nil
+	case bool:
+		return formatBoolean(v), nil
+	}
+	return "", errors.Errorf("unexpected value: %s", raw)
+}
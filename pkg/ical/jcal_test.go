@@ -0,0 +1,212 @@
+package ical
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func jcalTestEvent(t *testing.T) *VEvent {
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	require.NoError(t, err)
+
+	return &VEvent{
+		UID:         "20230601T000000Z-1@example.com",
+		DtStamp:     DateTime{Time: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)},
+		DtStart:     DateTime{Time: time.Date(2023, 6, 1, 9, 0, 0, 0, seoul)},
+		Duration:    Duration{Duration: 90 * time.Minute},
+		Summary:     Text{Value: "Weekly; sync, notes", Parameters: Parameters{Language: "ko"}},
+		Description: Text{Value: "line1\nline2"},
+		Geo:         "37.386013;-122.082932",
+		Seq:         2,
+		Categories:  Categories{Values: []string{"WORK", "MEETING"}},
+		Organizer:   CalAddress{Value: "mailto:jane@example.com", Parameters: Parameters{CN: "Doe, Jane"}},
+		Attendees: []CalAddress{{Value: "mailto:js@example.com", Parameters: Parameters{
+			CN:   "John",
+			Role: RoleChair,
+			RSVP: true,
+		}}},
+		RRule: &RecurrenceRule{Freq: FreqWeekly, Until: DateTime{Time: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)},
+			ByDay: []WeekdayNum{{Weekday: time.Monday}, {Weekday: time.Wednesday}}},
+		ExDates:    []DateTime{{Time: time.Date(2023, 6, 7, 9, 0, 0, 0, seoul)}, {Time: time.Date(2023, 6, 14, 9, 0, 0, 0, seoul)}},
+		RDates:     []Period{{Start: DateTime{Time: time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)}, End: DateTime{Time: time.Date(2023, 6, 2, 1, 0, 0, 0, time.UTC)}}},
+		Extensions: []Property{{Name: "X-MICROSOFT-CDO-BUSYSTATUS", Value: "BUSY"}, {Name: "X-ALT-DESC", Params: ";FMTTYPE=text/html", Value: "<p>Agenda</p>"}},
+	}
+}
+
+func TestJCalEncode(t *testing.T) {
+	evt := jcalTestEvent(t)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, NewJCalEncoder(buf).Encode(evt))
+
+	var got []any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	require.Equal(t, "vevent", got[0])
+	require.Equal(t, []any{}, got[2])
+
+	properties := map[string][]any{}
+	for _, prop := range got[1].([]any) {
+		prop := prop.([]any)
+		properties[prop[0].(string)] = prop
+	}
+	require.Equal(t, []any{"dtstart", map[string]any{"tzid": "Asia/Seoul"}, "date-time", "2023-06-01T09:00:00"}, properties["dtstart"])
+	require.Equal(t, []any{"dtstamp", map[string]any{}, "date-time", "2023-06-01T00:00:00Z"}, properties["dtstamp"])
+	require.Equal(t, []any{"duration", map[string]any{}, "duration", "PT1H30M"}, properties["duration"])
+	require.Equal(t, []any{"summary", map[string]any{"language": "ko"}, "text", "Weekly; sync, notes"}, properties["summary"])
+	require.Equal(t, []any{"description", map[string]any{}, "text", "line1\nline2"}, properties["description"])
+	require.Equal(t, []any{"geo", map[string]any{}, "float", []any{37.386013, -122.082932}}, properties["geo"])
+	require.Equal(t, []any{"sequence", map[string]any{}, "integer", float64(2)}, properties["sequence"])
+	require.Equal(t, []any{"categories", map[string]any{}, "text", "WORK", "MEETING"}, properties["categories"])
+	require.Equal(t, []any{"rrule", map[string]any{}, "recur", map[string]any{"freq": "WEEKLY", "until": "2023-12-31T00:00:00Z", "byday": []any{"MO", "WE"}}}, properties["rrule"])
+	require.Equal(t, []any{"exdate", map[string]any{"tzid": "Asia/Seoul"}, "date-time", "2023-06-07T09:00:00", "2023-06-14T09:00:00"}, properties["exdate"])
+	require.Equal(t, []any{"rdate", map[string]any{}, "period", "2023-06-02T00:00:00Z/2023-06-02T01:00:00Z"}, properties["rdate"])
+	require.Equal(t, []any{"x-microsoft-cdo-busystatus", map[string]any{}, "unknown", "BUSY"}, properties["x-microsoft-cdo-busystatus"])
+	require.Equal(t, []any{"x-alt-desc", map[string]any{"fmttype": "text/html"}, "unknown", "<p>Agenda</p>"}, properties["x-alt-desc"])
+
+	decoded := new(VEvent)
+	require.NoError(t, NewJCalDecoder(buf).Decode(decoded))
+	require.Equal(t, evt, decoded)
+}
+
+func TestJCalDecode(t *testing.T) {
+	type args struct {
+		data string
+		opts []DecodeOption
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+		want    *VCalendar
+	}{
+		{`rfc7265 example`, args{`["vcalendar",
+  [
+    ["calscale", {}, "text", "GREGORIAN"],
+    ["prodid", {}, "text", "-//Example Inc.//Example Calendar//EN"],
+    ["version", {}, "text", "2.0"]
+  ],
+  [
+    ["vevent",
+      [
+        ["dtstamp", {}, "date-time", "2008-02-05T19:12:24Z"],
+        ["dtstart", {}, "date", "2008-10-06"],
+        ["summary", {}, "text", "Planning meeting"],
+        ["uid", {}, "text", "4088E990AD89CB3DBB484909"]
+      ],
+      []
+    ]
+  ]
+]`, nil}, false, &VCalendar{
+			ProdID:   "-//Example Inc.//Example Calendar//EN",
+			Version:  "2.0",
+			CalScale: "GREGORIAN",
+			Events: []*VEvent{{
+				UID:     "4088E990AD89CB3DBB484909",
+				DtStamp: DateTime{Time: time.Date(2008, 2, 5, 19, 12, 24, 0, time.UTC)},
+				DtStart: DateTime{Time: time.Date(2008, 10, 6, 0, 0, 0, 0, time.UTC), isDate: true},
+				Summary: Text{Value: "Planning meeting"},
+			}},
+		}},
+		{`recur, utc-offset and number params`, args{`["vcalendar", [["prodid", {}, "text", "-//a//b//EN"], ["version", {}, "text", "2.0"]], [
+  ["vtimezone", [["tzid", {}, "text", "America/New_York"]], [
+    ["standard", [
+      ["dtstart", {}, "date-time", "2007-11-04T02:00:00"],
+      ["rrule", {}, "recur", {"freq": "YEARLY", "bymonth": 11, "byday": "1SU"}],
+      ["tzoffsetfrom", {}, "utc-offset", "-04:00"],
+      ["tzoffsetto", {}, "utc-offset", "-05:00"]
+    ], []]
+  ]],
+  ["vevent", [
+    ["uid", {}, "text", "1"],
+    ["dtstamp", {}, "date-time", "2008-02-05T19:12:24Z"],
+    ["dtstart", {"tzid": "America/New_York"}, "date-time", "2008-10-06T10:00:00"],
+    ["attendee", {"cn": "John", "rsvp": true, "delegated-to": ["mailto:a@example.com", "mailto:b@example.com"]}, "cal-address", "mailto:js@example.com"]
+  ], []]
+]]`, nil}, false, nil},
+		{`strict`, args{`["vcalendar", [["prodid", {}, "text", "-//a//b//EN"], ["version", {}, "text", "2.0"]], [["vevent", [["summary", {}, "text", "a"]], []]]]`,
+			[]DecodeOption{Strict()}}, true, nil},
+		{`not json`, args{`BEGIN:VCALENDAR`, nil}, true, nil},
+		{`not component`, args{`{"vcalendar": []}`, nil}, true, nil},
+		{`not vcalendar`, args{`["vevent", [], []]`, nil}, true, nil},
+		{`invalid property`, args{`["vcalendar", [["prodid", {}, "text"]], []]`, nil}, true, nil},
+		{`invalid params`, args{`["vcalendar", [["prodid", [], "text", "a"]], []]`, nil}, true, nil},
+		{`invalid date`, args{`["vcalendar", [], [["vevent", [["dtstart", {}, "date", "20081006"]], []]]]`, nil}, true, nil},
+		{`invalid recur`, args{`["vcalendar", [], [["vevent", [["rrule", {}, "recur", "FREQ=DAILY"]], []]]]`, nil}, true, nil},
+		{`invalid name`, args{`["vcalendar", [["pro did", {}, "text", "a"]], []]`, nil}, true, nil},
+		{`line break in parameter`, args{`["vcalendar", [["prodid", {"x-a": "a\r\nb"}, "text", "a"]], []]`, nil}, true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := new(VCalendar)
+			err := NewJCalDecoder(strings.NewReader(tt.args.data), tt.args.opts...).Decode(got)
+			require.Truef(t, (err != nil) == tt.wantErr, `Decode() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			if tt.want != nil {
+				require.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestJCalDecodeError(t *testing.T) {
+	data := `["vevent", [["dtstart", {}, "date-time", "2008-10-06T10:00:00"], ["dtend", {}, "date", "2008-10-07"]], []]`
+
+	err := NewJCalDecoder(strings.NewReader(data), Strict()).Decode(new(VEvent))
+	var errs DecodeErrors
+	require.ErrorAs(t, err, &errs)
+	require.NotEmpty(t, errs)
+	for _, e := range errs {
+		require.Zero(t, e.Line)
+		require.NotEmpty(t, e.Property)
+	}
+	require.Equal(t, "UID: required in VEVENT", errs[0].Error())
+}
+
+func TestExtendedValue(t *testing.T) {
+	type args struct {
+		typ   string
+		value string
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{`date`, args{typeDate, "19970714"}, "1997-07-14", false},
+		{`date-time`, args{typeDateTime, "19970714T133000"}, "1997-07-14T13:30:00", false},
+		{`date-time utc`, args{typeDateTime, "19970714T173000Z"}, "1997-07-14T17:30:00Z", false},
+		{`time`, args{typeTime, "230000Z"}, "23:00:00Z", false},
+		{`utc-offset`, args{typeUTCOffset, "-0500"}, "-05:00", false},
+		{`utc-offset with seconds`, args{typeUTCOffset, "+053028"}, "+05:30:28", false},
+		{`period`, args{typePeriod, "19970101T180000Z/19970102T070000Z"}, "1997-01-01T18:00:00Z/1997-01-02T07:00:00Z", false},
+		{`period with duration`, args{typePeriod, "19970101T180000Z/PT5H30M"}, "1997-01-01T18:00:00Z/PT5H30M", false},
+		{`text`, args{typeText, `a\, b\; c\\d\ne`}, "a, b; c\\d\ne", false},
+		{`duration`, args{typeDuration, "P1W"}, "P1W", false},
+		{`invalid date`, args{typeDate, "1997-07-14"}, "", true},
+		{`invalid utc-offset`, args{typeUTCOffset, "0500"}, "", true},
+		{`invalid period`, args{typePeriod, "19970101T180000Z"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toExtended(tt.args.typ, tt.args.value)
+			require.Truef(t, (err != nil) == tt.wantErr, `toExtended() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+			require.Equal(t, tt.want, got)
+
+			value, err := fromExtended(tt.args.typ, got)
+			require.NoError(t, err)
+			require.Equal(t, tt.args.value, value)
+		})
+	}
+}
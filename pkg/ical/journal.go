@@ -0,0 +1,187 @@
+package ical
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/whitekid/goxp"
+)
+
+// VJournal rfc5545 3.6.3 Journal Component
+type VJournal struct {
+	Class        string `validate:"max=100"` // PUBLIC(*), PRIVATE, CONFIDENTIAL
+	Created      DateTime
+	Description  string   `validate:"max=500"`
+	DtStamp      DateTime `validate:"required"`
+	DtStart      DateTime
+	LastModified DateTime
+	Organizer    string `validate:"max=100"`
+	RecurrenceID string `validate:"max=100"`
+	Seq          int
+	Status       string   `validate:"max=100"` // DRAFT, FINAL, CANCELLED
+	Summary      string   `validate:"max=100"`
+	UID          string   `validate:"max=100"`
+	URL          string   `validate:"max=100"`
+	Attach       string   `validate:"max=100"`
+	Attendee     string   `validate:"max=100"`
+	Categories   []string `validate:"max=100"`
+	Comment      string   `validate:"max=100"`
+	Contact      string   `validate:"max=100"`
+	ExDate       DateTime
+	Related      string `validate:"max=100"`
+	RDate        DateTime
+	RRule        string `validate:"max=100"`
+	RStatus      string `validate:"max=100"`
+
+	Extensions []Property // unknown and X- properties
+}
+
+type journalDecoder struct {
+	r    io.Reader
+	opts []DecodeOption
+}
+
+var _ Decoder = (*journalDecoder)(nil)
+
+func NewJournalDecoder(r io.Reader, opts ...DecodeOption) Decoder {
+	return &journalDecoder{r: r, opts: opts}
+}
+
+func (d *journalDecoder) Decode(v any) error {
+	journal, ok := v.(*VJournal)
+	if !ok {
+		return fmt.Errorf("v must be pointer of VJournal")
+	}
+
+	lines, err := readLines(d.r)
+	if err != nil {
+		return err
+	}
+
+	s := newDecodeState(d.opts)
+	if err := decodeJournal(s, journal, lines); err != nil {
+		return err
+	}
+	return s.err()
+}
+
+// decodeJournal decode unfolded content lines of VJOURNAL
+func decodeJournal(s *decodeState, journal *VJournal, lines []textLine) error {
+	seen, err := s.decodeComponent("VJOURNAL", lines, &journal.Extensions, func(cl *contentLine) (bool, error) {
+		key, params, value := cl.name, cl.params, cl.value
+
+		var err error
+		switch key {
+		case "CLASS":
+			journal.Class = unescape(value)
+		case "CREATED":
+			journal.Created, err = parseDateTime(value, params, s.timezones)
+		case "DESCRIPTION":
+			journal.Description = unescape(value)
+		case "DTSTAMP":
+			journal.DtStamp, err = parseDateTime(value, params, s.timezones)
+		case "DTSTART":
+			journal.DtStart, err = parseDateTime(value, params, s.timezones)
+		case "LAST-MODIFIED":
+			journal.LastModified, err = parseDateTime(value, params, s.timezones)
+		case "ORGANIZER":
+			journal.Organizer = unescape(value)
+		case "RECURRENCE-ID":
+			journal.RecurrenceID = unescape(value)
+		case "SEQUENCE":
+			journal.Seq, err = parseInteger(value)
+		case "STATUS":
+			journal.Status = unescape(value)
+		case "SUMMARY":
+			journal.Summary = unescape(value)
+		case "UID":
+			journal.UID = unescape(value)
+		case "URL":
+			journal.URL = unescape(value)
+		case "ATTACH":
+			journal.Attach = unescape(value)
+		case "ATTENDEE":
+			journal.Attendee = unescape(value)
+		case "CATEGORIES":
+			if len(value) != 0 {
+				journal.Categories = strings.Split(value, ",")
+			}
+		case "COMMENT":
+			journal.Comment = unescape(value)
+		case "CONTACT":
+			journal.Contact = unescape(value)
+		case "EXDATE":
+			journal.ExDate, err = parseDateTime(value, params, s.timezones)
+		case "RELATED":
+			journal.Related = unescape(value)
+		case "RDATE":
+			journal.RDate, err = parseDateTime(value, params, s.timezones)
+		case "RRULE":
+			journal.RRule = unescape(value)
+		case "REQUEST-STATUS":
+			journal.RStatus = value
+		default:
+			return false, nil
+		}
+
+		return true, err
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	s.validate(lines, "VJOURNAL", seen, []string{"UID", "DTSTAMP"}, nil)
+	return nil
+}
+
+type journalEncoder struct {
+	w io.Writer
+}
+
+var _ Encoder = (*journalEncoder)(nil)
+
+func NewJournalEncoder(w io.Writer) Encoder {
+	return &journalEncoder{w: w}
+}
+
+func (enc *journalEncoder) Encode(v any) error {
+	journal, ok := v.(*VJournal)
+	if !ok {
+		return fmt.Errorf("want *VJournal")
+	}
+
+	fmt.Fprintf(enc.w, "BEGIN:VJOURNAL\r\n")
+	if err := writeFields(enc.w, []*goxp.Tuple2[string, any]{
+		{"CLASS", journal.Class},
+		{"CREATED", journal.Created},
+		{"DESCRIPTION", journal.Description},
+		{"DTSTAMP", journal.DtStamp},
+		{"DTSTART", journal.DtStart},
+		{"LAST-MODIFIED", journal.LastModified},
+		{"ORGANIZER", journal.Organizer},
+		{"RECURRENCE-ID", journal.RecurrenceID},
+		{"SEQUENCE", journal.Seq},
+		{"STATUS", journal.Status},
+		{"SUMMARY", journal.Summary},
+		{"UID", journal.UID},
+		{"URL", journal.URL},
+		{"ATTACH", journal.Attach},
+		{"ATTENDEE", journal.Attendee},
+		{"CATEGORIES", journal.Categories},
+		{"COMMENT", journal.Comment},
+		{"CONTACT", journal.Contact},
+		{"EXDATE", journal.ExDate},
+		{"RELATED", journal.Related},
+		{"RDATE", journal.RDate},
+		{"RRULE", journal.RRule},
+		{"REQUEST-STATUS", rawValue(journal.RStatus)},
+	}); err != nil {
+		return err
+	}
+	if err := writeExtensions(enc.w, journal.Extensions); err != nil {
+		return err
+	}
+	fmt.Fprintf(enc.w, "END:VJOURNAL\r\n")
+	return nil
+}
@@ -0,0 +1,61 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournal(t *testing.T) {
+	type args struct {
+		data string
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+		want    *VJournal
+	}{
+		{`valid`, args{`BEGIN:VJOURNAL
+UID:19970901T130000Z-123405@example.com
+DTSTAMP:19970901T130000Z
+DTSTART:19970317
+SUMMARY:Staff meeting minutes
+DESCRIPTION:1. Staff meeting: Participants include Joe\, Lisa\, and Bob.\nAurora project plans were reviewed.
+STATUS:FINAL
+END:VJOURNAL
+`}, false, &VJournal{
+			UID:         "19970901T130000Z-123405@example.com",
+			DtStamp:     mustParseDateTime("19970901T130000Z"),
+			DtStart:     mustParseDateTime("19970317"),
+			Summary:     "Staff meeting minutes",
+			Description: "1. Staff meeting: Participants include Joe, Lisa, and Bob.\nAurora project plans were reviewed.",
+			Status:      "FINAL",
+		}},
+		{`invalid dtstart`, args{"BEGIN:VJOURNAL\nDTSTART:yesterday\nEND:VJOURNAL\n"}, true, nil},
+		{`unknown field`, args{"BEGIN:VJOURNAL\nDUE:19970317\nEND:VJOURNAL\n"}, false, &VJournal{
+			Extensions: []Property{{Name: "DUE", Value: "19970317"}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := new(VJournal)
+			err := NewJournalDecoder(strings.NewReader(tt.args.data)).Decode(got)
+			require.Truef(t, (err != nil) == tt.wantErr, `Decode() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			require.Equal(t, tt.want, got)
+
+			buf := new(bytes.Buffer)
+			require.NoError(t, NewJournalEncoder(buf).Encode(got))
+
+			decoded := new(VJournal)
+			require.NoError(t, NewJournalDecoder(buf).Decode(decoded))
+			require.Equal(t, got, decoded)
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package ical
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// contentLine 3.1 Content Lines
+//
+//	name *(";" param ) ":" value
+type contentLine struct {
+	name   string
+	params Params
+	value  string
+}
+
+// parseContentLine parse unfolded content line
+//
+//	ATTENDEE;CN="Doe, John";ROLE=REQ-PARTICIPANT:mailto:john@example.com
+//	CATEGORIES;X-TAGS=a,"b:c":WORK
+func parseContentLine(line string) (*contentLine, error) {
+	cl := &contentLine{params: Params{}}
+
+	i := strings.IndexAny(line, ";:")
+	if i <= 0 {
+		return nil, fmt.Errorf("invalid content line: %s", line)
+	}
+	cl.name = strings.ToUpper(line[:i])
+	if !isName(cl.name) {
+		return nil, fmt.Errorf("invalid property name: %s", line)
+	}
+	s := line[i:]
+
+	for s[0] == ';' {
+		name, rest, ok := strings.Cut(s[1:], "=")
+		if !ok || !isName(name) {
+			return nil, fmt.Errorf("invalid parameter: %s", line)
+		}
+		name = strings.ToUpper(name)
+		s = rest
+
+		for {
+			value := ""
+			if s != "" && s[0] == '"' {
+				end := strings.IndexByte(s[1:], '"')
+				if end < 0 {
+					return nil, fmt.Errorf("unterminated quoted parameter: %s", line)
+				}
+				value, s = s[1:end+1], s[end+2:]
+			} else {
+				end := strings.IndexAny(s, ",;:")
+				if end < 0 {
+					return nil, fmt.Errorf("value required: %s", line)
+				}
+				value, s = s[:end], s[end:]
+			}
+			cl.params[name] = append(cl.params[name], value)
+
+			if s == "" {
+				return nil, fmt.Errorf("value required: %s", line)
+			}
+			if s[0] != ',' {
+				break
+			}
+			s = s[1:]
+		}
+	}
+
+	if s[0] != ':' {
+		return nil, fmt.Errorf("invalid content line: %s", line)
+	}
+	cl.value = s[1:]
+
+	return cl, nil
+}
+
+// isName returns true if s is iana-token or x-name
+func isName(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// Params 3.2 Property Parameters; parameter name to values
+type Params map[string][]string
+
+// Get returns first value of the parameter
+func (p Params) Get(name string) string {
+	if v := p[name]; len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// String returns parameters as ";NAME=VALUE,..." sorted by name
+func (p Params) String() string {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	s := new(strings.Builder)
+	for _, name := range names {
+		writeParam(s, name, p[name]...)
+	}
+	return s.String()
+}
+
+func writeParam(s *strings.Builder, name string, values ...string) {
+	if len(values) == 0 {
+		return
+	}
+
+	s.WriteString(";" + name + "=")
+	for i, value := range values {
+		if i > 0 {
+			s.WriteString(",")
+		}
+
+		value = strings.ReplaceAll(value, `"`, "")
+		if strings.ContainsAny(value, ";:,") {
+			value = `"` + value + `"`
+		}
+		s.WriteString(value)
+	}
+}
+
+// participation roles, 3.2.16 Participation Role
+const (
+	RoleChair          = "CHAIR"
+	RoleReqParticipant = "REQ-PARTICIPANT"
+	RoleOptParticipant = "OPT-PARTICIPANT"
+	RoleNonParticipant = "NON-PARTICIPANT"
+)
+
+// participation status of event, 3.2.12 Participation Status
+const (
+	PartStatNeedsAction = "NEEDS-ACTION"
+	PartStatAccepted    = "ACCEPTED"
+	PartStatDeclined    = "DECLINED"
+	PartStatTentative   = "TENTATIVE"
+	PartStatDelegated   = "DELEGATED"
+)
+
+// Parameters commonly used property parameters; VALUE and TZID of date-time are given by DateTime
+type Parameters struct {
+	AltRep   string `validate:"max=500"` // 3.2.1 Alternate Text Representation, URI
+	CN       string `validate:"max=100"` // 3.2.2 Common Name
+	Language string `validate:"max=100"` // 3.2.10 Language, ex. en-US
+	PartStat string `validate:"max=100"` // 3.2.12 Participation Status
+	RelType  string `validate:"max=100"` // 3.2.15 Relationship Type, PARENT(*), CHILD, SIBLING
+	Role     string `validate:"max=100"` // 3.2.16 Participation Role, REQ-PARTICIPANT(*)
+	RSVP     bool   // 3.2.17 RSVP Expectation
+	Value    string `validate:"max=100"` // 3.2.20 Value Data Types, if not default
+	Others   Params // other parameters, written as is
+}
+
+func newParameters(params Params) Parameters {
+	p := Parameters{Others: Params{}}
+	for name, values := range params {
+		switch name {
+		case "ALTREP":
+			p.AltRep = params.Get(name)
+		case "CN":
+			p.CN = params.Get(name)
+		case "LANGUAGE":
+			p.Language = params.Get(name)
+		case "PARTSTAT":
+			p.PartStat = strings.ToUpper(params.Get(name))
+		case "RELTYPE":
+			p.RelType = strings.ToUpper(params.Get(name))
+		case "ROLE":
+			p.Role = strings.ToUpper(params.Get(name))
+		case "RSVP":
+			p.RSVP = strings.EqualFold(params.Get(name), "TRUE")
+		case "VALUE":
+			p.Value = strings.ToUpper(params.Get(name))
+		default:
+			p.Others[name] = values
+		}
+	}
+
+	if len(p.Others) == 0 {
+		p.Others = nil
+	}
+	return p
+}
+
+// String returns parameters as ";NAME=VALUE..."
+func (p *Parameters) String() string {
+	s := new(strings.Builder)
+	if p.AltRep != "" {
+		writeParam(s, "ALTREP", p.AltRep)
+	}
+	if p.CN != "" {
+		writeParam(s, "CN", p.CN)
+	}
+	if p.Language != "" {
+		writeParam(s, "LANGUAGE", p.Language)
+	}
+	if p.PartStat != "" {
+		writeParam(s, "PARTSTAT", p.PartStat)
+	}
+	if p.RelType != "" {
+		writeParam(s, "RELTYPE", p.RelType)
+	}
+	if p.Role != "" {
+		writeParam(s, "ROLE", p.Role)
+	}
+	if p.RSVP {
+		writeParam(s, "RSVP", "TRUE")
+	}
+	if p.Value != "" {
+		writeParam(s, "VALUE", p.Value)
+	}
+	s.WriteString(p.Others.String())
+
+	return s.String()
+}
+
+// Text TEXT value with parameters; ex. LANGUAGE, ALTREP
+type Text struct {
+	Value string `validate:"max=500"`
+	Parameters
+}
+
+// CalAddress 3.3.3 Calendar User Address with parameters; ex. CN, ROLE, PARTSTAT, RSVP
+//
+//	ATTENDEE;CN=John Smith;RSVP=TRUE:mailto:jsmith@example.com
+type CalAddress struct {
+	Value string `validate:"max=100"` // URI, mailto:...
+	Parameters
+}
+
+// Attachment 3.8.1.1 Attachment; URI or inline binary with ENCODING=BASE64 and VALUE=BINARY parameters
+//
+//	ATTACH;FMTTYPE=application/pdf:https://example.com/agenda.pdf
+type Attachment struct {
+	Value string `validate:"max=100"` // URI or base64 encoded binary
+	Parameters
+}
+
+// Categories 3.8.1.2 Categories; list of TEXT with LANGUAGE parameter
+//
+//	CATEGORIES;LANGUAGE=en:APPOINTMENT,EDUCATION
+type Categories struct {
+	Values []string `validate:"max=100"`
+	Parameters
+}
+
+func newCategories(value string, params Params) Categories {
+	c := Categories{Parameters: newParameters(params)}
+	for _, v := range splitUnescaped(value, ',') {
+		c.Values = append(c.Values, unescape(v))
+	}
+	return c
+}
+
+// Property property of X- or unknown IANA name; parameters and value are kept verbatim
+//
+//	X-ALT-DESC;FMTTYPE=text/html:<p>Agenda</p>
+type Property struct {
+	Name   string `validate:"max=100"`
+	Params string // parameters as given, ex. ;FMTTYPE=text/html
+	Value  string // value as given, not unescaped
+}
+
+// Parameters returns parsed parameters
+func (p *Property) Parameters() (Params, error) {
+	cl, err := parseContentLine("X" + p.Params + ":")
+	if err != nil {
+		return nil, err
+	}
+	return cl.params, nil
+}
+
+// String returns content line of the property
+func (p *Property) String() string {
+	return p.Name + p.Params + ":" + p.Value
+}
@@ -0,0 +1,158 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseContentLine(t *testing.T) {
+	type args struct {
+		line string
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		want    *contentLine
+		wantErr bool
+	}{
+		{`no params`, args{"SUMMARY:Meeting"}, &contentLine{name: "SUMMARY", params: Params{}, value: "Meeting"}, false},
+		{`value with colon`, args{"URL:http://example.com/a:b"}, &contentLine{name: "URL", params: Params{}, value: "http://example.com/a:b"}, false},
+		{`params`, args{"ATTENDEE;CN=John Smith;ROLE=REQ-PARTICIPANT:mailto:js@example.com"},
+			&contentLine{name: "ATTENDEE", params: Params{"CN": {"John Smith"}, "ROLE": {"REQ-PARTICIPANT"}}, value: "mailto:js@example.com"}, false},
+		{`quoted param`, args{`ATTENDEE;CN="Smith, John: Jr.";DELEGATED-TO="mailto:a@example.com","mailto:b@example.com":mailto:js@example.com`},
+			&contentLine{name: "ATTENDEE", params: Params{
+				"CN":           {"Smith, John: Jr."},
+				"DELEGATED-TO": {"mailto:a@example.com", "mailto:b@example.com"},
+			}, value: "mailto:js@example.com"}, false},
+		{`multi values`, args{"ATTENDEE;MEMBER=a,b;X-EMPTY=:mailto:a"},
+			&contentLine{name: "ATTENDEE", params: Params{"MEMBER": {"a", "b"}, "X-EMPTY": {""}}, value: "mailto:a"}, false},
+		{`lower case name`, args{"dtstart;value=DATE:19970714"}, &contentLine{name: "DTSTART", params: Params{"VALUE": {"DATE"}}, value: "19970714"}, false},
+		{`empty value`, args{"SUMMARY:"}, &contentLine{name: "SUMMARY", params: Params{}, value: ""}, false},
+		{`no colon`, args{"SUMMARY"}, nil, true},
+		{`no name`, args{":value"}, nil, true},
+		{`invalid name`, args{"SUM MARY:value"}, nil, true},
+		{`param without value`, args{"ATTENDEE;CN:mailto:a"}, nil, true},
+		{`unterminated quote`, args{`ATTENDEE;CN="John:mailto:a`}, nil, true},
+		{`no value after param`, args{"ATTENDEE;CN=John"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseContentLine(tt.args.line)
+			require.Truef(t, (err != nil) == tt.wantErr, `parseContentLine() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEventParameters(t *testing.T) {
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	require.NoError(t, err)
+
+	data := `BEGIN:VEVENT
+UID:19970901T130000Z-123401@example.com
+DTSTAMP:19970901T130000Z
+DTSTART;VALUE=DATE:19970903
+DTEND;TZID=Asia/Seoul:19970904T090000
+SUMMARY;LANGUAGE=ko:회의
+DESCRIPTION;ALTREP="http://example.com/agenda.html":Agenda
+ORGANIZER;CN="Doe, Jane";X-NUM=1:mailto:jane@example.com
+ATTENDEE;CN=John;PARTSTAT=ACCEPTED;ROLE=CHAIR;RSVP=TRUE:mailto:js@example.com
+END:VEVENT
+`
+	want := &VEvent{
+		UID:         "19970901T130000Z-123401@example.com",
+		DtStamp:     DateTime{Time: time.Date(1997, 9, 1, 13, 0, 0, 0, time.UTC)},
+		DtStart:     DateTime{Time: time.Date(1997, 9, 3, 0, 0, 0, 0, time.UTC), isDate: true},
+		DtEnd:       DateTime{Time: time.Date(1997, 9, 4, 9, 0, 0, 0, seoul)},
+		Summary:     Text{Value: "회의", Parameters: Parameters{Language: "ko"}},
+		Description: Text{Value: "Agenda", Parameters: Parameters{AltRep: "http://example.com/agenda.html"}},
+		Organizer:   CalAddress{Value: "mailto:jane@example.com", Parameters: Parameters{CN: "Doe, Jane", Others: Params{"X-NUM": {"1"}}}},
+		Attendees: []CalAddress{{Value: "mailto:js@example.com", Parameters: Parameters{
+			CN:       "John",
+			PartStat: PartStatAccepted,
+			Role:     RoleChair,
+			RSVP:     true,
+		}}},
+	}
+
+	got := new(VEvent)
+	require.NoError(t, NewEventDecoder(strings.NewReader(data)).Decode(got))
+	require.Equal(t, want, got)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, NewEventEncoder(buf).Encode(got))
+	require.Contains(t, buf.String(), "DTSTART;VALUE=DATE:19970903\r\n")
+	require.Contains(t, buf.String(), "DTEND;TZID=Asia/Seoul:19970904T090000\r\n")
+	require.Contains(t, buf.String(), `ORGANIZER;CN="Doe, Jane";X-NUM=1:mailto:jane@example.com`+"\r\n")
+	require.Contains(t, buf.String(), "ATTENDEE;CN=John;PARTSTAT=ACCEPTED;ROLE=CHAIR;RSVP=TRUE:mailto:js@example.c\r\n om\r\n")
+
+	reparsed := new(VEvent)
+	require.NoError(t, NewEventDecoder(buf).Decode(reparsed))
+	require.Equal(t, want, reparsed)
+}
+
+func TestEventRecurrenceIDAttachCategories(t *testing.T) {
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	require.NoError(t, err)
+
+	type args struct {
+		lines []string
+	}
+	tests := [...]struct {
+		name string
+		args args
+		want *VEvent
+	}{
+		{`recurrence id with tzid`, args{[]string{"RECURRENCE-ID;TZID=Asia/Seoul:19970105T083000"}}, &VEvent{
+			RecurrenceID: DateTime{Time: time.Date(1997, 1, 5, 8, 30, 0, 0, seoul)},
+		}},
+		{`recurrence id of date`, args{[]string{"RECURRENCE-ID;VALUE=DATE:19970105"}}, &VEvent{
+			RecurrenceID: NewDate(1997, 1, 5),
+		}},
+		{`attach with fmttype`, args{[]string{"ATTACH;FMTTYPE=application/pdf:https://example.com/agenda.pdf"}}, &VEvent{
+			Attatch: Attachment{Value: "https://example.com/agenda.pdf", Parameters: Parameters{Others: Params{"FMTTYPE": {"application/pdf"}}}},
+		}},
+		{`attach binary`, args{[]string{"ATTACH;VALUE=BINARY;ENCODING=BASE64;FMTTYPE=text/plain:aGVsbG8="}}, &VEvent{
+			Attatch: Attachment{Value: "aGVsbG8=", Parameters: Parameters{
+				Value:  "BINARY",
+				Others: Params{"ENCODING": {"BASE64"}, "FMTTYPE": {"text/plain"}},
+			}},
+		}},
+		{`categories with language`, args{[]string{`CATEGORIES;LANGUAGE=en:APPOINTMENT,R\,D`}}, &VEvent{
+			Categories: Categories{Values: []string{"APPOINTMENT", "R,D"}, Parameters: Parameters{Language: "en"}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := strings.Join(append(append([]string{
+				"BEGIN:VEVENT",
+				"UID:19970901T130000Z-123401@example.com",
+				"DTSTAMP:19970901T130000Z",
+			}, tt.args.lines...), "END:VEVENT", ""), "\r\n")
+			tt.want.UID = "19970901T130000Z-123401@example.com"
+			tt.want.DtStamp = DateTime{Time: time.Date(1997, 9, 1, 13, 0, 0, 0, time.UTC)}
+
+			got := new(VEvent)
+			require.NoError(t, NewEventDecoder(strings.NewReader(data)).Decode(got))
+			require.Equal(t, tt.want, got)
+
+			buf := new(bytes.Buffer)
+			require.NoError(t, NewEventEncoder(buf).Encode(got))
+			for _, line := range tt.args.lines {
+				require.Contains(t, buf.String(), line+"\r\n")
+			}
+
+			reparsed := new(VEvent)
+			require.NoError(t, NewEventDecoder(buf).Decode(reparsed))
+			require.Equal(t, tt.want, reparsed)
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package ical
+
+import (
+	"sort"
+	"time"
+)
+
+// Recurrence iterates occurrences of the event; DTSTART, RRULE and RDATE except EXDATE and EXRULE
+type Recurrence struct {
+	next     func() (time.Time, bool) // occurrences of DTSTART and RRULE
+	rrule    *RuleIterator
+	peek     *time.Time
+	exrule   *RuleIterator
+	exnext   *time.Time
+	rdates   []Period
+	exdates  []DateTime
+	isDate   bool
+	duration time.Duration
+	last     time.Time
+	err      error
+}
+
+// Occurrences returns iterator of occurrences as period of start and end
+func (evt *VEvent) Occurrences() *Recurrence {
+	dtstart := evt.DtStart.Time
+	it := &Recurrence{
+		rdates:  append([]Period{}, evt.RDates...),
+		exdates: evt.ExDates,
+		isDate:  evt.DtStart.isDate,
+	}
+	sort.SliceStable(it.rdates, func(i, j int) bool { return it.rdates[i].Start.Before(it.rdates[j].Start.Time) })
+
+	switch {
+	case !evt.DtEnd.IsZero():
+		it.duration = evt.DtEnd.Sub(dtstart)
+	case evt.Duration.Duration != 0:
+		it.duration = evt.Duration.Duration
+	case evt.DtStart.isDate:
+		it.duration = Day
+	}
+
+	if evt.RRule != nil {
+		it.rrule = evt.RRule.Iterator(dtstart)
+		it.next = it.rrule.Next
+	} else {
+		done := evt.DtStart.IsZero()
+		it.next = func() (time.Time, bool) {
+			if done {
+				return time.Time{}, false
+			}
+			done = true
+			return dtstart, true
+		}
+	}
+
+	if evt.ExRule != nil {
+		it.exrule = evt.ExRule.Iterator(dtstart)
+	}
+
+	return it
+}
+
+// Next returns next occurrence
+func (it *Recurrence) Next() (Period, bool) {
+	for it.err == nil {
+		if it.peek == nil {
+			if t, ok := it.next(); ok {
+				it.peek = &t
+			} else if it.rrule != nil && it.rrule.Err() != nil {
+				it.err = it.rrule.Err()
+				break
+			}
+		}
+
+		var occurrence Period
+		switch {
+		case it.peek != nil && (len(it.rdates) == 0 || !it.rdates[0].Start.Before(*it.peek)):
+			occurrence = Period{Start: DateTime{Time: *it.peek, isDate: it.isDate}}
+			if it.duration != 0 {
+				occurrence.End = DateTime{Time: it.peek.Add(it.duration), isDate: it.isDate}
+			}
+			it.peek = nil
+		case len(it.rdates) > 0:
+			occurrence = it.rdates[0]
+			it.rdates = it.rdates[1:]
+			if !occurrence.IsPeriod() && it.duration != 0 {
+				occurrence.End = DateTime{Time: occurrence.Start.Add(it.duration), isDate: occurrence.Start.isDate}
+			}
+			if occurrence.Duration.Duration != 0 {
+				occurrence.End = DateTime{Time: occurrence.Start.Add(occurrence.Duration.Duration)}
+				occurrence.Duration = Duration{}
+			}
+		default:
+			return Period{}, false
+		}
+
+		start := occurrence.Start.Time
+		if !it.last.IsZero() && start.Equal(it.last) {
+			continue
+		}
+		if it.excluded(start) {
+			continue
+		}
+		if it.err != nil {
+			break
+		}
+
+		it.last = start
+		return occurrence, true
+	}
+
+	return Period{}, false
+}
+
+// Err returns the error of RRULE or EXRULE stopped the iteration
+func (it *Recurrence) Err() error {
+	return it.err
+}
+
+// excluded returns true if t is in EXDATE or EXRULE
+func (it *Recurrence) excluded(t time.Time) bool {
+	for _, exdate := range it.exdates {
+		if exdate.isDate {
+			if y, m, d := t.Date(); exdate.Year() == y && exdate.Month() == m && exdate.Day() == d {
+				return true
+			}
+			continue
+		}
+
+		if exdate.Equal(t) {
+			return true
+		}
+	}
+
+	if it.exrule == nil {
+		return false
+	}
+
+	for it.exnext == nil || it.exnext.Before(t) {
+		next, ok := it.exrule.Next()
+		if !ok {
+			// occurrences excluded by EXRULE are unknown after the error
+			it.err = it.exrule.Err()
+			it.exrule = nil
+			return false
+		}
+		it.exnext = &next
+	}
+
+	return it.exnext.Equal(t)
+}
+
+// Take returns first n occurrences
+func (it *Recurrence) Take(n int) []Period {
+	occurrences := []Period{}
+	for len(occurrences) < n {
+		occurrence, ok := it.Next()
+		if !ok {
+			break
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+	return occurrences
+}
@@ -0,0 +1,726 @@
+package ical
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/whitekid/goxp/fx"
+)
+
+// frequencies of recurrence rule
+const (
+	FreqSecondly = "SECONDLY"
+	FreqMinutely = "MINUTELY"
+	FreqHourly   = "HOURLY"
+	FreqDaily    = "DAILY"
+	FreqWeekly   = "WEEKLY"
+	FreqMonthly  = "MONTHLY"
+	FreqYearly   = "YEARLY"
+)
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+func weekdayName(wd time.Weekday) string { return strings.ToUpper(wd.String()[:2]) }
+
+// WeekdayNum BYDAY value; N is n-th weekday of the month or year, 0 for every weekday
+//
+//	MO, 2SU, -1FR
+type WeekdayNum struct {
+	N       int
+	Weekday time.Weekday
+}
+
+func (w WeekdayNum) String() string {
+	if w.N == 0 {
+		return weekdayName(w.Weekday)
+	}
+	return strconv.Itoa(w.N) + weekdayName(w.Weekday)
+}
+
+func parseWeekdayNum(s string) (w WeekdayNum, err error) {
+	if len(s) < 2 {
+		return w, fmt.Errorf("invalid weekday: %s", s)
+	}
+
+	wd, ok := weekdays[strings.ToUpper(s[len(s)-2:])]
+	if !ok {
+		return w, fmt.Errorf("invalid weekday: %s", s)
+	}
+	w.Weekday = wd
+
+	if n := s[:len(s)-2]; n != "" {
+		if w.N, err = strconv.Atoi(n); err != nil || w.N == 0 {
+			return w, fmt.Errorf("invalid weekday: %s", s)
+		}
+	}
+
+	return w, nil
+}
+
+// RecurrenceRule 3.3.10 Recurrence Rule
+//
+//	FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1
+type RecurrenceRule struct {
+	Freq       string // SECONDLY, MINUTELY, HOURLY, DAILY, WEEKLY, MONTHLY, YEARLY; required
+	Interval   int    // 1(*)
+	Count      int    // exclusive with Until
+	Until      DateTime
+	BySecond   []int
+	ByMinute   []int
+	ByHour     []int
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByYearDay  []int
+	ByWeekNo   []int
+	ByMonth    []int
+	BySetPos   []int
+	WKST       string // week start, MO(*), TU, ...
+}
+
+// ParseRecurrenceRule parse and validate RRULE value
+func ParseRecurrenceRule(s string) (*RecurrenceRule, error) {
+	r := &RecurrenceRule{}
+	seen := map[string]bool{}
+
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.ToUpper(key)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid rule part: %s", part)
+		}
+
+		if seen[key] {
+			return nil, fmt.Errorf("duplicated rule part: %s", key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			r.Freq = strings.ToUpper(value)
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+		case "UNTIL":
+			r.Until, err = parseDateTime(value, nil, nil)
+		case "BYSECOND":
+			r.BySecond, err = parseIntList(value)
+		case "BYMINUTE":
+			r.ByMinute, err = parseIntList(value)
+		case "BYHOUR":
+			r.ByHour, err = parseIntList(value)
+		case "BYDAY":
+			for _, v := range strings.Split(value, ",") {
+				var w WeekdayNum
+				if w, err = parseWeekdayNum(v); err != nil {
+					break
+				}
+				r.ByDay = append(r.ByDay, w)
+			}
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseIntList(value)
+		case "BYYEARDAY":
+			r.ByYearDay, err = parseIntList(value)
+		case "BYWEEKNO":
+			r.ByWeekNo, err = parseIntList(value)
+		case "BYMONTH":
+			r.ByMonth, err = parseIntList(value)
+		case "BYSETPOS":
+			r.BySetPos, err = parseIntList(value)
+		case "WKST":
+			r.WKST = strings.ToUpper(value)
+		default:
+			return nil, fmt.Errorf("unsupported rule part: %s", key)
+		}
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid format: %s", key)
+		}
+	}
+
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func parseIntList(s string) ([]int, error) {
+	values := []int{}
+	for _, v := range strings.Split(s, ",") {
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, i)
+	}
+	return values, nil
+}
+
+// Validate validate rule parts and combination of them
+func (r *RecurrenceRule) Validate() error {
+	switch r.Freq {
+	case FreqSecondly, FreqMinutely, FreqHourly, FreqDaily, FreqWeekly, FreqMonthly, FreqYearly:
+	case "":
+		return errors.New("RRULE: FREQ required")
+	default:
+		return fmt.Errorf("RRULE: unsupported FREQ %s", r.Freq)
+	}
+
+	if r.Interval < 0 {
+		return errors.New("RRULE: INTERVAL must be positive")
+	}
+	if r.Count < 0 {
+		return errors.New("RRULE: COUNT must be positive")
+	}
+	if r.Count != 0 && !r.Until.IsZero() {
+		return errors.New("RRULE: COUNT and UNTIL are exclusive")
+	}
+
+	for _, check := range []struct {
+		name     string
+		values   []int
+		min, max int
+		signed   bool
+	}{
+		{"BYSECOND", r.BySecond, 0, 60, false},
+		{"BYMINUTE", r.ByMinute, 0, 59, false},
+		{"BYHOUR", r.ByHour, 0, 23, false},
+		{"BYMONTHDAY", r.ByMonthDay, 1, 31, true},
+		{"BYYEARDAY", r.ByYearDay, 1, 366, true},
+		{"BYWEEKNO", r.ByWeekNo, 1, 53, true},
+		{"BYMONTH", r.ByMonth, 1, 12, false},
+		{"BYSETPOS", r.BySetPos, 1, 366, true},
+	} {
+		for _, v := range check.values {
+			if check.signed && v < 0 {
+				v = -v
+			}
+			if v < check.min || v > check.max {
+				return fmt.Errorf("RRULE: %s out of range: %d", check.name, v)
+			}
+		}
+	}
+
+	for _, w := range r.ByDay {
+		if w.N == 0 {
+			continue
+		}
+		if r.Freq != FreqMonthly && r.Freq != FreqYearly {
+			return errors.New("RRULE: numeric BYDAY allowed with MONTHLY or YEARLY only")
+		}
+		if r.Freq == FreqYearly && len(r.ByWeekNo) > 0 {
+			return errors.New("RRULE: numeric BYDAY not allowed with BYWEEKNO")
+		}
+		if w.N < -53 || w.N > 53 {
+			return fmt.Errorf("RRULE: BYDAY out of range: %s", w)
+		}
+	}
+
+	if len(r.ByMonthDay) > 0 && r.Freq == FreqWeekly {
+		return errors.New("RRULE: BYMONTHDAY not allowed with WEEKLY")
+	}
+	if len(r.ByYearDay) > 0 && (r.Freq == FreqDaily || r.Freq == FreqWeekly || r.Freq == FreqMonthly) {
+		return fmt.Errorf("RRULE: BYYEARDAY not allowed with %s", r.Freq)
+	}
+	if len(r.ByWeekNo) > 0 && r.Freq != FreqYearly {
+		return errors.New("RRULE: BYWEEKNO allowed with YEARLY only")
+	}
+	if len(r.BySetPos) > 0 && len(r.BySecond)+len(r.ByMinute)+len(r.ByHour)+len(r.ByDay)+
+		len(r.ByMonthDay)+len(r.ByYearDay)+len(r.ByWeekNo)+len(r.ByMonth) == 0 {
+		return errors.New("RRULE: BYSETPOS requires other BYxxx rule part")
+	}
+	if len(r.BySetPos) > 0 && r.maxPeriodSize() > maxSetPosPeriodSize {
+		return fmt.Errorf("RRULE: BYSETPOS with more than %d occurrences in a period", maxSetPosPeriodSize)
+	}
+
+	if _, ok := weekdays[r.WKST]; r.WKST != "" && !ok {
+		return fmt.Errorf("RRULE: invalid WKST %s", r.WKST)
+	}
+
+	return nil
+}
+
+// maxSetPosPeriodSize limits occurrences of a period for BYSETPOS, which are expanded at once to select by position
+const maxSetPosPeriodSize = 100000
+
+// maxPeriodSize returns upper bound of occurrences in a period
+func (r *RecurrenceRule) maxPeriodSize() int {
+	days := map[string]int{FreqYearly: 366, FreqMonthly: 31, FreqWeekly: 7}[r.Freq]
+	size := fx.Max(days, 1)
+	if r.Freq == FreqYearly || r.Freq == FreqMonthly || r.Freq == FreqWeekly || r.Freq == FreqDaily {
+		size *= fx.Max(len(r.ByHour), 1)
+	}
+	if r.Freq != FreqMinutely && r.Freq != FreqSecondly {
+		size *= fx.Max(len(r.ByMinute), 1)
+	}
+	if r.Freq != FreqSecondly {
+		size *= fx.Max(len(r.BySecond), 1)
+	}
+	return size
+}
+
+func (r *RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if !r.Until.IsZero() {
+		until := r.Until
+		if until.TZID() != "" {
+			until.Time = until.UTC()
+		}
+		parts = append(parts, "UNTIL="+until.String())
+	}
+	if r.Count != 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Interval != 0 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	intList := func(name string, values []int) {
+		if len(values) == 0 {
+			return
+		}
+
+		s := make([]string, len(values))
+		for i, v := range values {
+			s[i] = strconv.Itoa(v)
+		}
+		parts = append(parts, name+"="+strings.Join(s, ","))
+	}
+
+	intList("BYSECOND", r.BySecond)
+	intList("BYMINUTE", r.ByMinute)
+	intList("BYHOUR", r.ByHour)
+	if len(r.ByDay) > 0 {
+		s := make([]string, len(r.ByDay))
+		for i, w := range r.ByDay {
+			s[i] = w.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(s, ","))
+	}
+	intList("BYMONTHDAY", r.ByMonthDay)
+	intList("BYYEARDAY", r.ByYearDay)
+	intList("BYWEEKNO", r.ByWeekNo)
+	intList("BYMONTH", r.ByMonth)
+	intList("BYSETPOS", r.BySetPos)
+	if r.WKST != "" {
+		parts = append(parts, "WKST="+r.WKST)
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// maxEmptyYears stop expanding if no occurrence is found in the years since the last occurrence
+// gregorian calendar repeats every 400 years, so the rule is never matched after that
+const maxEmptyYears = 400
+
+// maxEmptyPeriods stop expanding if no occurrence is found in consecutive periods of a call of Next;
+// daily periods of maxEmptyYears fit in it, and finer periods skip whole days, hours and minutes never matched
+const maxEmptyPeriods = 1 << 18
+
+// RuleIterator expands occurrences of recurrence rule; dtstart is always the first occurrence
+// occurrences are computed in wall clock of the location of dtstart
+type RuleIterator struct {
+	rule    *RecurrenceRule
+	dtstart time.Time
+	until   time.Time
+	wkst    time.Weekday
+
+	period                  int         // index of the period from dtstart; -1 before expanding the period of dtstart
+	days                    []time.Time // days of the period not expanded yet
+	hours, minutes, seconds []int       // time values of the period
+	pending                 []time.Time
+	last                    time.Time // the last occurrence expanded
+	count                   int
+	done                    bool
+	err                     error
+}
+
+// Iterator returns iterator of occurrences starts at dtstart
+func (r *RecurrenceRule) Iterator(dtstart time.Time) *RuleIterator {
+	it := &RuleIterator{
+		rule:    r,
+		dtstart: dtstart,
+		period:  -1,
+		wkst:    time.Monday,
+		pending: []time.Time{dtstart},
+		last:    dtstart,
+		count:   1,
+	}
+
+	if r.WKST != "" {
+		it.wkst = weekdays[r.WKST]
+	}
+
+	if !r.Until.IsZero() {
+		u := r.Until
+		switch {
+		case u.isDate:
+			it.until = time.Date(u.Year(), u.Month(), u.Day(), 23, 59, 59, 0, dtstart.Location())
+		case u.Location() == time.Local:
+			// floating time, as wall clock of dtstart
+			it.until = time.Date(u.Year(), u.Month(), u.Day(), u.Hour(), u.Minute(), u.Second(), 0, dtstart.Location())
+		default:
+			it.until = u.Time
+		}
+
+		if dtstart.After(it.until) {
+			it.pending, it.done = nil, true
+		}
+	}
+
+	if r.Count == 1 {
+		it.done = true
+	}
+
+	return it
+}
+
+// Next returns next occurrence
+func (it *RuleIterator) Next() (time.Time, bool) {
+	for periods := 0; len(it.pending) == 0 && !it.done; {
+		if len(it.days) == 0 {
+			if periods >= maxEmptyPeriods {
+				it.err = fmt.Errorf("RRULE: no occurrence in %d periods", maxEmptyPeriods)
+				it.done = true
+				break
+			}
+			periods++
+			it.nextPeriod()
+			continue
+		}
+		it.expand()
+	}
+
+	if len(it.pending) == 0 {
+		return time.Time{}, false
+	}
+
+	t := it.pending[0]
+	it.pending = it.pending[1:]
+	return t, true
+}
+
+// Err returns the error stopped the iteration; nil if all occurrences are expanded
+func (it *RuleIterator) Err() error {
+	return it.err
+}
+
+// expand add occurrences of the next day of the period to pending, or of the whole period for BYSETPOS
+// occurrences are generated a day at a time, so rules of many BYHOUR, BYMINUTE and BYSECOND are expanded as needed
+func (it *RuleIterator) expand() {
+	var candidates []time.Time
+	if len(it.rule.BySetPos) > 0 {
+		for _, d := range it.days {
+			candidates = append(candidates, it.dayCandidates(d)...)
+		}
+		it.days = nil
+		candidates = selectSetPos(candidates, it.rule.BySetPos)
+	} else {
+		candidates = it.dayCandidates(it.days[0])
+		it.days = it.days[1:]
+	}
+
+	for _, t := range candidates {
+		if !t.After(it.dtstart) {
+			continue
+		}
+
+		if !it.until.IsZero() && t.After(it.until) {
+			it.done = true
+			return
+		}
+
+		it.pending = append(it.pending, t)
+		it.last = t
+		it.count++
+		if it.rule.Count != 0 && it.count >= it.rule.Count {
+			it.done = true
+			return
+		}
+	}
+}
+
+// nextPeriod set matched days and time values of the next period
+func (it *RuleIterator) nextPeriod() {
+	it.period++
+
+	r := it.rule
+	dt := it.dtstart
+	loc := dt.Location()
+	step := it.period * fx.Max(r.Interval, 1)
+
+	var days []time.Time
+	var t time.Time // start of the period finer than daily
+	var hour, minute, second = -1, -1, -1
+
+	switch r.Freq {
+	case FreqYearly:
+		start := time.Date(dt.Year()+step, 1, 1, 0, 0, 0, 0, loc)
+		for d := start; d.Year() == start.Year(); d = d.AddDate(0, 0, 1) {
+			days = append(days, d)
+		}
+	case FreqMonthly:
+		start := time.Date(dt.Year(), dt.Month()+time.Month(step), 1, 0, 0, 0, 0, loc)
+		for d := start; d.Month() == start.Month(); d = d.AddDate(0, 0, 1) {
+			days = append(days, d)
+		}
+	case FreqWeekly:
+		start := time.Date(dt.Year(), dt.Month(), dt.Day()-int(dt.Weekday()-it.wkst+7)%7+step*7, 0, 0, 0, 0, loc)
+		for i := 0; i < 7; i++ {
+			days = append(days, start.AddDate(0, 0, i))
+		}
+	case FreqDaily:
+		days = append(days, time.Date(dt.Year(), dt.Month(), dt.Day()+step, 0, 0, 0, 0, loc))
+	default:
+		// periods start at the wall clock of dtstart; Truncate rounds the absolute time, not the wall clock of the zone
+		t = time.Unix(it.periodStart().Unix()+int64(step)*periodSeconds[r.Freq], 0).In(loc)
+		days = append(days, time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc))
+		hour = t.Hour()
+		if r.Freq != FreqHourly {
+			minute = t.Minute()
+		}
+		if r.Freq == FreqSecondly {
+			second = t.Second()
+		}
+	}
+
+	if days[0].Year() > 9999 {
+		it.done = true
+		return
+	}
+	if days[0].After(it.last.AddDate(maxEmptyYears, 0, 0)) {
+		it.err = fmt.Errorf("RRULE: no occurrence in %d years", maxEmptyYears)
+		it.done = true
+		return
+	}
+
+	// days before dtstart never occur, but are counted for BYSETPOS
+	first := time.Date(dt.Year(), dt.Month(), dt.Day(), 0, 0, 0, 0, loc)
+	it.days = fx.Filter(days, func(d time.Time) bool {
+		return (len(r.BySetPos) > 0 || !d.Before(first)) && it.matchDay(d)
+	})
+	it.hours = timeValues(hour, r.ByHour, dt.Hour())
+	it.minutes = timeValues(minute, r.ByMinute, dt.Minute())
+	it.seconds = timeValues(second, r.BySecond, dt.Second())
+
+	// skip the periods of the day, hour or minute never matched at once; ex. MINUTELY with BYMONTHDAY
+	var skip time.Time
+	switch {
+	case t.IsZero():
+	case len(it.days) == 0:
+		skip = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+	case it.hours == nil:
+		skip = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+	case it.minutes == nil:
+		skip = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+	}
+	if !skip.IsZero() {
+		it.days = nil
+		length := periodSeconds[r.Freq] * int64(fx.Max(r.Interval, 1))
+		it.period = int((skip.Unix()-it.periodStart().Unix()+length-1)/length) - 1
+	}
+}
+
+// periodSeconds is the length of the periods of the frequencies finer than daily
+var periodSeconds = map[string]int64{
+	FreqHourly:   60 * 60,
+	FreqMinutely: 60,
+	FreqSecondly: 1,
+}
+
+// periodStart returns the start of the period of dtstart for the frequencies finer than daily
+func (it *RuleIterator) periodStart() time.Time {
+	dt := it.dtstart
+	minute, second := 0, 0
+	if it.rule.Freq != FreqHourly {
+		minute = dt.Minute()
+	}
+	if it.rule.Freq == FreqSecondly {
+		second = dt.Second()
+	}
+	return time.Date(dt.Year(), dt.Month(), dt.Day(), dt.Hour(), minute, second, 0, dt.Location())
+}
+
+// dayCandidates returns sorted occurrences of the day
+func (it *RuleIterator) dayCandidates(d time.Time) []time.Time {
+	candidates := make([]time.Time, 0, len(it.hours)*len(it.minutes)*len(it.seconds))
+	for _, h := range it.hours {
+		for _, m := range it.minutes {
+			for _, s := range it.seconds {
+				candidates = append(candidates, time.Date(d.Year(), d.Month(), d.Day(), h, m, s, 0, d.Location()))
+			}
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+
+	// remove duplicates; ex. normalized time of daylight saving gap
+	return uniqueTimes(candidates)
+}
+
+// selectSetPos returns sorted occurrences at the positions of BYSETPOS in the sorted occurrences of the period
+func selectSetPos(candidates []time.Time, positions []int) []time.Time {
+	selected := []time.Time{}
+	for _, pos := range positions {
+		i := pos - 1
+		if pos < 0 {
+			i = len(candidates) + pos
+		}
+		if 0 <= i && i < len(candidates) {
+			selected = append(selected, candidates[i])
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].Before(selected[j]) })
+
+	return uniqueTimes(selected)
+}
+
+// uniqueTimes remove duplicates of sorted times
+func uniqueTimes(times []time.Time) []time.Time {
+	unique := times[:0]
+	for i, t := range times {
+		if i == 0 || !t.Equal(times[i-1]) {
+			unique = append(unique, t)
+		}
+	}
+	return unique
+}
+
+// timeValues returns values of hour, minute or second; fixed is the value of the period for finer frequency
+func timeValues(fixed int, by []int, def int) []int {
+	if fixed >= 0 {
+		if len(by) > 0 && !containsInt(by, fixed) {
+			return nil
+		}
+		return []int{fixed}
+	}
+
+	if len(by) > 0 {
+		values := append([]int{}, by...)
+		sort.Ints(values)
+		return values
+	}
+
+	return []int{def}
+}
+
+func (it *RuleIterator) matchDay(d time.Time) bool {
+	r := it.rule
+	dt := it.dtstart
+
+	daysInYear := time.Date(d.Year(), 12, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	daysInMonth := time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	if len(r.ByMonth) > 0 && !containsInt(r.ByMonth, int(d.Month())) {
+		return false
+	}
+
+	if len(r.ByWeekNo) > 0 {
+		weekNo, weeks := weekNumber(d, it.wkst)
+		if !containsInt(r.ByWeekNo, weekNo) && !containsInt(r.ByWeekNo, weekNo-weeks-1) {
+			return false
+		}
+	}
+
+	if len(r.ByYearDay) > 0 && !containsInt(r.ByYearDay, d.YearDay()) && !containsInt(r.ByYearDay, d.YearDay()-daysInYear-1) {
+		return false
+	}
+
+	if len(r.ByMonthDay) > 0 && !containsInt(r.ByMonthDay, d.Day()) && !containsInt(r.ByMonthDay, d.Day()-daysInMonth-1) {
+		return false
+	}
+
+	if len(r.ByDay) > 0 {
+		matched := false
+		for _, w := range r.ByDay {
+			if w.Weekday != d.Weekday() {
+				continue
+			}
+
+			if w.N == 0 {
+				matched = true
+				break
+			}
+
+			// n-th weekday of the month, or of the year if YEARLY without BYMONTH
+			day, days := d.Day(), daysInMonth
+			if r.Freq == FreqYearly && len(r.ByMonth) == 0 {
+				day, days = d.YearDay(), daysInYear
+			}
+			if w.N == (day-1)/7+1 || w.N == -((days-day)/7+1) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	// without BYxxx of day, it occurs on the day of dtstart
+	if len(r.ByWeekNo)+len(r.ByYearDay)+len(r.ByMonthDay)+len(r.ByDay) == 0 {
+		switch r.Freq {
+		case FreqYearly:
+			if len(r.ByMonth) == 0 && d.Month() != dt.Month() {
+				return false
+			}
+			return d.Day() == dt.Day()
+		case FreqMonthly:
+			return d.Day() == dt.Day()
+		case FreqWeekly:
+			return d.Weekday() == dt.Weekday()
+		}
+	}
+
+	if r.Freq == FreqYearly && len(r.ByWeekNo) > 0 && len(r.ByYearDay)+len(r.ByMonthDay)+len(r.ByDay) == 0 {
+		return d.Weekday() == dt.Weekday()
+	}
+
+	return true
+}
+
+// weekNumber returns week number of the year and number of weeks in the year
+// week 1 is the first week that contains at least four days of the year; 0 if the day belongs to week of other year
+func weekNumber(d time.Time, wkst time.Weekday) (int, int) {
+	firstWeek := func(year int) time.Time {
+		jan1 := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+		offset := int(jan1.Weekday()-wkst+7) % 7
+		if offset <= 3 {
+			return jan1.AddDate(0, 0, -offset)
+		}
+		return jan1.AddDate(0, 0, 7-offset)
+	}
+
+	day := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+	start, next := firstWeek(d.Year()), firstWeek(d.Year()+1)
+	weeks := int(next.Sub(start) / Week)
+
+	if day.Before(start) || !day.Before(next) {
+		return 0, weeks
+	}
+
+	return int(day.Sub(start)/Week) + 1, weeks
+}
+
+func containsInt(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,378 @@
+package ical
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecurrenceRule(t *testing.T) {
+	type args struct {
+		s string
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		want    *RecurrenceRule
+		wantStr string
+		wantErr bool
+	}{
+		{`valid`, args{"FREQ=YEARLY"}, &RecurrenceRule{Freq: FreqYearly}, "FREQ=YEARLY", false},
+		{`valid full`, args{"FREQ=MONTHLY;INTERVAL=2;COUNT=10;BYDAY=1SU,-1SU;BYHOUR=8,9;BYMINUTE=30"}, &RecurrenceRule{
+			Freq:     FreqMonthly,
+			Interval: 2,
+			Count:    10,
+			ByDay:    []WeekdayNum{{1, time.Sunday}, {-1, time.Sunday}},
+			ByHour:   []int{8, 9},
+			ByMinute: []int{30},
+		}, "FREQ=MONTHLY;COUNT=10;INTERVAL=2;BYMINUTE=30;BYHOUR=8,9;BYDAY=1SU,-1SU", false},
+		{`valid until`, args{"FREQ=WEEKLY;UNTIL=19971007T000000Z;WKST=SU;BYDAY=TU,TH"}, &RecurrenceRule{
+			Freq:  FreqWeekly,
+			Until: DateTime{Time: time.Date(1997, 10, 7, 0, 0, 0, 0, time.UTC)},
+			WKST:  "SU",
+			ByDay: []WeekdayNum{{0, time.Tuesday}, {0, time.Thursday}},
+		}, "FREQ=WEEKLY;UNTIL=19971007T000000Z;BYDAY=TU,TH;WKST=SU", false},
+		{`valid setpos`, args{"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1"}, &RecurrenceRule{
+			Freq:     FreqMonthly,
+			ByDay:    []WeekdayNum{{0, time.Monday}, {0, time.Tuesday}, {0, time.Wednesday}, {0, time.Thursday}, {0, time.Friday}},
+			BySetPos: []int{-1},
+		}, "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", false},
+		{`valid lower case`, args{"freq=yearly;bymonth=1;bymonthday=-1,1;byyearday=100;byweekno=-1"}, &RecurrenceRule{
+			Freq:       FreqYearly,
+			ByMonth:    []int{1},
+			ByMonthDay: []int{-1, 1},
+			ByYearDay:  []int{100},
+			ByWeekNo:   []int{-1},
+		}, "FREQ=YEARLY;BYMONTHDAY=-1,1;BYYEARDAY=100;BYWEEKNO=-1;BYMONTH=1", false},
+		{`freq required`, args{"COUNT=10"}, nil, "", true},
+		{`unsupported freq`, args{"FREQ=HOURS"}, nil, "", true},
+		{`unsupported part`, args{"FREQ=DAILY;FOO=1"}, nil, "", true},
+		{`duplicated part`, args{"FREQ=DAILY;COUNT=1;COUNT=2"}, nil, "", true},
+		{`count and until`, args{"FREQ=DAILY;COUNT=1;UNTIL=19971007T000000Z"}, nil, "", true},
+		{`invalid count`, args{"FREQ=DAILY;COUNT=x"}, nil, "", true},
+		{`negative interval`, args{"FREQ=DAILY;INTERVAL=-1"}, nil, "", true},
+		{`invalid weekday`, args{"FREQ=WEEKLY;BYDAY=XX"}, nil, "", true},
+		{`zero ordinal weekday`, args{"FREQ=MONTHLY;BYDAY=0MO"}, nil, "", true},
+		{`numeric weekday with weekly`, args{"FREQ=WEEKLY;BYDAY=1MO"}, nil, "", true},
+		{`numeric weekday with weekno`, args{"FREQ=YEARLY;BYWEEKNO=1;BYDAY=1MO"}, nil, "", true},
+		{`monthday out of range`, args{"FREQ=MONTHLY;BYMONTHDAY=32"}, nil, "", true},
+		{`zero monthday`, args{"FREQ=MONTHLY;BYMONTHDAY=0"}, nil, "", true},
+		{`month out of range`, args{"FREQ=YEARLY;BYMONTH=13"}, nil, "", true},
+		{`hour out of range`, args{"FREQ=DAILY;BYHOUR=24"}, nil, "", true},
+		{`monthday with weekly`, args{"FREQ=WEEKLY;BYMONTHDAY=1"}, nil, "", true},
+		{`yearday with monthly`, args{"FREQ=MONTHLY;BYYEARDAY=1"}, nil, "", true},
+		{`weekno with monthly`, args{"FREQ=MONTHLY;BYWEEKNO=1"}, nil, "", true},
+		{`setpos only`, args{"FREQ=MONTHLY;BYSETPOS=1"}, nil, "", true},
+		{`setpos of too many occurrences`, args{"FREQ=YEARLY;BYHOUR=" + intRange(0, 23) + ";BYMINUTE=" + intRange(0, 59) + ";BYSETPOS=1"}, nil, "", true},
+		{`invalid wkst`, args{"FREQ=WEEKLY;WKST=XX"}, nil, "", true},
+		{`empty value`, args{"FREQ="}, nil, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRecurrenceRule(tt.args.s)
+			require.Truef(t, (err != nil) == tt.wantErr, `ParseRecurrenceRule() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantStr, got.String())
+
+			reparsed, err := ParseRecurrenceRule(got.String())
+			require.NoError(t, err)
+			require.Equal(t, got, reparsed)
+		})
+	}
+}
+
+// intRange returns comma separated integers from min to max
+func intRange(min, max int) string {
+	values := []string{}
+	for i := min; i <= max; i++ {
+		values = append(values, strconv.Itoa(i))
+	}
+	return strings.Join(values, ",")
+}
+
+var everySecond = "FREQ=YEARLY;COUNT=2;BYDAY=MO,TU,WE,TH,FR,SA,SU;BYHOUR=" + intRange(0, 23) +
+	";BYMINUTE=" + intRange(0, 59) + ";BYSECOND=" + intRange(0, 59)
+
+// examples from rfc5545 3.8.5.3
+func TestRuleIterator(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	type args struct {
+		dtstart string
+		rule    string
+		n       int
+	}
+	tests := [...]struct {
+		name string
+		args args
+		want []string
+	}{
+		{`daily for 10 occurrences`, args{"19970902T090000", "FREQ=DAILY;COUNT=10", 20}, []string{
+			"19970902T090000", "19970903T090000", "19970904T090000", "19970905T090000", "19970906T090000",
+			"19970907T090000", "19970908T090000", "19970909T090000", "19970910T090000", "19970911T090000",
+		}},
+		{`every other day`, args{"19970902T090000", "FREQ=DAILY;INTERVAL=2", 5}, []string{
+			"19970902T090000", "19970904T090000", "19970906T090000", "19970908T090000", "19970910T090000",
+		}},
+		{`every 10 days, 5 occurrences`, args{"19970902T090000", "FREQ=DAILY;INTERVAL=10;COUNT=5", 10}, []string{
+			"19970902T090000", "19970912T090000", "19970922T090000", "19971002T090000", "19971012T090000",
+		}},
+		{`every day in january`, args{"19980101T090000", "FREQ=DAILY;UNTIL=20000131T140000Z;BYMONTH=1", 3}, []string{
+			"19980101T090000", "19980102T090000", "19980103T090000",
+		}},
+		{`daily across daylight saving`, args{"19971025T090000", "FREQ=DAILY;COUNT=3", 5}, []string{
+			"19971025T090000", "19971026T090000", "19971027T090000",
+		}},
+		{`weekly on tuesday and thursday for five weeks`, args{"19970902T090000", "FREQ=WEEKLY;UNTIL=19971007T000000Z;WKST=SU;BYDAY=TU,TH", 20}, []string{
+			"19970902T090000", "19970904T090000", "19970909T090000", "19970911T090000", "19970916T090000",
+			"19970918T090000", "19970923T090000", "19970925T090000", "19970930T090000", "19971002T090000",
+		}},
+		{`week start monday`, args{"19970805T090000", "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO", 10}, []string{
+			"19970805T090000", "19970810T090000", "19970819T090000", "19970824T090000",
+		}},
+		{`week start sunday`, args{"19970805T090000", "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU", 10}, []string{
+			"19970805T090000", "19970817T090000", "19970819T090000", "19970831T090000",
+		}},
+		{`monthly on the first friday`, args{"19970905T090000", "FREQ=MONTHLY;COUNT=10;BYDAY=1FR", 20}, []string{
+			"19970905T090000", "19971003T090000", "19971107T090000", "19971205T090000", "19980102T090000",
+			"19980206T090000", "19980306T090000", "19980403T090000", "19980501T090000", "19980605T090000",
+		}},
+		{`monthly on the second-to-last monday`, args{"19970922T090000", "FREQ=MONTHLY;COUNT=6;BYDAY=-2MO", 10}, []string{
+			"19970922T090000", "19971020T090000", "19971117T090000", "19971222T090000", "19980119T090000", "19980216T090000",
+		}},
+		{`monthly on the 31th`, args{"19970131T090000", "FREQ=MONTHLY;COUNT=4", 10}, []string{
+			"19970131T090000", "19970331T090000", "19970531T090000", "19970731T090000",
+		}},
+		{`last work day of the month`, args{"19970930T090000", "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", 7}, []string{
+			"19970930T090000", "19971031T090000", "19971128T090000", "19971231T090000", "19980130T090000",
+			"19980227T090000", "19980331T090000",
+		}},
+		{`first work day of the month from the middle`, args{"19970915T090000", "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=1", 3}, []string{
+			"19970915T090000", "19971001T090000", "19971103T090000",
+		}},
+		{`friday the 13th`, args{"19970902T090000", "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13", 4}, []string{
+			"19970902T090000", "19980213T090000", "19980313T090000", "19981113T090000",
+		}},
+		{`yearly in june and july`, args{"19970610T090000", "FREQ=YEARLY;COUNT=4;BYMONTH=6,7", 10}, []string{
+			"19970610T090000", "19970710T090000", "19980610T090000", "19980710T090000",
+		}},
+		{`monday of week number 20`, args{"19970512T090000", "FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO", 3}, []string{
+			"19970512T090000", "19980511T090000", "19990517T090000",
+		}},
+		{`every 20th monday of the year`, args{"19970519T090000", "FREQ=YEARLY;BYDAY=20MO", 3}, []string{
+			"19970519T090000", "19980518T090000", "19990517T090000",
+		}},
+		{`us presidential election day`, args{"19961105T090000", "FREQ=YEARLY;INTERVAL=4;BYMONTH=11;BYDAY=TU;BYMONTHDAY=2,3,4,5,6,7,8", 3}, []string{
+			"19961105T090000", "20001107T090000", "20041102T090000",
+		}},
+		{`leap day`, args{"20000229T090000", "FREQ=YEARLY;COUNT=3", 10}, []string{
+			"20000229T090000", "20040229T090000", "20080229T090000",
+		}},
+		{`every 3 hours`, args{"19970902T090000", "FREQ=HOURLY;INTERVAL=3;UNTIL=19970902T210000Z", 10}, []string{
+			"19970902T090000", "19970902T120000", "19970902T150000",
+		}},
+		{`every 15 minutes for 4 occurrences`, args{"19970902T090000", "FREQ=MINUTELY;INTERVAL=15;COUNT=4", 10}, []string{
+			"19970902T090000", "19970902T091500", "19970902T093000", "19970902T094500",
+		}},
+		{`every 20 minutes in 9 to 10`, args{"19970902T090000", "FREQ=DAILY;BYHOUR=9,10;BYMINUTE=0,20,40", 8}, []string{
+			"19970902T090000", "19970902T092000", "19970902T094000", "19970902T100000", "19970902T102000",
+			"19970902T104000", "19970903T090000", "19970903T092000",
+		}},
+		{`until as date`, args{"19970902T090000", "FREQ=DAILY;UNTIL=19970904", 10}, []string{
+			"19970902T090000", "19970903T090000", "19970904T090000",
+		}},
+		{`until before dtstart`, args{"19970902T090000", "FREQ=DAILY;UNTIL=19970801", 10}, []string{}},
+		{`every second of the year`, args{"19970902T090000", everySecond, 10}, []string{"19970902T090000", "19970902T090001"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dtstart, err := time.ParseInLocation("20060102T150405", tt.args.dtstart, newYork)
+			require.NoError(t, err)
+
+			rule, err := ParseRecurrenceRule(tt.args.rule)
+			require.NoError(t, err)
+
+			got := []string{}
+			it := rule.Iterator(dtstart)
+			for len(got) < tt.args.n {
+				next, ok := it.Next()
+				if !ok {
+					break
+				}
+				require.Equal(t, newYork, next.Location())
+				got = append(got, next.Format("20060102T150405"))
+			}
+
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// periods of finer frequencies are counted in wall clock of the zone, not in UTC
+func TestRuleIteratorWallClock(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	require.NoError(t, err)
+
+	tests := [...]struct {
+		name string
+		rule string
+		want []string
+	}{
+		{`every 2 hours`, "FREQ=HOURLY;INTERVAL=2;COUNT=3", []string{"19970902T100000", "19970902T120000", "19970902T140000"}},
+		{`every 3 hours`, "FREQ=HOURLY;INTERVAL=3;COUNT=3", []string{"19970902T100000", "19970902T130000", "19970902T160000"}},
+		{`every 90 minutes`, "FREQ=MINUTELY;INTERVAL=90;COUNT=3", []string{"19970902T100000", "19970902T113000", "19970902T130000"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tt.rule)
+			require.NoError(t, err)
+
+			got := []string{}
+			it := rule.Iterator(time.Date(1997, 9, 2, 10, 0, 0, 0, kolkata))
+			for next, ok := it.Next(); ok; next, ok = it.Next() {
+				got = append(got, next.Format("20060102T150405"))
+			}
+
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// sparse rules are expanded by skipping days never matched, and rules never matched stop with error
+func TestRuleIteratorBound(t *testing.T) {
+	type args struct {
+		dtstart string
+		rule    string
+		n       int
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{`minutely on the first day of month`, args{"19970102T090000", "FREQ=MINUTELY;BYMONTHDAY=1", 3}, []string{
+			"19970102T090000", "19970201T000000", "19970201T000100",
+		}, false},
+		{`minutely at an hour of leap day`, args{"19970102T090000", "FREQ=MINUTELY;INTERVAL=30;BYMONTH=2;BYMONTHDAY=29;BYHOUR=12", 3}, []string{
+			"19970102T090000", "20000229T120000", "20000229T123000",
+		}, false},
+		{`never matched`, args{"19970902T090000", "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30", 10}, []string{"19970902T090000"}, true},
+		{`minutely never matched`, args{"19970902T090000", "FREQ=MINUTELY;BYMONTH=2;BYMONTHDAY=30", 10}, []string{"19970902T090000"}, true},
+		{`seconds never matched`, args{"19970902T090000", "FREQ=SECONDLY;INTERVAL=2;BYSECOND=1", 10}, []string{"19970902T090000"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dtstart, err := time.ParseInLocation("20060102T150405", tt.args.dtstart, time.UTC)
+			require.NoError(t, err)
+
+			rule, err := ParseRecurrenceRule(tt.args.rule)
+			require.NoError(t, err)
+
+			got := []string{}
+			it := rule.Iterator(dtstart)
+			for next, ok := it.Next(); ok && len(got) < tt.args.n; next, ok = it.Next() {
+				got = append(got, next.Format("20060102T150405"))
+			}
+
+			err = it.Err()
+			require.Truef(t, (err != nil) == tt.wantErr, `Err() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// occurrences are expanded as needed; full period of every second was 4GB
+func TestRuleIteratorAllocs(t *testing.T) {
+	rule, err := ParseRecurrenceRule(everySecond)
+	require.NoError(t, err)
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	it := rule.Iterator(time.Date(1997, 9, 2, 9, 0, 0, 0, time.UTC))
+	n := 0
+	for _, ok := it.Next(); ok; _, ok = it.Next() {
+		n++
+	}
+
+	runtime.ReadMemStats(&after)
+	require.Equal(t, 2, n)
+	require.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(64<<20))
+}
+
+func TestEventOccurrences(t *testing.T) {
+	type args struct {
+		data string
+		n    int
+	}
+	tests := [...]struct {
+		name string
+		args args
+		want []string
+	}{
+		{`no recurrence`, args{`BEGIN:VEVENT
+DTSTART:19970902T090000Z
+DTEND:19970902T100000Z
+END:VEVENT`, 10}, []string{"19970902T090000Z/19970902T100000Z"}},
+		{`exdate`, args{`BEGIN:VEVENT
+DTSTART;TZID=America/New_York:19970902T090000
+DURATION:PT1H
+RRULE:FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13
+EXDATE;TZID=America/New_York:19970902T090000
+END:VEVENT`, 3}, []string{
+			"19980213T090000/19980213T100000",
+			"19980313T090000/19980313T100000",
+			"19981113T090000/19981113T100000",
+		}},
+		{`rdate`, args{`BEGIN:VEVENT
+DTSTART:19970902T090000Z
+DTEND:19970902T100000Z
+RRULE:FREQ=WEEKLY;COUNT=3
+RDATE:19970903T090000Z,19970909T090000Z
+RDATE;VALUE=PERIOD:19970920T090000Z/PT3H
+EXDATE:19970916T090000Z
+END:VEVENT`, 10}, []string{
+			"19970902T090000Z/19970902T100000Z",
+			"19970903T090000Z/19970903T100000Z",
+			"19970909T090000Z/19970909T100000Z",
+			"19970920T090000Z/19970920T120000Z",
+		}},
+		{`all day with exdate`, args{`BEGIN:VEVENT
+DTSTART;VALUE=DATE:19970902
+RRULE:FREQ=DAILY;COUNT=3
+EXDATE;VALUE=DATE:19970903
+END:VEVENT`, 10}, []string{"19970902/19970903", "19970904/19970905"}},
+		{`exrule`, args{`BEGIN:VEVENT
+DTSTART:19970902T090000Z
+RRULE:FREQ=DAILY;COUNT=7
+EXRULE:FREQ=DAILY;INTERVAL=2
+END:VEVENT`, 10}, []string{"19970903T090000Z", "19970905T090000Z", "19970907T090000Z"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evt := new(VEvent)
+			require.NoError(t, NewEventDecoder(strings.NewReader(tt.args.data)).Decode(evt))
+
+			got := []string{}
+			for _, occurrence := range evt.Occurrences().Take(tt.args.n) {
+				s := occurrence.Start.String()
+				if !occurrence.End.IsZero() {
+					s += "/" + occurrence.End.String()
+				}
+				got = append(got, s)
+			}
+
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,486 @@
+package ical
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/whitekid/goxp"
+)
+
+// VTimezone rfc5545 3.6.5 Time Zone Component
+type VTimezone struct {
+	TZID  string `validate:"required,max=100"`
+	Rules []*TimezoneRule
+
+	Extensions []Property // unknown and X- properties; ex. X-LIC-LOCATION
+}
+
+// TimezoneRule STANDARD or DAYLIGHT sub-component of VTIMEZONE
+type TimezoneRule struct {
+	Daylight   bool
+	DtStart    DateTime  // onset as local time, before transition
+	OffsetFrom UTCOffset // 3.8.3.3 Time Zone Offset From
+	OffsetTo   UTCOffset // 3.8.3.4 Time Zone Offset To
+	Name       string    `validate:"max=100"` // 3.8.3.2 Time Zone Name
+	RRule      string    `validate:"max=100"`
+
+	Extensions []Property // unknown and X- properties
+}
+
+// tzTransition offset change of the location
+type tzTransition struct {
+	local    time.Time // wall clock time before transition
+	at       time.Time
+	from, to int
+	name     string
+	daylight bool
+}
+
+// NewVTimezone build VTIMEZONE from tzdata of loc; covers transitions around from and to
+func NewVTimezone(loc *time.Location, from, to time.Time) *VTimezone {
+	start := time.Date(from.Year()-1, 1, 1, 0, 0, 0, 0, loc)
+	end := time.Date(to.Year()+2, 1, 1, 0, 0, 0, 0, loc)
+
+	transitions := zoneTransitions(loc, start, end)
+	tz := &VTimezone{TZID: loc.String()}
+
+	if len(transitions) == 0 {
+		name, offset := start.Zone()
+		tz.Rules = append(tz.Rules, &TimezoneRule{
+			Daylight:   start.IsDST(),
+			DtStart:    DateTime{Time: time.Date(1970, 1, 1, 0, 0, 0, 0, time.Local)},
+			OffsetFrom: UTCOffset{time.Duration(offset) * time.Second},
+			OffsetTo:   UTCOffset{time.Duration(offset) * time.Second},
+			Name:       name,
+		})
+		return tz
+	}
+
+	// group by kind of transition and make yearly rule of consecutive years
+	groups := map[string][]*tzTransition{}
+	keys := []string{}
+	for _, tr := range transitions {
+		key := fmt.Sprintf("%v/%d/%d/%s", tr.daylight, tr.from, tr.to, tr.name)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], tr)
+	}
+
+	for _, key := range keys {
+		run := []*tzTransition{}
+		for _, tr := range groups[key] {
+			if len(run) > 0 && ruleDay(append(run, tr)) == 0 {
+				tz.Rules = append(tz.Rules, newTimezoneRule(run, end))
+				run = run[:0:0]
+			}
+			run = append(run, tr)
+		}
+		tz.Rules = append(tz.Rules, newTimezoneRule(run, end))
+	}
+
+	sort.SliceStable(tz.Rules, func(i, j int) bool { return tz.Rules[i].DtStart.Before(tz.Rules[j].DtStart.Time) })
+	return tz
+}
+
+// zoneTransitions returns transitions of loc in [start, end)
+func zoneTransitions(loc *time.Location, start, end time.Time) []*tzTransition {
+	zone := func(t time.Time) (string, int, bool) {
+		name, offset := t.Zone()
+		return name, offset, t.IsDST()
+	}
+
+	transitions := []*tzTransition{}
+	prev := start
+	for t := start.Add(Day); t.Before(end); t = t.Add(Day) {
+		pname, poffset, pdst := zone(prev)
+		name, offset, dst := zone(t)
+		if pname == name && poffset == offset && pdst == dst {
+			prev = t
+			continue
+		}
+
+		// binary search to the second
+		lo, hi := prev.Unix(), t.Unix()
+		for hi-lo > 1 {
+			mid := lo + (hi-lo)/2
+			if n, o, d := zone(time.Unix(mid, 0).In(loc)); n == pname && o == poffset && d == pdst {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+
+		at := time.Unix(hi, 0).UTC()
+		transitions = append(transitions, &tzTransition{
+			local:    at.Add(time.Duration(poffset) * time.Second),
+			at:       at,
+			from:     poffset,
+			to:       offset,
+			name:     name,
+			daylight: dst,
+		})
+		prev = t
+	}
+
+	return transitions
+}
+
+// byDay returns n-th weekday of the month of t and whether it is the last weekday of the month
+func byDay(t time.Time) (int, bool) {
+	return (t.Day()-1)/7 + 1, t.AddDate(0, 0, 7).Month() != t.Month()
+}
+
+// ruleDay returns BYDAY ordinal if transitions occur yearly at the same weekday of the month, 0 otherwise
+func ruleDay(run []*tzTransition) int {
+	first := run[0].local
+	n, last := byDay(first)
+	for i, tr := range run[1:] {
+		t := tr.local
+		if t.Year() != run[i].local.Year()+1 || t.Month() != first.Month() || t.Weekday() != first.Weekday() ||
+			t.Hour() != first.Hour() || t.Minute() != first.Minute() || t.Second() != first.Second() {
+			return 0
+		}
+
+		tn, tlast := byDay(t)
+		if tn != n {
+			n = 0
+		}
+		last = last && tlast
+	}
+
+	if n != 0 {
+		return n
+	}
+	if last {
+		return -1
+	}
+	return 0
+}
+
+func newTimezoneRule(run []*tzTransition, end time.Time) *TimezoneRule {
+	first := run[0]
+	rule := &TimezoneRule{
+		Daylight:   first.daylight,
+		DtStart:    DateTime{Time: time.Date(first.local.Year(), first.local.Month(), first.local.Day(), first.local.Hour(), first.local.Minute(), first.local.Second(), 0, time.Local)},
+		OffsetFrom: UTCOffset{time.Duration(first.from) * time.Second},
+		OffsetTo:   UTCOffset{time.Duration(first.to) * time.Second},
+		Name:       first.name,
+	}
+
+	if len(run) < 2 {
+		return rule
+	}
+
+	rule.RRule = fmt.Sprintf("FREQ=YEARLY;BYMONTH=%d;BYDAY=%d%s", first.local.Month(), ruleDay(run),
+		strings.ToUpper(first.local.Weekday().String()[:2]))
+
+	// rule was abolished before the end of the range
+	if last := run[len(run)-1]; last.local.Year() < end.Year()-1 {
+		rule.RRule += ";UNTIL=" + last.at.Format("20060102T150405Z")
+	}
+
+	return rule
+}
+
+// timezones locations of VTIMEZONE by TZID
+type timezones map[string]*time.Location
+
+// location returns location of the TZID in tzdata, or of VTIMEZONE; ex. Outlook uses "Pacific Standard Time"
+// tzdata is preferred because VTIMEZONE usually covers only the years of the components
+func (tzs timezones) location(tzid string) (*time.Location, error) {
+	loc, err := time.LoadLocation(tzid)
+	if err == nil {
+		return loc, nil
+	}
+	if loc, ok := tzs[tzid]; ok {
+		return loc, nil
+	}
+	return nil, err
+}
+
+const (
+	maxTransitionYear = 2100 // transitions of recurring rules are expanded until the year; the last offset is kept after that
+	maxTransitions    = 5000 // transitions of VTIMEZONE; ex. yearly rules from 1601 of Outlook are about 1000
+)
+
+// tzZone local time type of TZif
+type tzZone struct {
+	offset   int
+	daylight bool
+	name     string
+}
+
+// location returns location of the timezone rules named by TZID
+func (tz *VTimezone) location() (*time.Location, error) {
+	if len(tz.Rules) == 0 {
+		return nil, fmt.Errorf("VTIMEZONE %s: STANDARD or DAYLIGHT required", tz.TZID)
+	}
+
+	type transition struct {
+		at   int64
+		zone tzZone
+	}
+
+	transitions := []transition{}
+	for _, rule := range tz.Rules {
+		// DTSTART is local time before the onset, in TZOFFSETFROM
+		from := time.FixedZone("", int(rule.OffsetFrom.Seconds()))
+		d := rule.DtStart
+		onset := time.Date(d.Year(), d.Month(), d.Day(), d.Hour(), d.Minute(), d.Second(), 0, from)
+		zone := tzZone{offset: int(rule.OffsetTo.Seconds()), daylight: rule.Daylight, name: rule.Name}
+
+		if rule.RRule == "" {
+			transitions = append(transitions, transition{onset.Unix(), zone})
+			continue
+		}
+
+		rrule, err := ParseRecurrenceRule(rule.RRule)
+		if err != nil {
+			return nil, errors.Wrapf(err, "VTIMEZONE %s", tz.TZID)
+		}
+
+		it := rrule.Iterator(onset)
+		for t, ok := it.Next(); ok && t.Year() <= maxTransitionYear; t, ok = it.Next() {
+			if len(transitions) >= maxTransitions {
+				return nil, fmt.Errorf("VTIMEZONE %s: too many transitions", tz.TZID)
+			}
+			transitions = append(transitions, transition{t.Unix(), zone})
+		}
+		if err := it.Err(); err != nil {
+			return nil, errors.Wrapf(err, "VTIMEZONE %s", tz.TZID)
+		}
+	}
+	sort.SliceStable(transitions, func(i, j int) bool { return transitions[i].at < transitions[j].at })
+
+	// the first zone is not used by transitions, so it is the zone before the first transition
+	first := tz.Rules[0]
+	for _, rule := range tz.Rules {
+		if rule.DtStart.Before(first.DtStart.Time) {
+			first = rule
+		}
+	}
+	zones := []tzZone{{offset: int(first.OffsetFrom.Seconds()), daylight: !first.Daylight}}
+
+	index := map[tzZone]int{}
+	times := make([]int64, len(transitions))
+	types := make([]byte, len(transitions))
+	for i, tr := range transitions {
+		n, ok := index[tr.zone]
+		if !ok {
+			n = len(zones)
+			index[tr.zone] = n
+			zones = append(zones, tr.zone)
+		}
+		if n > 255 {
+			return nil, fmt.Errorf("VTIMEZONE %s: too many offsets", tz.TZID)
+		}
+		times[i], types[i] = tr.at, byte(n)
+	}
+
+	return time.LoadLocationFromTZData(tz.TZID, tzifData(zones, times, types))
+}
+
+// tzifData returns TZif version 2 data of RFC 8536 without version 1 data and TZ string footer
+func tzifData(zones []tzZone, times []int64, types []byte) []byte {
+	// abbreviations are indexed by a byte; empty name at 0 if it overflows
+	names := []byte{0}
+	nameIndex := make([]byte, len(zones))
+	for i, zone := range zones {
+		if zone.name == "" || len(names)+len(zone.name)+1 > 256 {
+			continue
+		}
+		nameIndex[i] = byte(len(names))
+		names = append(append(names, zone.name...), 0)
+	}
+
+	buf := new(bytes.Buffer)
+	header := func(timeCount, zoneCount, charCount int) {
+		buf.WriteString("TZif2")
+		buf.Write(make([]byte, 15))
+		// isutcnt, isstdcnt, leapcnt, timecnt, typecnt, charcnt
+		for _, n := range []int{0, 0, 0, timeCount, zoneCount, charCount} {
+			binary.Write(buf, binary.BigEndian, uint32(n))
+		}
+	}
+
+	header(0, 0, 0)
+	header(len(times), len(zones), len(names))
+	for _, t := range times {
+		binary.Write(buf, binary.BigEndian, t)
+	}
+	buf.Write(types)
+	for i, zone := range zones {
+		binary.Write(buf, binary.BigEndian, int32(zone.offset))
+		buf.WriteByte(goxp.Ternary[byte](zone.daylight, 1, 0))
+		buf.WriteByte(nameIndex[i])
+	}
+	buf.Write(names)
+	buf.WriteString("\n\n")
+
+	return buf.Bytes()
+}
+
+// decodeTimezone decode unfolded content lines of VTIMEZONE
+func decodeTimezone(s *decodeState, tz *VTimezone, lines []textLine) error {
+	seen, err := s.decodeComponent("VTIMEZONE", lines, &tz.Extensions, func(cl *contentLine) (bool, error) {
+		switch cl.name {
+		case "TZID":
+			tz.TZID = unescape(cl.value)
+		default:
+			return false, nil
+		}
+		return true, nil
+	}, func(name string, component []textLine) (bool, error) {
+		if name != "STANDARD" && name != "DAYLIGHT" {
+			return false, nil
+		}
+
+		rule := &TimezoneRule{Daylight: name == "DAYLIGHT"}
+		if err := decodeTimezoneRule(s, rule, component); err != nil {
+			return true, err
+		}
+		tz.Rules = append(tz.Rules, rule)
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.validate(lines, "VTIMEZONE", seen, []string{"TZID"}, nil)
+	return nil
+}
+
+func decodeTimezoneRule(s *decodeState, rule *TimezoneRule, lines []textLine) error {
+	name := goxp.Ternary(rule.Daylight, "DAYLIGHT", "STANDARD")
+	seen, err := s.decodeComponent(name, lines, &rule.Extensions, func(cl *contentLine) (bool, error) {
+		key, value := cl.name, cl.value
+
+		var err error
+		switch key {
+		case "DTSTART":
+			rule.DtStart, err = parseDateTime(value, cl.params, nil)
+		case "TZOFFSETFROM":
+			rule.OffsetFrom, err = parseUTCOffset(value)
+		case "TZOFFSETTO":
+			rule.OffsetTo, err = parseUTCOffset(value)
+		case "TZNAME":
+			rule.Name = unescape(value)
+		case "RRULE":
+			rule.RRule = value
+		default:
+			return false, nil
+		}
+
+		return true, err
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	s.validate(lines, name, seen, []string{"DTSTART", "TZOFFSETFROM", "TZOFFSETTO"}, nil)
+	return nil
+}
+
+func encodeTimezone(w io.Writer, tz *VTimezone) error {
+	if tz.TZID == "" {
+		return errors.New("VTIMEZONE: TZID required")
+	}
+
+	fmt.Fprintf(w, "BEGIN:VTIMEZONE\r\n")
+	if err := writeField(w, "TZID", tz.TZID); err != nil {
+		return err
+	}
+	if err := writeExtensions(w, tz.Extensions); err != nil {
+		return err
+	}
+
+	for _, rule := range tz.Rules {
+		name := goxp.Ternary(rule.Daylight, "DAYLIGHT", "STANDARD")
+
+		fmt.Fprintf(w, "BEGIN:%s\r\n", name)
+		if err := writeFields(w, []*goxp.Tuple2[string, any]{
+			{"DTSTART", rule.DtStart},
+			{"TZOFFSETFROM", rule.OffsetFrom},
+			{"TZOFFSETTO", rule.OffsetTo},
+			{"TZNAME", rule.Name},
+		}); err != nil {
+			return err
+		}
+
+		// RRULE is written as is; parameter separators should not be escaped
+		if rule.RRule != "" {
+			if err := writeLine(w, "RRULE:"+rule.RRule); err != nil {
+				return err
+			}
+		}
+		if err := writeExtensions(w, rule.Extensions); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "END:%s\r\n", name)
+	}
+	fmt.Fprintf(w, "END:VTIMEZONE\r\n")
+
+	return nil
+}
+
+// timezonesOf returns VTIMEZONE of TZIDs referenced by components of the calendar
+func timezonesOf(cal *VCalendar) []*VTimezone {
+	type span struct {
+		loc      *time.Location
+		from, to time.Time
+	}
+
+	tzids := []string{}
+	spans := map[string]*span{}
+	add := func(values ...DateTime) {
+		for _, dt := range values {
+			tzid := dt.TZID()
+			if tzid == "" {
+				continue
+			}
+
+			s, ok := spans[tzid]
+			if !ok {
+				tzids = append(tzids, tzid)
+				spans[tzid] = &span{loc: dt.Location(), from: dt.Time, to: dt.Time}
+				continue
+			}
+
+			if dt.Before(s.from) {
+				s.from = dt.Time
+			}
+			if dt.After(s.to) {
+				s.to = dt.Time
+			}
+		}
+	}
+
+	for _, evt := range cal.Events {
+		add(evt.DtStart, evt.DtEnd, evt.Created, evt.LastModied)
+		add(evt.ExDates...)
+		for _, period := range evt.RDates {
+			add(period.Start, period.End)
+		}
+	}
+	for _, todo := range cal.Todos {
+		add(todo.DtStart, todo.Due, todo.Created, todo.LastModified, todo.ExDate, todo.RDate)
+	}
+	for _, journal := range cal.Journals {
+		add(journal.DtStart, journal.Created, journal.LastModified, journal.ExDate, journal.RDate)
+	}
+
+	timezones := []*VTimezone{}
+	for _, tzid := range tzids {
+		s := spans[tzid]
+		timezones = append(timezones, NewVTimezone(s.loc, s.from, s.to))
+	}
+
+	return timezones
+}
@@ -0,0 +1,119 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewVTimezone(t *testing.T) {
+	type args struct {
+		tzid     string
+		from, to string
+	}
+	tests := [...]struct {
+		name string
+		args args
+		want string
+	}{
+		{`no daylight saving`, args{"Asia/Seoul", "20230701T090000", "20230701T090000"}, `BEGIN:VTIMEZONE
+TZID:Asia/Seoul
+BEGIN:STANDARD
+DTSTART:19700101T000000
+TZOFFSETFROM:+0900
+TZOFFSETTO:+0900
+TZNAME:KST
+END:STANDARD
+END:VTIMEZONE
+`},
+		{`daylight saving`, args{"America/New_York", "20230701T090000", "20230701T090000"}, `BEGIN:VTIMEZONE
+TZID:America/New_York
+BEGIN:DAYLIGHT
+DTSTART:20220313T020000
+TZOFFSETFROM:-0500
+TZOFFSETTO:-0400
+TZNAME:EDT
+RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=2SU
+END:DAYLIGHT
+BEGIN:STANDARD
+DTSTART:20221106T020000
+TZOFFSETFROM:-0400
+TZOFFSETTO:-0500
+TZNAME:EST
+RRULE:FREQ=YEARLY;BYMONTH=11;BYDAY=1SU
+END:STANDARD
+END:VTIMEZONE
+`},
+		{`last sunday`, args{"Europe/London", "20230701T090000", "20240701T090000"}, `BEGIN:VTIMEZONE
+TZID:Europe/London
+BEGIN:DAYLIGHT
+DTSTART:20220327T010000
+TZOFFSETFROM:+0000
+TZOFFSETTO:+0100
+TZNAME:BST
+RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU
+END:DAYLIGHT
+BEGIN:STANDARD
+DTSTART:20221030T020000
+TZOFFSETFROM:+0100
+TZOFFSETTO:+0000
+TZNAME:GMT
+RRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU
+END:STANDARD
+END:VTIMEZONE
+`},
+		{`abolished daylight saving`, args{"Europe/Moscow", "20100701T090000", "20150701T090000"}, `BEGIN:VTIMEZONE
+TZID:Europe/Moscow
+BEGIN:DAYLIGHT
+DTSTART:20090329T020000
+TZOFFSETFROM:+0300
+TZOFFSETTO:+0400
+TZNAME:MSD
+RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU;UNTIL=20100327T230000Z
+END:DAYLIGHT
+BEGIN:STANDARD
+DTSTART:20091025T030000
+TZOFFSETFROM:+0400
+TZOFFSETTO:+0300
+TZNAME:MSK
+RRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU;UNTIL=20101030T230000Z
+END:STANDARD
+BEGIN:STANDARD
+DTSTART:20110327T020000
+TZOFFSETFROM:+0300
+TZOFFSETTO:+0400
+TZNAME:MSK
+END:STANDARD
+BEGIN:STANDARD
+DTSTART:20141026T020000
+TZOFFSETFROM:+0400
+TZOFFSETTO:+0300
+TZNAME:MSK
+END:STANDARD
+END:VTIMEZONE
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, err := parseDateTime(tt.args.from, Params{"TZID": {tt.args.tzid}}, nil)
+			require.NoError(t, err)
+			to, err := parseDateTime(tt.args.to, Params{"TZID": {tt.args.tzid}}, nil)
+			require.NoError(t, err)
+
+			tz := NewVTimezone(from.Location(), from.Time, to.Time)
+
+			buf := new(bytes.Buffer)
+			require.NoError(t, encodeTimezone(buf, tz))
+			require.Equal(t, strings.ReplaceAll(tt.want, "\n", "\r\n"), buf.String())
+
+			lines, err := readLines(buf)
+			require.NoError(t, err)
+
+			got := new(VTimezone)
+			require.NoError(t, decodeTimezone(newDecodeState(nil), got, lines))
+			require.Equal(t, tz, got)
+		})
+	}
+}
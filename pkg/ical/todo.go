@@ -0,0 +1,224 @@
+package ical
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/whitekid/goxp"
+)
+
+// VTodo rfc5545 3.6.2 To-Do Component
+type VTodo struct {
+	Class           string   `validate:"max=100"` // PUBLIC(*), PRIVATE, CONFIDENTIAL
+	Completed       DateTime // 3.8.2.1 Date-Time Completed, as UTC
+	Created         DateTime
+	Description     string   `validate:"max=500"`
+	DtStamp         DateTime `validate:"required"`
+	DtStart         DateTime
+	Geo             string `validate:"max=100"`
+	LastModified    DateTime
+	Location        string `validate:"max=100"`
+	Organizer       string `validate:"max=100"`
+	PercentComplete int    `validate:"min=0,max=100"` // 3.8.1.8 Percent Complete
+	Priority        string `validate:"max=100"`
+	RecurrenceID    string `validate:"max=100"`
+	Seq             int
+	Status          string   `validate:"max=100"` // NEEDS-ACTION, COMPLETED, IN-PROCESS, CANCELLED
+	Summary         string   `validate:"max=100"`
+	UID             string   `validate:"max=100"`
+	URL             string   `validate:"max=100"`
+	Due             DateTime // 3.8.2.3 Date-Time Due; exclusive with Duration
+	Duration        Duration
+	Attach          string   `validate:"max=100"`
+	Attendee        string   `validate:"max=100"`
+	Categories      []string `validate:"max=100"`
+	Comment         string   `validate:"max=100"`
+	Contact         string   `validate:"max=100"`
+	ExDate          DateTime
+	RStatus         string `validate:"max=100"`
+	Related         string `validate:"max=100"`
+	Resources       string `validate:"max=100"`
+	RDate           DateTime
+	RRule           string `validate:"max=100"`
+
+	Extensions []Property // unknown and X- properties
+}
+
+type todoDecoder struct {
+	r    io.Reader
+	opts []DecodeOption
+}
+
+var _ Decoder = (*todoDecoder)(nil)
+
+func NewTodoDecoder(r io.Reader, opts ...DecodeOption) Decoder {
+	return &todoDecoder{r: r, opts: opts}
+}
+
+func (d *todoDecoder) Decode(v any) error {
+	todo, ok := v.(*VTodo)
+	if !ok {
+		return fmt.Errorf("v must be pointer of VTodo")
+	}
+
+	lines, err := readLines(d.r)
+	if err != nil {
+		return err
+	}
+
+	s := newDecodeState(d.opts)
+	if err := decodeTodo(s, todo, lines); err != nil {
+		return err
+	}
+	return s.err()
+}
+
+// decodeTodo decode unfolded content lines of VTODO
+func decodeTodo(s *decodeState, todo *VTodo, lines []textLine) error {
+	seen, err := s.decodeComponent("VTODO", lines, &todo.Extensions, func(cl *contentLine) (bool, error) {
+		key, params, value := cl.name, cl.params, cl.value
+
+		var err error
+		switch key {
+		case "CLASS":
+			todo.Class = unescape(value)
+		case "COMPLETED":
+			todo.Completed, err = parseDateTime(value, params, s.timezones)
+		case "CREATED":
+			todo.Created, err = parseDateTime(value, params, s.timezones)
+		case "DESCRIPTION":
+			todo.Description = unescape(value)
+		case "DTSTAMP":
+			todo.DtStamp, err = parseDateTime(value, params, s.timezones)
+		case "DTSTART":
+			todo.DtStart, err = parseDateTime(value, params, s.timezones)
+		case "GEO":
+			todo.Geo = unescape(value)
+		case "LAST-MODIFIED":
+			todo.LastModified, err = parseDateTime(value, params, s.timezones)
+		case "LOCATION":
+			todo.Location = unescape(value)
+		case "ORGANIZER":
+			todo.Organizer = unescape(value)
+		case "PERCENT-COMPLETE":
+			todo.PercentComplete, err = parseInteger(value)
+		case "PRIORITY":
+			todo.Priority = unescape(value)
+		case "RECURRENCE-ID":
+			todo.RecurrenceID = unescape(value)
+		case "SEQUENCE":
+			todo.Seq, err = parseInteger(value)
+		case "STATUS":
+			todo.Status = unescape(value)
+		case "SUMMARY":
+			todo.Summary = unescape(value)
+		case "UID":
+			todo.UID = unescape(value)
+		case "URL":
+			todo.URL = unescape(value)
+		case "DUE":
+			todo.Due, err = parseDateTime(value, params, s.timezones)
+		case "DURATION":
+			todo.Duration, err = parseDuration(value)
+		case "ATTACH":
+			todo.Attach = unescape(value)
+		case "ATTENDEE":
+			todo.Attendee = unescape(value)
+		case "CATEGORIES":
+			if len(value) != 0 {
+				todo.Categories = strings.Split(value, ",")
+			}
+		case "COMMENT":
+			todo.Comment = unescape(value)
+		case "CONTACT":
+			todo.Contact = unescape(value)
+		case "EXDATE":
+			todo.ExDate, err = parseDateTime(value, params, s.timezones)
+		case "REQUEST-STATUS":
+			todo.RStatus = value
+		case "RELATED":
+			todo.Related = unescape(value)
+		case "RESOURCES":
+			todo.Resources = unescape(value)
+		case "RDATE":
+			todo.RDate, err = parseDateTime(value, params, s.timezones)
+		case "RRULE":
+			todo.RRule = unescape(value)
+		default:
+			return false, nil
+		}
+
+		return true, err
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	s.validate(lines, "VTODO", seen, []string{"UID", "DTSTAMP"}, [][2]string{{"DUE", "DURATION"}})
+	return nil
+}
+
+type todoEncoder struct {
+	w io.Writer
+}
+
+var _ Encoder = (*todoEncoder)(nil)
+
+func NewTodoEncoder(w io.Writer) Encoder {
+	return &todoEncoder{w: w}
+}
+
+func (enc *todoEncoder) Encode(v any) error {
+	todo, ok := v.(*VTodo)
+	if !ok {
+		return fmt.Errorf("want *VTodo")
+	}
+
+	if !todo.Due.IsZero() && todo.Duration.Duration != 0 {
+		return errors.New("DUE and DURATION are exclusive")
+	}
+
+	fmt.Fprintf(enc.w, "BEGIN:VTODO\r\n")
+	if err := writeFields(enc.w, []*goxp.Tuple2[string, any]{
+		{"CLASS", todo.Class},
+		{"COMPLETED", todo.Completed},
+		{"CREATED", todo.Created},
+		{"DESCRIPTION", todo.Description},
+		{"DTSTAMP", todo.DtStamp},
+		{"DTSTART", todo.DtStart},
+		{"GEO", rawValue(todo.Geo)},
+		{"LAST-MODIFIED", todo.LastModified},
+		{"LOCATION", todo.Location},
+		{"ORGANIZER", todo.Organizer},
+		{"PERCENT-COMPLETE", todo.PercentComplete},
+		{"PRIORITY", todo.Priority},
+		{"RECURRENCE-ID", todo.RecurrenceID},
+		{"SEQUENCE", todo.Seq},
+		{"STATUS", todo.Status},
+		{"SUMMARY", todo.Summary},
+		{"UID", todo.UID},
+		{"URL", todo.URL},
+		{"DUE", todo.Due},
+		{"DURATION", todo.Duration},
+		{"ATTACH", todo.Attach},
+		{"ATTENDEE", todo.Attendee},
+		{"CATEGORIES", todo.Categories},
+		{"COMMENT", todo.Comment},
+		{"CONTACT", todo.Contact},
+		{"EXDATE", todo.ExDate},
+		{"REQUEST-STATUS", rawValue(todo.RStatus)},
+		{"RELATED", todo.Related},
+		{"RESOURCES", todo.Resources},
+		{"RDATE", todo.RDate},
+		{"RRULE", todo.RRule},
+	}); err != nil {
+		return err
+	}
+	if err := writeExtensions(enc.w, todo.Extensions); err != nil {
+		return err
+	}
+	fmt.Fprintf(enc.w, "END:VTODO\r\n")
+	return nil
+}
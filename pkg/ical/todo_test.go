@@ -0,0 +1,130 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoDecode(t *testing.T) {
+	type args struct {
+		data string
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+		want    *VTodo
+	}{
+		{`valid`, args{`BEGIN:VTODO
+UID:20070313T123432Z-456553@example.com
+DTSTAMP:20070313T123432Z
+DUE:20070501
+SUMMARY:Submit Quebec Income Tax Return for 2006
+CLASS:CONFIDENTIAL
+CATEGORIES:FAMILY,FINANCE
+STATUS:NEEDS-ACTION
+PERCENT-COMPLETE:40
+SEQUENCE:2
+END:VTODO
+`}, false, &VTodo{
+			UID:             "20070313T123432Z-456553@example.com",
+			DtStamp:         mustParseDateTime("20070313T123432Z"),
+			Due:             mustParseDateTime("20070501"),
+			Summary:         "Submit Quebec Income Tax Return for 2006",
+			Class:           "CONFIDENTIAL",
+			Categories:      []string{"FAMILY", "FINANCE"},
+			Status:          "NEEDS-ACTION",
+			PercentComplete: 40,
+			Seq:             2,
+		}},
+		{`completed`, args{`BEGIN:VTODO
+UID:20070514T103211Z-123404@example.com
+DTSTAMP:20070514T103211Z
+DTSTART:20070514T110000Z
+DURATION:P1D
+COMPLETED:20070707T100000Z
+SUMMARY:Submit Revised Internet-Draft
+STATUS:COMPLETED
+END:VTODO
+`}, false, &VTodo{
+			UID:       "20070514T103211Z-123404@example.com",
+			DtStamp:   mustParseDateTime("20070514T103211Z"),
+			DtStart:   mustParseDateTime("20070514T110000Z"),
+			Duration:  Duration{Day},
+			Completed: mustParseDateTime("20070707T100000Z"),
+			Summary:   "Submit Revised Internet-Draft",
+			Status:    "COMPLETED",
+		}},
+		{`invalid percent`, args{"BEGIN:VTODO\nPERCENT-COMPLETE:forty\nEND:VTODO\n"}, true, nil},
+		{`invalid due`, args{"BEGIN:VTODO\nDUE:tomorrow\nEND:VTODO\n"}, true, nil},
+		{`unknown field`, args{"BEGIN:VTODO\nUNKNOWN:x\nX-UNKNOWN:y\nX-UNKNOWN:z\nEND:VTODO\n"}, false, &VTodo{
+			Extensions: []Property{{Name: "UNKNOWN", Value: "x"}, {Name: "X-UNKNOWN", Value: "y"}, {Name: "X-UNKNOWN", Value: "z"}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := new(VTodo)
+			err := NewTodoDecoder(strings.NewReader(tt.args.data)).Decode(got)
+			require.Truef(t, (err != nil) == tt.wantErr, `Decode() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			require.Equal(t, tt.want, got)
+
+			buf := new(bytes.Buffer)
+			require.NoError(t, NewTodoEncoder(buf).Encode(got))
+
+			decoded := new(VTodo)
+			require.NoError(t, NewTodoDecoder(buf).Decode(decoded))
+			require.Equal(t, got, decoded)
+		})
+	}
+}
+
+func TestTodoEncode(t *testing.T) {
+	type args struct {
+		todo *VTodo
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+		want    string
+	}{
+		{`valid`, args{&VTodo{
+			UID:             "uid1@example.com",
+			DtStamp:         DateTime{Time: time.Date(2007, 3, 13, 12, 34, 32, 0, time.UTC)},
+			Due:             DateTime{Time: time.Date(2007, 5, 1, 9, 0, 0, 0, time.UTC)},
+			Summary:         "Replace filter",
+			PercentComplete: 50,
+		}}, false, `BEGIN:VTODO
+DTSTAMP:20070313T123432Z
+PERCENT-COMPLETE:50
+SUMMARY:Replace filter
+UID:uid1@example.com
+DUE:20070501T090000Z
+END:VTODO
+`},
+		{`due and duration`, args{&VTodo{
+			Due:      DateTime{Time: time.Date(2007, 5, 1, 9, 0, 0, 0, time.UTC)},
+			Duration: Duration{time.Hour},
+		}}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := NewTodoEncoder(buf).Encode(tt.args.todo)
+			require.Truef(t, (err != nil) == tt.wantErr, `Encode() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			require.Equal(t, strings.ReplaceAll(tt.want, "\n", "\r\n"), buf.String())
+		})
+	}
+}
@@ -0,0 +1,346 @@
+package ical
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// value types of 3.3 Property Value Data Types; DATE and DATE-TIME are in DateTime
+
+// parseBoolean 3.3.2 Boolean; TRUE or FALSE, case insensitive
+func parseBoolean(s string) (bool, error) {
+	switch strings.ToUpper(s) {
+	case "TRUE":
+		return true, nil
+	case "FALSE":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid boolean: %s", s)
+}
+
+func formatBoolean(b bool) string {
+	if b {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+// parseFloat 3.3.7 Float; optional sign, digits and optional fraction, ex. 1000000.0000001, -1.333
+//
+// exponent, NaN and Inf are not allowed
+func parseFloat(s string) (float64, error) {
+	whole, frac, hasFrac := strings.Cut(trimSign(s), ".")
+	if !isDigits(whole) || (hasFrac && !isDigits(frac)) {
+		return 0, fmt.Errorf("invalid float: %s", s)
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid float: %s", s)
+	}
+	return f, nil
+}
+
+// formatFloat returns float without exponent
+func formatFloat(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) }
+
+// parseInteger 3.3.8 Integer; optional sign and digits in range of -2147483648 to 2147483647
+func parseInteger(s string) (int, error) {
+	if !isDigits(trimSign(s)) {
+		return 0, fmt.Errorf("invalid integer: %s", s)
+	}
+
+	v, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid integer: %s", s)
+	}
+	return int(v), nil
+}
+
+// trimSign returns s without leading + or -
+func trimSign(s string) string {
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		return s[1:]
+	}
+	return s
+}
+
+// isDigits returns true if s is not empty and all digits
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Duration 3.3.6 Duration; nominal days and weeks are exact 24 hours and 7 days
+//
+//	P15DT5H0M20S
+//	P7W
+//	-PT15M
+type Duration struct {
+	time.Duration
+}
+
+const (
+	Day  = time.Hour * 24
+	Week = Day * 7
+)
+
+// durationUnits units of dur-week, dur-day, dur-hour, dur-minute and dur-second
+var durationUnits = map[byte]time.Duration{
+	'W': Week,
+	'D': Day,
+	'H': time.Hour,
+	'M': time.Minute,
+	'S': time.Second,
+}
+
+// durationForms units of valid durations in order; dur-week, or dur-day and dur-time without gaps of hour, minute and second
+var durationForms = map[string]bool{
+	"W": true, "D": true,
+	"TH": true, "THM": true, "THMS": true, "TM": true, "TMS": true, "TS": true,
+	"DTH": true, "DTHM": true, "DTHMS": true, "DTM": true, "DTMS": true, "DTS": true,
+}
+
+// ParseDuration parse DURATION value; ex. PT1H30M, -P1D
+func ParseDuration(s string) (Duration, error) { return parseDuration(s) }
+
+func parseDuration(s string) (d Duration, err error) {
+	value, neg := strings.CutPrefix(s, "-")
+	if !neg {
+		value = strings.TrimPrefix(value, "+")
+	}
+
+	value, ok := strings.CutPrefix(value, "P")
+	if !ok {
+		return d, fmt.Errorf("invalid duration: P required %s", s)
+	}
+
+	form := ""
+	for value != "" {
+		if value[0] == 'T' {
+			form += "T"
+			value = value[1:]
+			continue
+		}
+
+		i := 0
+		for i < len(value) && '0' <= value[i] && value[i] <= '9' {
+			i++
+		}
+		if i == 0 || i == len(value) {
+			return d, fmt.Errorf("invalid duration: %s", s)
+		}
+
+		unit, ok := durationUnits[value[i]]
+		v, err := strconv.ParseInt(value[:i], 10, 64)
+		if !ok || err != nil || v > (math.MaxInt64-int64(d.Duration))/int64(unit) {
+			return d, fmt.Errorf("invalid duration: %s", s)
+		}
+
+		d.Duration += time.Duration(v) * unit
+		form += value[i : i+1]
+		value = value[i+1:]
+	}
+
+	if !durationForms[form] {
+		return d, fmt.Errorf("invalid duration: %s", s)
+	}
+
+	if neg {
+		d.Duration = -d.Duration
+	}
+
+	return d, nil
+}
+
+// String returns duration in weeks if it is multiple of a week, otherwise in days and time; fraction of a second is truncated
+func (d *Duration) String() string {
+	du := d.Duration.Truncate(time.Second)
+	if du == 0 {
+		return "PT0S"
+	}
+
+	s := new(strings.Builder)
+	if du < 0 {
+		s.WriteString("-")
+		du = -du
+	}
+	s.WriteString("P")
+
+	if du%Week == 0 {
+		fmt.Fprintf(s, "%dW", du/Week)
+		return s.String()
+	}
+
+	if days := du / Day; days > 0 {
+		fmt.Fprintf(s, "%dD", days)
+		du %= Day
+	}
+	if du == 0 {
+		return s.String()
+	}
+
+	s.WriteString("T")
+	hours, minutes, seconds := du/time.Hour, du%time.Hour/time.Minute, du%time.Minute/time.Second
+	if hours > 0 {
+		fmt.Fprintf(s, "%dH", hours)
+	}
+	// minute is required between hour and second
+	if minutes > 0 || (hours > 0 && seconds > 0) {
+		fmt.Fprintf(s, "%dM", minutes)
+	}
+	if seconds > 0 {
+		fmt.Fprintf(s, "%dS", seconds)
+	}
+
+	return s.String()
+}
+
+// Period 3.3.9 Period of Time; start with explicit end or positive duration
+//
+//	19970101T180000Z/19970102T070000Z
+//	19970101T180000Z/PT5H30M
+//
+// as RDATE value, period which has only Start is DATE or DATE-TIME
+type Period struct {
+	Start    DateTime
+	End      DateTime
+	Duration Duration
+}
+
+// IsPeriod returns true if it has end or duration
+func (p *Period) IsPeriod() bool { return !p.End.IsZero() || p.Duration.Duration != 0 }
+
+func (p *Period) String() string {
+	if p.Duration.Duration != 0 {
+		return p.Start.String() + "/" + p.Duration.String()
+	}
+	return p.Start.String() + "/" + p.End.String()
+}
+
+func parsePeriod(value string, params Params, tzs timezones) (period Period, err error) {
+	start, end, ok := strings.Cut(value, "/")
+	if !ok {
+		return period, fmt.Errorf("invalid period: %s", value)
+	}
+
+	tzid := Params{"TZID": params["TZID"]}
+	if period.Start, err = parseDateTime(start, tzid, tzs); err != nil {
+		return period, err
+	}
+
+	if strings.HasPrefix(strings.TrimLeft(end, "+-"), "P") {
+		period.Duration, err = parseDuration(end)
+	} else {
+		period.End, err = parseDateTime(end, tzid, tzs)
+	}
+	if err != nil {
+		return period, err
+	}
+
+	if period.Start.isDate || period.End.isDate || !period.IsPeriod() {
+		return period, fmt.Errorf("invalid period: %s", value)
+	}
+
+	if period.Duration.Duration < 0 || (!period.End.IsZero() && !period.End.After(period.Start.Time)) {
+		return period, fmt.Errorf("invalid period: end must be after start %s", value)
+	}
+	return period, nil
+}
+
+// Time 3.3.12 Time; UTC with Z suffix or floating time in time.Local
+//
+//	230000
+//	070000Z
+//
+// leap second 60 is normalized to the next minute
+type Time struct {
+	time.Time
+}
+
+func parseTime(s string) (tm Time, err error) {
+	value, utc := strings.CutSuffix(s, "Z")
+	if len(value) != 6 || !isDigits(value) {
+		return tm, fmt.Errorf("invalid time: %s", s)
+	}
+
+	hour, _ := strconv.Atoi(value[0:2])
+	minute, _ := strconv.Atoi(value[2:4])
+	second, _ := strconv.Atoi(value[4:6])
+	if hour > 23 || minute > 59 || second > 60 {
+		return tm, fmt.Errorf("invalid time: %s", s)
+	}
+
+	loc := time.Local
+	if utc {
+		loc = time.UTC
+	}
+	tm.Time = time.Date(0, 1, 1, hour, minute, second, 0, loc)
+	return tm, nil
+}
+
+func (t *Time) String() string {
+	if t.Location() == time.UTC {
+		return t.Format("150405Z")
+	}
+	return t.Format("150405")
+}
+
+// UTCOffset 3.3.14 UTC Offset
+//
+//	-0500
+//	+0100
+type UTCOffset struct {
+	time.Duration
+}
+
+func parseUTCOffset(s string) (offset UTCOffset, err error) {
+	if len(s) != 5 && len(s) != 7 || (s[0] != '+' && s[0] != '-') || !isDigits(s[1:]) {
+		return offset, fmt.Errorf("invalid utc offset: %s", s)
+	}
+
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		if 1+i*2 >= len(s) {
+			break
+		}
+
+		v, _ := strconv.Atoi(s[1+i*2 : 3+i*2])
+		if (unit == time.Hour && v > 23) || v > 59 {
+			return offset, fmt.Errorf("invalid utc offset: %s", s)
+		}
+		offset.Duration += time.Duration(v) * unit
+	}
+
+	if s[0] == '-' {
+		if offset.Duration == 0 {
+			return offset, fmt.Errorf("invalid utc offset: %s", s) // -0000 is not allowed
+		}
+		offset.Duration = -offset.Duration
+	}
+
+	return offset, nil
+}
+
+func (o *UTCOffset) String() string {
+	d, sign := o.Duration, '+'
+	if d < 0 {
+		d, sign = -d, '-'
+	}
+
+	s := fmt.Sprintf("%c%02d%02d", sign, d/time.Hour, d%time.Hour/time.Minute)
+	if sec := d % time.Minute / time.Second; sec != 0 {
+		s += fmt.Sprintf("%02d", sec)
+	}
+
+	return s
+}
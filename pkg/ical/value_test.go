@@ -0,0 +1,308 @@
+package ical
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuration(t *testing.T) {
+	type args struct {
+		s string
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		want    time.Duration
+		wantStr string
+		wantErr bool
+	}{
+		{`valid`, args{"P15DT5H0M20S"}, 15*Day + 5*time.Hour + 20*time.Second, "P15DT5H0M20S", false},
+		{`negative`, args{"-P15DT5H0M20S"}, -(15*Day + 5*time.Hour + 20*time.Second), "-P15DT5H0M20S", false},
+		{`positive sign`, args{"+PT15M"}, 15 * time.Minute, "PT15M", false},
+		{`week`, args{"P7W"}, 7 * Week, "P7W", false},
+		{`days of week`, args{"P7D"}, Week, "P1W", false},
+		{`hour`, args{"PT1H"}, time.Hour, "PT1H", false},
+		{`minutes of hour`, args{"PT60M"}, time.Hour, "PT1H", false},
+		{`day`, args{"P1D"}, Day, "P1D", false},
+		{`hour and second`, args{"PT1H0M5S"}, time.Hour + 5*time.Second, "PT1H0M5S", false},
+		{`seconds`, args{"PT90S"}, 90 * time.Second, "PT1M30S", false},
+		{`zero`, args{"PT0S"}, 0, "PT0S", false},
+		{`empty`, args{""}, 0, "", true},
+		{`sign only`, args{"-"}, 0, "", true},
+		{`P required`, args{"T1H"}, 0, "", true},
+		{`no value`, args{"P"}, 0, "", true},
+		{`no time`, args{"P1DT"}, 0, "", true},
+		{`week with day`, args{"P1W2D"}, 0, "", true},
+		{`time without T`, args{"P1H"}, 0, "", true},
+		{`day in time`, args{"PT1D"}, 0, "", true},
+		{`gap of minute`, args{"PT1H5S"}, 0, "", true},
+		{`unit order`, args{"PT5M1H"}, 0, "", true},
+		{`duplicated unit`, args{"PT1H1H"}, 0, "", true},
+		{`unit required`, args{"PT1"}, 0, "", true},
+		{`digit required`, args{"PTH"}, 0, "", true},
+		{`fraction`, args{"PT1.5S"}, 0, "", true},
+		{`lower case`, args{"pt1h"}, 0, "", true},
+		{`overflow`, args{"P99999999W"}, 0, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDuration(tt.args.s)
+			require.Truef(t, (err != nil) == tt.wantErr, `parseDuration() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+			require.Equal(t, tt.want, got.Duration)
+			require.Equal(t, tt.wantStr, got.String())
+		})
+	}
+}
+
+func TestParsePeriod(t *testing.T) {
+	type args struct {
+		value string
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		want    Period
+		wantErr bool
+	}{
+		{`explicit`, args{"19970101T180000Z/19970102T070000Z"}, Period{
+			Start: DateTime{Time: time.Date(1997, 1, 1, 18, 0, 0, 0, time.UTC)},
+			End:   DateTime{Time: time.Date(1997, 1, 2, 7, 0, 0, 0, time.UTC)},
+		}, false},
+		{`start with duration`, args{"19970101T180000Z/PT5H30M"}, Period{
+			Start:    DateTime{Time: time.Date(1997, 1, 1, 18, 0, 0, 0, time.UTC)},
+			Duration: Duration{5*time.Hour + 30*time.Minute},
+		}, false},
+		{`no end`, args{"19970101T180000Z"}, Period{}, true},
+		{`date`, args{"19970101/19970102"}, Period{}, true},
+		{`invalid end`, args{"19970101T180000Z/tomorrow"}, Period{}, true},
+		{`end before start`, args{"19970101T180000Z/19970101T170000Z"}, Period{}, true},
+		{`end at start`, args{"19970101T180000Z/19970101T180000Z"}, Period{}, true},
+		{`negative duration`, args{"19970101T180000Z/-PT5H"}, Period{}, true},
+		{`zero duration`, args{"19970101T180000Z/PT0S"}, Period{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePeriod(tt.args.value, nil, nil)
+			require.Truef(t, (err != nil) == tt.wantErr, `parsePeriod() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.args.value, got.String())
+		})
+	}
+}
+
+func TestTime(t *testing.T) {
+	type args struct {
+		s string
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		want    Time
+		wantStr string
+		wantErr bool
+	}{
+		{`utc`, args{"230000Z"}, Time{Time: time.Date(0, 1, 1, 23, 0, 0, 0, time.UTC)}, "230000Z", false},
+		{`floating`, args{"230000"}, Time{Time: time.Date(0, 1, 1, 23, 0, 0, 0, time.Local)}, "230000", false},
+		{`leap second`, args{"235960Z"}, Time{Time: time.Date(0, 1, 2, 0, 0, 0, 0, time.UTC)}, "000000Z", false},
+		{`invalid hour`, args{"240000"}, Time{}, "", true},
+		{`invalid minute`, args{"236000"}, Time{}, "", true},
+		{`invalid second`, args{"235961"}, Time{}, "", true},
+		{`short`, args{"2300"}, Time{}, "", true},
+		{`extended`, args{"23:00:00"}, Time{}, "", true},
+		{`sign`, args{"+23000"}, Time{}, "", true},
+		{`empty`, args{""}, Time{}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTime(tt.args.s)
+			require.Truef(t, (err != nil) == tt.wantErr, `parseTime() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+			require.Equalf(t, tt.want, got, "%s %s", tt.want.String(), got.Time.String())
+			require.Equal(t, tt.wantStr, got.String())
+		})
+	}
+}
+
+func TestUTCOffset(t *testing.T) {
+	type args struct {
+		s string
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		want    time.Duration
+		wantErr bool
+	}{
+		{`valid`, args{"+0900"}, 9 * time.Hour, false},
+		{`valid negative`, args{"-0430"}, -4*time.Hour - 30*time.Minute, false},
+		{`valid seconds`, args{"+053028"}, 5*time.Hour + 30*time.Minute + 28*time.Second, false},
+		{`valid zero`, args{"+0000"}, 0, false},
+		{`negative zero`, args{"-0000"}, 0, true},
+		{`sign required`, args{"0900"}, 0, true},
+		{`invalid hour`, args{"+2400"}, 0, true},
+		{`invalid minute`, args{"+0960"}, 0, true},
+		{`invalid second`, args{"+090060"}, 0, true},
+		{`not digit`, args{"+09-1"}, 0, true},
+		{`empty`, args{""}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseUTCOffset(tt.args.s)
+			require.Truef(t, (err != nil) == tt.wantErr, `parseUTCOffset() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			require.Equal(t, tt.want, got.Duration)
+			require.Equal(t, tt.args.s, got.String())
+		})
+	}
+}
+
+func TestScalarValue(t *testing.T) {
+	type args struct {
+		typ   string
+		value string
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		want    any
+		wantErr bool
+	}{
+		{`boolean`, args{typeBoolean, "TRUE"}, true, false},
+		{`boolean lower case`, args{typeBoolean, "false"}, false, false},
+		{`boolean number`, args{typeBoolean, "1"}, nil, true},
+		{`boolean short`, args{typeBoolean, "T"}, nil, true},
+		{`float`, args{typeFloat, "1000000.0000001"}, 1000000.0000001, false},
+		{`float negative`, args{typeFloat, "-1.333"}, -1.333, false},
+		{`float integer`, args{typeFloat, "+42"}, 42.0, false},
+		{`float exponent`, args{typeFloat, "1e5"}, nil, true},
+		{`float no fraction`, args{typeFloat, "1."}, nil, true},
+		{`float no integer`, args{typeFloat, ".5"}, nil, true},
+		{`float inf`, args{typeFloat, "Inf"}, nil, true},
+		{`float empty`, args{typeFloat, ""}, nil, true},
+		{`integer`, args{typeInteger, "1234567890"}, 1234567890, false},
+		{`integer negative`, args{typeInteger, "-1234567890"}, -1234567890, false},
+		{`integer positive`, args{typeInteger, "+1234567890"}, 1234567890, false},
+		{`integer max`, args{typeInteger, "2147483647"}, math.MaxInt32, false},
+		{`integer min`, args{typeInteger, "-2147483648"}, math.MinInt32, false},
+		{`integer overflow`, args{typeInteger, "2147483648"}, nil, true},
+		{`integer float`, args{typeInteger, "1.0"}, nil, true},
+		{`integer underscore`, args{typeInteger, "1_000"}, nil, true},
+		{`integer sign only`, args{typeInteger, "-"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got any
+			var err error
+			switch tt.args.typ {
+			case typeBoolean:
+				got, err = parseBoolean(tt.args.value)
+			case typeFloat:
+				got, err = parseFloat(tt.args.value)
+			case typeInteger:
+				got, err = parseInteger(tt.args.value)
+			}
+			require.Truef(t, (err != nil) == tt.wantErr, `parse() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestValueRoundTrip checks parse(print(x)) == x for random values
+func TestValueRoundTrip(t *testing.T) {
+	config := &quick.Config{MaxCount: 1000}
+	seconds := func(r *rand.Rand, max int64) int64 { return r.Int63n(2*max+1) - max }
+
+	t.Run(`duration`, func(t *testing.T) {
+		require.NoError(t, quick.Check(func(d Duration) bool {
+			got, err := parseDuration(d.String())
+			return err == nil && got == d
+		}, &quick.Config{MaxCount: config.MaxCount, Values: func(values []reflect.Value, r *rand.Rand) {
+			// seconds within 100 years in various scales
+			max := []int64{60, 3600, 86400 * 10, 86400 * 365 * 100}[r.Intn(4)]
+			d := time.Duration(seconds(r, max)) * time.Second
+			if r.Intn(4) == 0 {
+				d = d.Truncate(Week)
+			}
+			values[0] = reflect.ValueOf(Duration{d})
+		}}))
+	})
+
+	t.Run(`utc-offset`, func(t *testing.T) {
+		require.NoError(t, quick.Check(func(o UTCOffset) bool {
+			got, err := parseUTCOffset(o.String())
+			return err == nil && got == o
+		}, &quick.Config{MaxCount: config.MaxCount, Values: func(values []reflect.Value, r *rand.Rand) {
+			values[0] = reflect.ValueOf(UTCOffset{time.Duration(seconds(r, 24*3600-1)) * time.Second})
+		}}))
+	})
+
+	t.Run(`time`, func(t *testing.T) {
+		require.NoError(t, quick.Check(func(tm Time) bool {
+			got, err := parseTime(tm.String())
+			return err == nil && got == tm
+		}, &quick.Config{MaxCount: config.MaxCount, Values: func(values []reflect.Value, r *rand.Rand) {
+			loc := []*time.Location{time.UTC, time.Local}[r.Intn(2)]
+			values[0] = reflect.ValueOf(Time{time.Date(0, 1, 1, r.Intn(24), r.Intn(60), r.Intn(60), 0, loc)})
+		}}))
+	})
+
+	t.Run(`period`, func(t *testing.T) {
+		require.NoError(t, quick.Check(func(p Period) bool {
+			got, err := parsePeriod(p.String(), nil, nil)
+			return err == nil && reflect.DeepEqual(got, p)
+		}, &quick.Config{MaxCount: config.MaxCount, Values: func(values []reflect.Value, r *rand.Rand) {
+			start := time.Unix(r.Int63n(4102444800), 0).UTC()
+			d := time.Duration(r.Int63n(86400*365)+1) * time.Second
+			p := Period{Start: DateTime{Time: start}}
+			if r.Intn(2) == 0 {
+				p.End = DateTime{Time: start.Add(d)}
+			} else {
+				p.Duration = Duration{d}
+			}
+			values[0] = reflect.ValueOf(p)
+		}}))
+	})
+
+	t.Run(`float`, func(t *testing.T) {
+		require.NoError(t, quick.Check(func(f float64) bool {
+			got, err := parseFloat(formatFloat(f))
+			return err == nil && got == f
+		}, config))
+	})
+
+	t.Run(`integer`, func(t *testing.T) {
+		require.NoError(t, quick.Check(func(i int32) bool {
+			got, err := parseInteger(strconv.Itoa(int(i)))
+			return err == nil && got == int(i)
+		}, config))
+	})
+
+	t.Run(`boolean`, func(t *testing.T) {
+		require.NoError(t, quick.Check(func(b bool) bool {
+			got, err := parseBoolean(formatBoolean(b))
+			return err == nil && got == b
+		}, config))
+	})
+}
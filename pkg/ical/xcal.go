@@ -0,0 +1,410 @@
+package ical
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// xCal(RFC 6321) XML format of iCalendar
+//
+//	<icalendar xmlns="urn:ietf:params:xml:ns:icalendar-2.0">
+//	  <vcalendar>
+//	    <properties>
+//	      <version><text>2.0</text></version>
+//	    </properties>
+//	    <components>
+//	      <vevent>
+//	        <properties>
+//	          <dtstart>
+//	            <parameters><tzid><text>Asia/Seoul</text></tzid></parameters>
+//	            <date-time>2023-06-01T09:00:00</date-time>
+//	          </dtstart>
+//	          <rrule><recur><freq>WEEKLY</freq><byday>MO</byday><byday>WE</byday></recur></rrule>
+//	        </properties>
+//	      </vevent>
+//	    </components>
+//	  </vcalendar>
+//	</icalendar>
+//
+// VCalendar is encoded in icalendar; VEvent, VTodo and VJournal are encoded as the component element
+
+const xcalNamespace = "urn:ietf:params:xml:ns:icalendar-2.0"
+
+// xcalNode element of xCal
+type xcalNode struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr  `xml:",any,attr"`
+	Nodes   []*xcalNode `xml:",any"`
+	Text    string      `xml:",chardata"`
+}
+
+func newXCalNode(name string, nodes ...*xcalNode) *xcalNode {
+	return &xcalNode{XMLName: xml.Name{Local: name}, Nodes: nodes}
+}
+
+func newXCalText(name, text string) *xcalNode {
+	return &xcalNode{XMLName: xml.Name{Local: name}, Text: text}
+}
+
+// xcalParamTypes value type of parameters; text if not listed
+var xcalParamTypes = map[string]string{
+	"ALTREP":         typeURI,
+	"DELEGATED-FROM": typeCalAddress,
+	"DELEGATED-TO":   typeCalAddress,
+	"DIR":            typeURI,
+	"MEMBER":         typeCalAddress,
+	"RSVP":           typeBoolean,
+	"SENT-BY":        typeCalAddress,
+}
+
+// xcalStructuredElements elements of components of structured properties
+var xcalStructuredElements = map[string][]string{
+	"GEO":            {"latitude", "longitude"},
+	"REQUEST-STATUS": {"code", "description", "data"},
+}
+
+type xcalEncoder struct {
+	w io.Writer
+}
+
+var _ Encoder = (*xcalEncoder)(nil)
+
+func NewXCalEncoder(w io.Writer) Encoder {
+	return &xcalEncoder{w: w}
+}
+
+func (enc *xcalEncoder) Encode(v any) error {
+	c, err := encodeRawComponent(v)
+	if err != nil {
+		return err
+	}
+
+	root, err := xcalComponent(c)
+	if err != nil {
+		return err
+	}
+	if c.name == "VCALENDAR" {
+		root = newXCalNode("icalendar", root)
+	}
+	root.Attrs = []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: xcalNamespace}}
+
+	if _, err := io.WriteString(enc.w, xml.Header); err != nil {
+		return err
+	}
+	return xml.NewEncoder(enc.w).Encode(root)
+}
+
+func xcalComponent(c *rawComponent) (*xcalNode, error) {
+	node := newXCalNode(strings.ToLower(c.name))
+
+	if len(c.properties) > 0 {
+		properties := newXCalNode("properties")
+		for _, cl := range c.properties {
+			prop, err := xcalProperty(cl)
+			if err != nil {
+				return nil, errors.Wrapf(err, "%s", cl.name)
+			}
+			properties.Nodes = append(properties.Nodes, prop)
+		}
+		node.Nodes = append(node.Nodes, properties)
+	}
+
+	if len(c.components) > 0 {
+		components := newXCalNode("components")
+		for _, sub := range c.components {
+			component, err := xcalComponent(sub)
+			if err != nil {
+				return nil, err
+			}
+			components.Nodes = append(components.Nodes, component)
+		}
+		node.Nodes = append(node.Nodes, components)
+	}
+
+	return node, nil
+}
+
+// xcalProperty returns <name><parameters>...</parameters><type>value</type>...</name>
+func xcalProperty(cl *contentLine) (*xcalNode, error) {
+	typ := valueType(cl)
+	prop := newXCalNode(strings.ToLower(cl.name))
+
+	if len(cl.params) > 1 || len(cl.params) == 1 && cl.params["VALUE"] == nil {
+		parameters := newXCalNode("parameters")
+		for _, name := range sortedParams(cl.params) {
+			if name == "VALUE" {
+				continue
+			}
+
+			paramType := typeText
+			if t, ok := xcalParamTypes[name]; ok {
+				paramType = t
+			}
+			param := newXCalNode(strings.ToLower(name))
+			for _, v := range cl.params[name] {
+				if paramType == typeBoolean {
+					v = strings.ToLower(v)
+				}
+				param.Nodes = append(param.Nodes, newXCalText(paramType, v))
+			}
+			parameters.Nodes = append(parameters.Nodes, param)
+		}
+		prop.Nodes = append(prop.Nodes, parameters)
+	}
+
+	switch {
+	case typ == typeRecur:
+		parts, err := splitRecur(cl.value)
+		if err != nil {
+			return nil, err
+		}
+
+		recur := newXCalNode(typeRecur)
+		for _, part := range parts {
+			for _, v := range part.values {
+				recur.Nodes = append(recur.Nodes, newXCalText(part.key, v))
+			}
+		}
+		prop.Nodes = append(prop.Nodes, recur)
+		return prop, nil
+
+	case structuredProperties[cl.name]:
+		elements := xcalStructuredElements[cl.name]
+		components := splitUnescaped(cl.value, ';')
+		if len(components) > len(elements) {
+			return nil, fmt.Errorf("invalid value: %s", cl.value)
+		}
+		for i, v := range components {
+			if typ == typeText {
+				v = unescape(v)
+			}
+			prop.Nodes = append(prop.Nodes, newXCalText(elements[i], v))
+		}
+		return prop, nil
+	}
+
+	for _, v := range splitValues(cl) {
+		value, err := xcalValue(typ, v)
+		if err != nil {
+			return nil, err
+		}
+		prop.Nodes = append(prop.Nodes, value)
+	}
+	return prop, nil
+}
+
+// xcalValue returns value element of the iCalendar value
+func xcalValue(typ, v string) (*xcalNode, error) {
+	switch typ {
+	case typeBoolean:
+		return newXCalText(typ, strings.ToLower(v)), nil
+	case typePeriod:
+		start, end, ok := strings.Cut(v, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid period: %s", v)
+		}
+
+		value, err := toExtended(typeDateTime, start)
+		if err != nil {
+			return nil, err
+		}
+		period := newXCalNode(typ, newXCalText("start", value))
+
+		if strings.HasPrefix(strings.TrimLeft(end, "+-"), "P") {
+			period.Nodes = append(period.Nodes, newXCalText("duration", end))
+			return period, nil
+		}
+
+		if value, err = toExtended(typeDateTime, end); err != nil {
+			return nil, err
+		}
+		period.Nodes = append(period.Nodes, newXCalText("end", value))
+		return period, nil
+	}
+
+	value, err := toExtended(typ, v)
+	if err != nil {
+		return nil, err
+	}
+	return newXCalText(typ, value), nil
+}
+
+// sortedParams returns parameter names in order
+func sortedParams(params Params) []string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type xcalDecoder struct {
+	r    io.Reader
+	opts []DecodeOption
+}
+
+var _ Decoder = (*xcalDecoder)(nil)
+
+// NewXCalDecoder returns decoder of xCal; options are applied as the text decoder
+func NewXCalDecoder(r io.Reader, opts ...DecodeOption) Decoder {
+	return &xcalDecoder{r: r, opts: opts}
+}
+
+func (d *xcalDecoder) Decode(v any) error {
+	root := new(xcalNode)
+	if err := xml.NewDecoder(d.r).Decode(root); err != nil {
+		return errors.Wrap(err, "invalid xcal")
+	}
+
+	if root.XMLName.Local == "icalendar" {
+		if len(root.Nodes) != 1 {
+			return errors.New("invalid xcal: icalendar must have a vcalendar")
+		}
+		root = root.Nodes[0]
+	}
+
+	c, err := parseXCalComponent(root)
+	if err != nil {
+		return errors.Wrap(err, "invalid xcal")
+	}
+
+	return decodeRawComponent(c, v, d.opts)
+}
+
+// parseXCalComponent parse <name><properties>...</properties><components>...</components></name>
+func parseXCalComponent(node *xcalNode) (*rawComponent, error) {
+	c := &rawComponent{name: strings.ToUpper(node.XMLName.Local)}
+	for _, child := range node.Nodes {
+		switch child.XMLName.Local {
+		case "properties":
+			for _, prop := range child.Nodes {
+				cl, err := parseXCalProperty(prop)
+				if err != nil {
+					return nil, errors.Wrapf(err, "%s: %s", node.XMLName.Local, prop.XMLName.Local)
+				}
+				c.properties = append(c.properties, cl)
+			}
+		case "components":
+			for _, component := range child.Nodes {
+				sub, err := parseXCalComponent(component)
+				if err != nil {
+					return nil, err
+				}
+				c.components = append(c.components, sub)
+			}
+		default:
+			return nil, fmt.Errorf("%s: unexpected element %s", node.XMLName.Local, child.XMLName.Local)
+		}
+	}
+
+	return c, nil
+}
+
+// parseXCalProperty parse <name><parameters>...</parameters><type>value</type>...</name>
+func parseXCalProperty(node *xcalNode) (*contentLine, error) {
+	cl := &contentLine{name: strings.ToUpper(node.XMLName.Local), params: Params{}}
+
+	values := []*xcalNode{}
+	for _, child := range node.Nodes {
+		if child.XMLName.Local != "parameters" {
+			values = append(values, child)
+			continue
+		}
+
+		for _, param := range child.Nodes {
+			name := strings.ToUpper(param.XMLName.Local)
+			for _, v := range param.Nodes {
+				text := v.Text
+				if v.XMLName.Local == typeBoolean {
+					text = strings.ToUpper(strings.TrimSpace(text))
+				}
+				cl.params[name] = append(cl.params[name], text)
+			}
+		}
+	}
+	if len(values) == 0 {
+		return nil, errors.New("value required")
+	}
+
+	if elements, ok := xcalStructuredElements[cl.name]; ok {
+		typ := defaultValueTypes[cl.name]
+		components := make([]string, 0, len(values))
+		for i, v := range values {
+			if i >= len(elements) || v.XMLName.Local != elements[i] {
+				return nil, fmt.Errorf("unexpected element %s", v.XMLName.Local)
+			}
+
+			text := strings.TrimSpace(v.Text)
+			if typ == typeText {
+				text = escape(v.Text)
+			}
+			components = append(components, text)
+		}
+		cl.value = strings.Join(components, ";")
+		return cl, nil
+	}
+
+	typ := values[0].XMLName.Local
+	setValueType(cl, typ)
+
+	texts := make([]string, len(values))
+	for i, v := range values {
+		if v.XMLName.Local != typ {
+			return nil, fmt.Errorf("values must be the same type: %s, %s", typ, v.XMLName.Local)
+		}
+
+		text, err := parseXCalValue(typ, v)
+		if err != nil {
+			return nil, err
+		}
+		texts[i] = text
+	}
+	cl.value = strings.Join(texts, ",")
+
+	return cl, nil
+}
+
+// parseXCalValue returns iCalendar value of the value element
+func parseXCalValue(typ string, node *xcalNode) (string, error) {
+	switch typ {
+	case typeRecur:
+		parts := []recurPart{}
+		for _, part := range node.Nodes {
+			key := strings.ToLower(part.XMLName.Local)
+			if len(parts) > 0 && parts[len(parts)-1].key == key {
+				parts[len(parts)-1].values = append(parts[len(parts)-1].values, strings.TrimSpace(part.Text))
+				continue
+			}
+			parts = append(parts, recurPart{key: key, values: []string{strings.TrimSpace(part.Text)}})
+		}
+		return joinRecur(parts)
+
+	case typePeriod:
+		var start, end string
+		for _, v := range node.Nodes {
+			switch v.XMLName.Local {
+			case "start":
+				start = strings.TrimSpace(v.Text)
+			case "end", "duration":
+				end = strings.TrimSpace(v.Text)
+			default:
+				return "", fmt.Errorf("unexpected element %s", v.XMLName.Local)
+			}
+		}
+		return fromExtended(typ, start+"/"+end)
+
+	case typeBoolean:
+		return strings.ToUpper(strings.TrimSpace(node.Text)), nil
+
+	case typeText, typeUnknown:
+		return fromExtended(typ, node.Text)
+	}
+
+	return fromExtended(typ, strings.TrimSpace(node.Text))
+}
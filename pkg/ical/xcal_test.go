@@ -0,0 +1,142 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestXCalEncode(t *testing.T) {
+	evt := jcalTestEvent(t)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, NewXCalEncoder(buf).Encode(evt))
+
+	got := buf.String()
+	require.True(t, strings.HasPrefix(got, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+`<vevent xmlns="urn:ietf:params:xml:ns:icalendar-2.0"><properties>`), got)
+	require.Contains(t, got, `<dtstart><parameters><tzid><text>Asia/Seoul</text></tzid></parameters><date-time>2023-06-01T09:00:00</date-time></dtstart>`)
+	require.Contains(t, got, `<dtstamp><date-time>2023-06-01T00:00:00Z</date-time></dtstamp>`)
+	require.Contains(t, got, `<summary><parameters><language><text>ko</text></language></parameters><text>Weekly; sync, notes</text></summary>`)
+	require.Contains(t, got, `<geo><latitude>37.386013</latitude><longitude>-122.082932</longitude></geo>`)
+	require.Contains(t, got, `<categories><text>WORK</text><text>MEETING</text></categories>`)
+	require.Contains(t, got, `<attendee><parameters><cn><text>John</text></cn><role><text>CHAIR</text></role><rsvp><boolean>true</boolean></rsvp></parameters><cal-address>mailto:js@example.com</cal-address></attendee>`)
+	require.Contains(t, got, `<rrule><recur><freq>WEEKLY</freq><until>2023-12-31T00:00:00Z</until><byday>MO</byday><byday>WE</byday></recur></rrule>`)
+	require.Contains(t, got, `<rdate><period><start>2023-06-02T00:00:00Z</start><end>2023-06-02T01:00:00Z</end></period></rdate>`)
+	require.Contains(t, got, `<x-alt-desc><parameters><fmttype><text>text/html</text></fmttype></parameters><unknown>&lt;p&gt;Agenda&lt;/p&gt;</unknown></x-alt-desc>`)
+
+	decoded := new(VEvent)
+	require.NoError(t, NewXCalDecoder(buf).Decode(decoded))
+	require.Equal(t, evt, decoded)
+}
+
+func TestXCalDecode(t *testing.T) {
+	type args struct {
+		data string
+		opts []DecodeOption
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+		want    *VCalendar
+	}{
+		{`rfc6321 example`, args{`<?xml version="1.0" encoding="utf-8"?>
+<icalendar xmlns="urn:ietf:params:xml:ns:icalendar-2.0">
+  <vcalendar>
+    <properties>
+      <calscale><text>GREGORIAN</text></calscale>
+      <prodid><text>-//Example Inc.//Example Calendar//EN</text></prodid>
+      <version><text>2.0</text></version>
+    </properties>
+    <components>
+      <vevent>
+        <properties>
+          <dtstamp><date-time>2008-02-05T19:12:24Z</date-time></dtstamp>
+          <dtstart><date>2008-10-06</date></dtstart>
+          <summary><text>Planning meeting</text></summary>
+          <uid><text>4088E990AD89CB3DBB484909</text></uid>
+        </properties>
+      </vevent>
+    </components>
+  </vcalendar>
+</icalendar>`, nil}, false, &VCalendar{
+			ProdID:   "-//Example Inc.//Example Calendar//EN",
+			Version:  "2.0",
+			CalScale: "GREGORIAN",
+			Events: []*VEvent{{
+				UID:     "4088E990AD89CB3DBB484909",
+				DtStamp: DateTime{Time: time.Date(2008, 2, 5, 19, 12, 24, 0, time.UTC)},
+				DtStart: DateTime{Time: time.Date(2008, 10, 6, 0, 0, 0, 0, time.UTC), isDate: true},
+				Summary: Text{Value: "Planning meeting"},
+			}},
+		}},
+		{`timezone`, args{`<icalendar xmlns="urn:ietf:params:xml:ns:icalendar-2.0"><vcalendar>
+  <properties><prodid><text>-//a//b//EN</text></prodid><version><text>2.0</text></version></properties>
+  <components>
+    <vtimezone>
+      <properties><tzid><text>America/New_York</text></tzid></properties>
+      <components>
+        <standard><properties>
+          <dtstart><date-time>2007-11-04T02:00:00</date-time></dtstart>
+          <rrule><recur><freq>YEARLY</freq><bymonth>11</bymonth><byday>1SU</byday></recur></rrule>
+          <tzoffsetfrom><utc-offset>-04:00</utc-offset></tzoffsetfrom>
+          <tzoffsetto><utc-offset>-05:00</utc-offset></tzoffsetto>
+        </properties></standard>
+      </components>
+    </vtimezone>
+    <vevent><properties>
+      <uid><text>1</text></uid>
+      <dtstamp><date-time>2008-02-05T19:12:24Z</date-time></dtstamp>
+      <dtstart><parameters><tzid><text>America/New_York</text></tzid></parameters><date-time>2008-10-06T10:00:00</date-time></dtstart>
+      <attendee><parameters><rsvp><boolean>true</boolean></rsvp></parameters><cal-address>mailto:js@example.com</cal-address></attendee>
+    </properties></vevent>
+  </components>
+</vcalendar></icalendar>`, nil}, false, nil},
+		{`strict`, args{`<icalendar><vcalendar><properties><prodid><text>-//a//b//EN</text></prodid><version><text>2.0</text></version></properties>
+<components><vevent><properties><summary><text>a</text></summary></properties></vevent></components></vcalendar></icalendar>`,
+			[]DecodeOption{Strict()}}, true, nil},
+		{`not xml`, args{`BEGIN:VCALENDAR`, nil}, true, nil},
+		{`not vcalendar`, args{`<vevent/>`, nil}, true, nil},
+		{`empty icalendar`, args{`<icalendar/>`, nil}, true, nil},
+		{`unexpected element`, args{`<icalendar><vcalendar><foo/></vcalendar></icalendar>`, nil}, true, nil},
+		{`no value`, args{`<icalendar><vcalendar><properties><prodid/></properties></vcalendar></icalendar>`, nil}, true, nil},
+		{`mixed types`, args{`<icalendar><vcalendar><properties><prodid><text>a</text><uri>b</uri></prodid></properties></vcalendar></icalendar>`, nil}, true, nil},
+		{`invalid date`, args{`<icalendar><vcalendar><components><vevent><properties><dtstart><date>20081006</date></dtstart></properties></vevent></components></vcalendar></icalendar>`, nil}, true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := new(VCalendar)
+			err := NewXCalDecoder(strings.NewReader(tt.args.data), tt.args.opts...).Decode(got)
+			require.Truef(t, (err != nil) == tt.wantErr, `Decode() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			if tt.want != nil {
+				require.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestXCalCalendar(t *testing.T) {
+	cal := &VCalendar{ProdID: DefaultProdID, Version: DefaultVersion, Events: []*VEvent{jcalTestEvent(t)}}
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, NewXCalEncoder(buf).Encode(cal))
+	require.Contains(t, buf.String(), `<icalendar xmlns="urn:ietf:params:xml:ns:icalendar-2.0"><vcalendar><properties>`)
+
+	decoded := new(VCalendar)
+	require.NoError(t, NewXCalDecoder(bytes.NewReader(buf.Bytes())).Decode(decoded))
+
+	jcal := new(bytes.Buffer)
+	require.NoError(t, NewJCalEncoder(jcal).Encode(decoded))
+
+	fromJCal := new(VCalendar)
+	require.NoError(t, NewJCalDecoder(jcal).Decode(fromJCal))
+	require.Equal(t, decoded, fromJCal)
+	require.Equal(t, cal.Events[0].Summary, fromJCal.Events[0].Summary)
+}
@@ -0,0 +1,324 @@
+package qrcode
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/whitekid/goxp/validate"
+	"golang.org/x/crypto/sha3"
+)
+
+// BitcoinPayment BIP21 payment request
+// https://github.com/bitcoin/bips/blob/master/bip-0021.mediawiki
+type BitcoinPayment struct {
+	Address string `validate:"required,max=100"`
+	Amount  string `validate:"max=20"` // in BTC
+	Label   string `validate:"max=100"`
+	Message string `validate:"max=200"`
+}
+
+var reBitcoinAmount = regexp.MustCompile(`^[0-9]+(\.[0-9]{1,8})?$`)
+
+// Bitcoin generate bitcoin: payment QRCode
+func Bitcoin(p *BitcoinPayment) (*QR, error) {
+	if err := validate.Struct(p); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateBitcoinAddress(p.Address); err != nil {
+		return nil, err
+	}
+
+	if p.Amount != "" && !reBitcoinAmount.MatchString(p.Amount) {
+		return nil, fmt.Errorf("invalid amount: %s", p.Amount)
+	}
+
+	params := []string{}
+	for _, param := range [][2]string{{"amount", p.Amount}, {"label", p.Label}, {"message", p.Message}} {
+		if param[1] == "" {
+			continue
+		}
+		params = append(params, param[0]+"="+uriEscape(param[1]))
+	}
+
+	uri := "bitcoin:" + p.Address
+	if len(params) > 0 {
+		uri += "?" + strings.Join(params, "&")
+	}
+
+	return Text(uri)
+}
+
+// uriEscape percent-encode as RFC 3986; space as %20 not +
+func uriEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// ValidateBitcoinAddress validate checksum of legacy(Base58Check) or segwit(Bech32, Bech32m) address
+func ValidateBitcoinAddress(addr string) error {
+	lower := strings.ToLower(addr)
+	for _, hrp := range []string{"bc1", "tb1", "bcrt1"} {
+		if strings.HasPrefix(lower, hrp) {
+			return validateSegwitAddress(addr)
+		}
+	}
+
+	return validateBase58Address(addr)
+}
+
+// legacy address version bytes: P2PKH, P2SH for mainnet and testnet
+var base58Versions = []byte{0x00, 0x05, 0x6f, 0xc4}
+
+func validateBase58Address(addr string) error {
+	b, err := base58Decode(addr)
+	if err != nil {
+		return errors.Wrap(err, "invalid address")
+	}
+
+	if len(b) != 25 {
+		return fmt.Errorf("invalid address: length %d", len(b))
+	}
+
+	if !bytes.Contains(base58Versions, b[:1]) {
+		return fmt.Errorf("invalid address: unknown version %d", b[0])
+	}
+
+	h := sha256.Sum256(b[:21])
+	h = sha256.Sum256(h[:])
+	if !bytes.Equal(h[:4], b[21:]) {
+		return errors.New("invalid address: checksum mismatch")
+	}
+
+	return nil
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+func base58Decode(s string) ([]byte, error) {
+	if s == "" {
+		return nil, errors.New("empty")
+	}
+
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+
+	// base256 value, big endian
+	b := []byte{}
+	for i := zeros; i < len(s); i++ {
+		carry := strings.IndexByte(base58Alphabet, s[i])
+		if carry == -1 {
+			return nil, fmt.Errorf("invalid character %q", s[i])
+		}
+
+		for j := len(b) - 1; j >= 0; j-- {
+			carry += int(b[j]) * 58
+			b[j] = byte(carry)
+			carry >>= 8
+		}
+		for ; carry > 0; carry >>= 8 {
+			b = append([]byte{byte(carry)}, b...)
+		}
+	}
+
+	return append(make([]byte, zeros), b...), nil
+}
+
+// Bech32 checksum constants
+// https://github.com/bitcoin/bips/blob/master/bip-0173.mediawiki
+// https://github.com/bitcoin/bips/blob/master/bip-0350.mediawiki
+const (
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+
+	bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+)
+
+func validateSegwitAddress(addr string) error {
+	if strings.ToLower(addr) != addr && strings.ToUpper(addr) != addr {
+		return errors.New("invalid address: mixed case")
+	}
+	addr = strings.ToLower(addr)
+
+	if len(addr) > 90 {
+		return errors.New("invalid address: too long")
+	}
+
+	// data part is witness version and checksum at least
+	sep := strings.LastIndexByte(addr, '1')
+	if sep < 1 || sep+8 > len(addr) {
+		return errors.New("invalid address: separator")
+	}
+
+	hrp := addr[:sep]
+	if hrp != "bc" && hrp != "tb" && hrp != "bcrt" {
+		return fmt.Errorf("invalid address: human readable part %q", hrp)
+	}
+
+	data := make([]byte, 0, len(addr)-sep-1)
+	for _, c := range addr[sep+1:] {
+		v := strings.IndexRune(bech32Charset, c)
+		if v == -1 {
+			return fmt.Errorf("invalid address: invalid character %q", c)
+		}
+		data = append(data, byte(v))
+	}
+
+	checksum := bech32Polymod(append(bech32HRPExpand(hrp), data...))
+	version := data[0]
+	switch {
+	case version == 0 && checksum != bech32Const:
+		return errors.New("invalid address: bech32 checksum mismatch")
+	case version > 0 && checksum != bech32mConst:
+		return errors.New("invalid address: bech32m checksum mismatch")
+	case version > 16:
+		return fmt.Errorf("invalid address: witness version %d", version)
+	}
+
+	program, err := convertBits(data[1:len(data)-6], 5, 8, false)
+	if err != nil {
+		return errors.Wrap(err, "invalid address")
+	}
+
+	if len(program) < 2 || len(program) > 40 {
+		return fmt.Errorf("invalid address: witness program length %d", len(program))
+	}
+
+	if version == 0 && len(program) != 20 && len(program) != 32 {
+		return fmt.Errorf("invalid address: v0 witness program length %d", len(program))
+	}
+
+	return nil
+}
+
+func bech32Polymod(values []byte) uint32 {
+	gen := [...]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		b := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (b>>i)&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HRPExpand(hrp string) []byte {
+	r := make([]byte, 0, len(hrp)*2+1)
+	for _, c := range []byte(hrp) {
+		r = append(r, c>>5)
+	}
+	r = append(r, 0)
+	for _, c := range []byte(hrp) {
+		r = append(r, c&31)
+	}
+	return r
+}
+
+func convertBits(data []byte, from, to uint, pad bool) ([]byte, error) {
+	acc, bits := uint32(0), uint(0)
+	maxv := uint32(1)<<to - 1
+	r := []byte{}
+	for _, v := range data {
+		acc = acc<<from | uint32(v)
+		bits += from
+		for bits >= to {
+			bits -= to
+			r = append(r, byte(acc>>bits&maxv))
+		}
+	}
+
+	if pad {
+		if bits > 0 {
+			r = append(r, byte(acc<<(to-bits)&maxv))
+		}
+	} else if bits >= from || acc<<(to-bits)&maxv != 0 {
+		return nil, errors.New("invalid padding")
+	}
+
+	return r, nil
+}
+
+// EthereumPayment EIP-681 payment request
+// https://eips.ethereum.org/EIPS/eip-681
+type EthereumPayment struct {
+	Address string `validate:"required,max=42"`
+	ChainID uint64
+	Value   string `validate:"max=80"` // in wei
+}
+
+var reEthereumValue = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?([eE][0-9]+)?$`)
+
+// Ethereum generate ethereum: payment QRCode
+func Ethereum(p *EthereumPayment) (*QR, error) {
+	if err := validate.Struct(p); err != nil {
+		return nil, err
+	}
+
+	addr, err := ChecksumEthereumAddress(p.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Value != "" && !reEthereumValue.MatchString(p.Value) {
+		return nil, fmt.Errorf("invalid value: %s", p.Value)
+	}
+
+	uri := "ethereum:" + addr
+	if p.ChainID != 0 {
+		uri += "@" + strconv.FormatUint(p.ChainID, 10)
+	}
+
+	if p.Value != "" {
+		uri += "?value=" + p.Value
+	}
+
+	return Text(uri)
+}
+
+// ChecksumEthereumAddress returns EIP-55 mixed-case checksum address
+// all lower or upper case address is accepted as is; mixed case address must have valid checksum
+// https://eips.ethereum.org/EIPS/eip-55
+func ChecksumEthereumAddress(addr string) (string, error) {
+	if !strings.HasPrefix(addr, "0x") || len(addr) != 42 {
+		return "", fmt.Errorf("invalid address: %s", addr)
+	}
+
+	hexAddr := addr[2:]
+	if _, err := hex.DecodeString(hexAddr); err != nil {
+		return "", fmt.Errorf("invalid address: %s", addr)
+	}
+
+	lower := strings.ToLower(hexAddr)
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(lower))
+	hash := h.Sum(nil)
+
+	checksummed := []byte(lower)
+	for i, c := range checksummed {
+		nibble := hash[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if c >= 'a' && nibble&0xf >= 8 {
+			checksummed[i] = c - 'a' + 'A'
+		}
+	}
+
+	if hexAddr != lower && hexAddr != strings.ToUpper(hexAddr) && hexAddr != string(checksummed) {
+		return "", errors.New("invalid address: checksum mismatch")
+	}
+
+	return "0x" + string(checksummed), nil
+}
@@ -0,0 +1,155 @@
+package qrcode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateBitcoinAddress(t *testing.T) {
+	type args struct {
+		addr string
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{`p2pkh`, args{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"}, false},
+		{`p2sh`, args{"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy"}, false},
+		{`p2pkh checksum`, args{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN3"}, true},
+		{`base58 invalid char`, args{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN0"}, true},
+		{`p2wpkh`, args{"bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq"}, false},
+		{`p2wpkh upper`, args{"BC1QAR0SRRR7XFKVY5L643LYDNW9RE59GTZZWF5MDQ"}, false},
+		{`p2wpkh mixed case`, args{"bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdQ"}, true},
+		{`p2wpkh checksum`, args{"bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdp"}, true},
+		{`p2tr`, args{"bc1p5d7rjq7g6rdk2yhzks9smlaqtedr4dekq08ge8ztwac72sfr9rusxg3297"}, false},
+		{`p2tr with bech32 checksum`, args{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqh2y7hd"}, true},
+		{`testnet p2wsh`, args{"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7"}, false},
+		{`testnet checksum`, args{"tb1qw508d6qejxtdg4c0r7raxzhp8rv4ss8dqdjfvq"}, true},
+		{`testnet checksum only`, args{"tb1dclvmr"}, true},
+		{`regtest checksum only`, args{"bcrt1tyddyu"}, true},
+		{`unknown hrp`, args{"bc1a1pq208e"}, true},
+		{`empty`, args{""}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBitcoinAddress(tt.args.addr)
+			require.Truef(t, (err != nil) == tt.wantErr, `ValidateBitcoinAddress() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+		})
+	}
+}
+
+func TestBitcoin(t *testing.T) {
+	type args struct {
+		payment *BitcoinPayment
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+		want    string
+	}{
+		{`address only`, args{&BitcoinPayment{Address: "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"}}, false,
+			"bitcoin:1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"},
+		{`full`, args{&BitcoinPayment{
+			Address: "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq",
+			Amount:  "20.3",
+			Label:   "Luke-Jr",
+			Message: "Donation for project xyz & more",
+		}}, false, "bitcoin:bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq?amount=20.3&label=Luke-Jr&message=Donation%20for%20project%20xyz%20%26%20more"},
+		{`invalid amount`, args{&BitcoinPayment{Address: "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", Amount: "1.123456789"}}, true, ""},
+		{`negative amount`, args{&BitcoinPayment{Address: "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", Amount: "-1"}}, true, ""},
+		{`invalid address`, args{&BitcoinPayment{Address: "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN3"}}, true, ""},
+		{`missing address`, args{&BitcoinPayment{}}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qr, err := Bitcoin(tt.args.payment)
+			require.Truef(t, (err != nil) == tt.wantErr, `Bitcoin() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			require.Equal(t, tt.want, qr.Content)
+		})
+	}
+}
+
+func TestChecksumEthereumAddress(t *testing.T) {
+	// https://eips.ethereum.org/EIPS/eip-55#test-cases
+	for _, addr := range []string{
+		"0x52908400098527886E0F7030069857D2E4169EE7",
+		"0x8617E340B3D01FA5F11F306F4090FD50E238070D",
+		"0xde709f2102306220921060314715629080e2fb77",
+		"0x27b1fdb04752bbc536007a920d24acb045561c26",
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	} {
+		t.Run(addr, func(t *testing.T) {
+			got, err := ChecksumEthereumAddress(strings.ToLower(addr))
+			require.NoError(t, err)
+			require.Equal(t, strings.ToLower(addr), strings.ToLower(got))
+
+			got, err = ChecksumEthereumAddress(got)
+			require.NoError(t, err)
+
+			if strings.ToLower(addr) != addr && strings.ToUpper(addr[2:]) != addr[2:] {
+				require.Equal(t, addr, got)
+			}
+		})
+	}
+
+	type args struct {
+		addr string
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{`checksum mismatch`, args{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD"}, true},
+		{`missing prefix`, args{"5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"}, true},
+		{`short`, args{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA"}, true},
+		{`not hex`, args{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeg"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ChecksumEthereumAddress(tt.args.addr)
+			require.Truef(t, (err != nil) == tt.wantErr, `ChecksumEthereumAddress() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+		})
+	}
+}
+
+func TestEthereum(t *testing.T) {
+	type args struct {
+		payment *EthereumPayment
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+		want    string
+	}{
+		{`address only`, args{&EthereumPayment{Address: "0xfb6916095ca1df60bb79ce92ce3ea74c37c5d359"}}, false,
+			"ethereum:0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359"},
+		{`full`, args{&EthereumPayment{Address: "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", ChainID: 1, Value: "2.014e18"}}, false,
+			"ethereum:0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359@1?value=2.014e18"},
+		{`invalid value`, args{&EthereumPayment{Address: "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", Value: "1 ETH"}}, true, ""},
+		{`invalid address`, args{&EthereumPayment{Address: "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d35"}}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qr, err := Ethereum(tt.args.payment)
+			require.Truef(t, (err != nil) == tt.wantErr, `Ethereum() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			require.Equal(t, tt.want, qr.Content)
+		})
+	}
+}
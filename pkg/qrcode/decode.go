@@ -12,6 +12,8 @@ import (
 	"github.com/pkg/errors"
 )
 
+// Decode decodes QR code of the image; finder pattern detection sometimes picks false candidates of a clean code,
+// so it falls back to decode as a pure barcode of the image without rotation or skew.
 func Decode(img image.Image) (string, error) {
 	bmp, err := gozxing.NewBinaryBitmapFromImage(img)
 	if err != nil {
@@ -20,7 +22,11 @@ func Decode(img image.Image) (string, error) {
 
 	r, err := qrcode.NewQRCodeReader().Decode(bmp, nil)
 	if err != nil {
-		return "", errors.Wrap(err, "decode failed")
+		pure, pureErr := qrcode.NewQRCodeReader().Decode(bmp, map[gozxing.DecodeHintType]interface{}{gozxing.DecodeHintType_PURE_BARCODE: true})
+		if pureErr != nil {
+			return "", errors.Wrap(err, "decode failed")
+		}
+		r = pure
 	}
 
 	return r.String(), nil
@@ -0,0 +1,426 @@
+package qrcode
+
+import (
+	"fmt"
+	"image"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/whitekid/goxp/validate"
+)
+
+// EMVCo Merchant-Presented Mode
+// SPEC
+// https://www.emvco.com/specifications/emv-qr-code-specification-for-payment-systems-emv-qrcps-merchant-presented-mode/
+
+// EMVCo data object IDs
+const (
+	emvIDPayloadFormat        = "00"
+	emvIDInitiationMethod     = "01"
+	emvIDMerchantCategory     = "52"
+	emvIDCurrency             = "53"
+	emvIDAmount               = "54"
+	emvIDTipIndicator         = "55"
+	emvIDConvenienceFee       = "56"
+	emvIDConvenienceFeePct    = "57"
+	emvIDCountryCode          = "58"
+	emvIDMerchantName         = "59"
+	emvIDMerchantCity         = "60"
+	emvIDPostalCode           = "61"
+	emvIDAdditionalData       = "62"
+	emvIDCRC                  = "63"
+	emvIDMerchantInfoLanguage = "64"
+)
+
+// EMVCo point of initiation method
+const (
+	EMVCoStatic  = "11" // same QR for more than one transaction
+	EMVCoDynamic = "12" // new QR for each transaction
+)
+
+// EMVCo tip or convenience indicator
+const (
+	EMVCoTipPrompt     = "01" // prompt consumer to enter tip
+	EMVCoTipFixed      = "02" // fixed convenience fee
+	EMVCoTipPercentage = "03" // percentage convenience fee
+)
+
+// EMVCoTLV EMVCo data object
+type EMVCoTLV struct {
+	ID    string `validate:"required,len=2,numeric"`
+	Value string `validate:"max=99"`
+}
+
+func (t *EMVCoTLV) String() string {
+	return fmt.Sprintf("%s%02d%s", t.ID, utf8.RuneCountInString(t.Value), t.Value)
+}
+
+// EMVCoMerchantAccount merchant account information, ID 02~51
+// ID 02~25 are primitive values defined by payment networks(Visa, Mastercard, ...)
+// ID 26~51 are templates with globally unique identifier and payment network specific data
+type EMVCoMerchantAccount struct {
+	ID     string     `validate:"required,len=2,numeric"`
+	Value  string     `validate:"max=99"` // primitive value, 02~25
+	GUID   string     `validate:"max=32"` // template 00, 26~51
+	Fields []EMVCoTLV `validate:"dive"`   // template 01~99, 26~51
+}
+
+func (m *EMVCoMerchantAccount) isTemplate() bool { return m.ID >= "26" }
+
+func (m *EMVCoMerchantAccount) value() string {
+	if !m.isTemplate() {
+		return m.Value
+	}
+
+	return encodeTLVs(append([]EMVCoTLV{{ID: "00", Value: m.GUID}}, m.Fields...))
+}
+
+// EMVCoAdditionalData additional data field template, ID 62
+type EMVCoAdditionalData struct {
+	BillNumber          string     `validate:"max=25"` // 01
+	MobileNumber        string     `validate:"max=25"` // 02
+	StoreLabel          string     `validate:"max=25"` // 03
+	LoyaltyNumber       string     `validate:"max=25"` // 04
+	ReferenceLabel      string     `validate:"max=25"` // 05
+	CustomerLabel       string     `validate:"max=25"` // 06
+	TerminalLabel       string     `validate:"max=25"` // 07
+	Purpose             string     `validate:"max=25"` // 08
+	ConsumerDataRequest string     `validate:"max=3"`  // 09: A(address), M(mobile), E(email)
+	Fields              []EMVCoTLV `validate:"dive"`   // 10~99
+}
+
+func (a *EMVCoAdditionalData) tlvs() []EMVCoTLV {
+	return append([]EMVCoTLV{
+		{ID: "01", Value: a.BillNumber},
+		{ID: "02", Value: a.MobileNumber},
+		{ID: "03", Value: a.StoreLabel},
+		{ID: "04", Value: a.LoyaltyNumber},
+		{ID: "05", Value: a.ReferenceLabel},
+		{ID: "06", Value: a.CustomerLabel},
+		{ID: "07", Value: a.TerminalLabel},
+		{ID: "08", Value: a.Purpose},
+		{ID: "09", Value: a.ConsumerDataRequest},
+	}, a.Fields...)
+}
+
+// EMVCoLanguage merchant information - language template, ID 64
+type EMVCoLanguage struct {
+	Preference   string `validate:"omitempty,len=2"` // 00: ISO 639
+	MerchantName string `validate:"max=25"`          // 01
+	MerchantCity string `validate:"max=15"`          // 02
+}
+
+// EMVCoPayload EMVCo merchant-presented mode payload
+type EMVCoPayload struct {
+	PayloadFormat     string                 `validate:"omitempty,eq=01"`          // 00
+	InitiationMethod  string                 `validate:"omitempty,oneof=11 12"`    // 01
+	MerchantAccounts  []EMVCoMerchantAccount `validate:"required,min=1,dive"`      // 02~51
+	MerchantCategory  string                 `validate:"required,len=4,numeric"`   // 52: ISO 18245
+	Currency          string                 `validate:"required,len=3,numeric"`   // 53: ISO 4217 numeric
+	Amount            string                 `validate:"omitempty,max=13,numeric"` // 54
+	TipIndicator      string                 `validate:"omitempty,oneof=01 02 03"` // 55
+	ConvenienceFee    string                 `validate:"omitempty,max=13,numeric"` // 56
+	ConvenienceFeePct string                 `validate:"omitempty,max=5,numeric"`  // 57
+	CountryCode       string                 `validate:"required,len=2,alpha"`     // 58: ISO 3166-1 alpha 2
+	MerchantName      string                 `validate:"required,max=25"`          // 59
+	MerchantCity      string                 `validate:"required,max=15"`          // 60
+	PostalCode        string                 `validate:"max=10"`                   // 61
+	AdditionalData    EMVCoAdditionalData    // 62
+	Language          EMVCoLanguage          // 64
+	Fields            []EMVCoTLV             `validate:"dive"`                        // 65~99: RFU and unreserved templates
+	CRC               string                 `validate:"omitempty,len=4,hexadecimal"` // 63, set by ParseEMVCo
+}
+
+// reEMVCoAmount unsigned decimal of amount, convenience fee and its percentage; numeric tag accepts sign
+var reEMVCoAmount = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+// Encode encode payload as EMVCo data objects with CRC
+func (p *EMVCoPayload) Encode() (string, error) {
+	if err := validate.Struct(p); err != nil {
+		return "", err
+	}
+
+	for _, amount := range []EMVCoTLV{
+		{ID: emvIDAmount, Value: p.Amount},
+		{ID: emvIDConvenienceFee, Value: p.ConvenienceFee},
+		{ID: emvIDConvenienceFeePct, Value: p.ConvenienceFeePct},
+	} {
+		if amount.Value != "" && !reEMVCoAmount.MatchString(amount.Value) {
+			return "", fmt.Errorf("data object %s: invalid amount: %s", amount.ID, amount.Value)
+		}
+	}
+
+	accounts := append([]EMVCoMerchantAccount{}, p.MerchantAccounts...)
+	sort.SliceStable(accounts, func(i, j int) bool { return accounts[i].ID < accounts[j].ID })
+
+	tlvs := []EMVCoTLV{
+		{ID: emvIDPayloadFormat, Value: "01"},
+		{ID: emvIDInitiationMethod, Value: p.InitiationMethod},
+	}
+	for _, account := range accounts {
+		if account.ID < "02" || account.ID > "51" {
+			return "", fmt.Errorf("invalid merchant account id: %s", account.ID)
+		}
+		// empty data object is skipped by encodeTLVs, so the account would be dropped silently
+		value := account.value()
+		if value == "" {
+			return "", fmt.Errorf("merchant account %s: value or GUID required", account.ID)
+		}
+		tlvs = append(tlvs, EMVCoTLV{ID: account.ID, Value: value})
+	}
+	tlvs = append(tlvs,
+		EMVCoTLV{ID: emvIDMerchantCategory, Value: p.MerchantCategory},
+		EMVCoTLV{ID: emvIDCurrency, Value: p.Currency},
+		EMVCoTLV{ID: emvIDAmount, Value: p.Amount},
+		EMVCoTLV{ID: emvIDTipIndicator, Value: p.TipIndicator},
+		EMVCoTLV{ID: emvIDConvenienceFee, Value: p.ConvenienceFee},
+		EMVCoTLV{ID: emvIDConvenienceFeePct, Value: p.ConvenienceFeePct},
+		EMVCoTLV{ID: emvIDCountryCode, Value: strings.ToUpper(p.CountryCode)},
+		EMVCoTLV{ID: emvIDMerchantName, Value: p.MerchantName},
+		EMVCoTLV{ID: emvIDMerchantCity, Value: p.MerchantCity},
+		EMVCoTLV{ID: emvIDPostalCode, Value: p.PostalCode},
+		EMVCoTLV{ID: emvIDAdditionalData, Value: encodeTLVs(p.AdditionalData.tlvs())},
+		EMVCoTLV{ID: emvIDMerchantInfoLanguage, Value: encodeTLVs([]EMVCoTLV{
+			{ID: "00", Value: p.Language.Preference},
+			{ID: "01", Value: p.Language.MerchantName},
+			{ID: "02", Value: p.Language.MerchantCity},
+		})},
+	)
+	for _, field := range p.Fields {
+		if field.ID < "65" {
+			return "", fmt.Errorf("invalid data object id: %s", field.ID)
+		}
+		tlvs = append(tlvs, field)
+	}
+
+	for _, tlv := range tlvs {
+		if utf8.RuneCountInString(tlv.Value) > 99 {
+			return "", fmt.Errorf("data object %s too long", tlv.ID)
+		}
+	}
+
+	s := encodeTLVs(tlvs) + emvIDCRC + "04"
+	return s + fmt.Sprintf("%04X", crc16CCITT([]byte(s))), nil
+}
+
+// encodeTLVs encode data objects, skipping empty values
+func encodeTLVs(tlvs []EMVCoTLV) string {
+	s := new(strings.Builder)
+	for _, tlv := range tlvs {
+		if tlv.Value == "" {
+			continue
+		}
+		s.WriteString(tlv.String())
+	}
+	return s.String()
+}
+
+// parseTLVs parse data objects; length is counted by characters
+func parseTLVs(s string) ([]EMVCoTLV, error) {
+	r := []rune(s)
+	tlvs := []EMVCoTLV{}
+
+	for len(r) > 0 {
+		if len(r) < 4 {
+			return nil, fmt.Errorf("invalid data object: %s", string(r))
+		}
+
+		id := string(r[:2])
+		if _, err := strconv.Atoi(id); err != nil {
+			return nil, fmt.Errorf("invalid data object id: %s", id)
+		}
+
+		size, err := strconv.Atoi(string(r[2:4]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid data object length: %s", string(r[2:4]))
+		}
+
+		r = r[4:]
+		if len(r) < size {
+			return nil, fmt.Errorf("data object %s: length %d exceeds payload", id, size)
+		}
+
+		tlvs = append(tlvs, EMVCoTLV{ID: id, Value: string(r[:size])})
+		r = r[size:]
+	}
+
+	return tlvs, nil
+}
+
+// ParseEMVCo parse EMVCo merchant-presented mode payload and verify CRC
+func ParseEMVCo(s string) (*EMVCoPayload, error) {
+	idx := len(s) - 8
+	if idx < 0 || s[idx:idx+4] != emvIDCRC+"04" {
+		return nil, errors.New("CRC data object must be the last")
+	}
+
+	crc := s[idx+4:]
+	if want := fmt.Sprintf("%04X", crc16CCITT([]byte(s[:idx+4]))); !strings.EqualFold(crc, want) {
+		return nil, fmt.Errorf("CRC mismatch: want %s but got %s", want, crc)
+	}
+
+	tlvs, err := parseTLVs(s[:idx])
+	if err != nil {
+		return nil, errors.Wrap(err, "parse failed")
+	}
+
+	if len(tlvs) == 0 || tlvs[0].ID != emvIDPayloadFormat {
+		return nil, errors.New("payload format indicator must be the first")
+	}
+
+	p := &EMVCoPayload{CRC: strings.ToUpper(crc)}
+	for _, tlv := range tlvs {
+		switch id := tlv.ID; {
+		case id == emvIDPayloadFormat:
+			p.PayloadFormat = tlv.Value
+		case id == emvIDInitiationMethod:
+			p.InitiationMethod = tlv.Value
+		case id >= "02" && id <= "25":
+			p.MerchantAccounts = append(p.MerchantAccounts, EMVCoMerchantAccount{ID: id, Value: tlv.Value})
+		case id >= "26" && id <= "51":
+			account := EMVCoMerchantAccount{ID: id}
+			fields, err := parseTLVs(tlv.Value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "merchant account %s", id)
+			}
+			for _, field := range fields {
+				if field.ID == "00" {
+					account.GUID = field.Value
+					continue
+				}
+				account.Fields = append(account.Fields, field)
+			}
+			p.MerchantAccounts = append(p.MerchantAccounts, account)
+		case id == emvIDMerchantCategory:
+			p.MerchantCategory = tlv.Value
+		case id == emvIDCurrency:
+			p.Currency = tlv.Value
+		case id == emvIDAmount:
+			p.Amount = tlv.Value
+		case id == emvIDTipIndicator:
+			p.TipIndicator = tlv.Value
+		case id == emvIDConvenienceFee:
+			p.ConvenienceFee = tlv.Value
+		case id == emvIDConvenienceFeePct:
+			p.ConvenienceFeePct = tlv.Value
+		case id == emvIDCountryCode:
+			p.CountryCode = tlv.Value
+		case id == emvIDMerchantName:
+			p.MerchantName = tlv.Value
+		case id == emvIDMerchantCity:
+			p.MerchantCity = tlv.Value
+		case id == emvIDPostalCode:
+			p.PostalCode = tlv.Value
+		case id == emvIDAdditionalData:
+			if err := p.AdditionalData.parse(tlv.Value); err != nil {
+				return nil, errors.Wrap(err, "additional data")
+			}
+		case id == emvIDMerchantInfoLanguage:
+			if err := p.Language.parse(tlv.Value); err != nil {
+				return nil, errors.Wrap(err, "merchant information language")
+			}
+		default:
+			p.Fields = append(p.Fields, tlv)
+		}
+	}
+
+	if err := validate.Struct(p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (a *EMVCoAdditionalData) parse(s string) error {
+	tlvs, err := parseTLVs(s)
+	if err != nil {
+		return err
+	}
+
+	for _, tlv := range tlvs {
+		switch tlv.ID {
+		case "01":
+			a.BillNumber = tlv.Value
+		case "02":
+			a.MobileNumber = tlv.Value
+		case "03":
+			a.StoreLabel = tlv.Value
+		case "04":
+			a.LoyaltyNumber = tlv.Value
+		case "05":
+			a.ReferenceLabel = tlv.Value
+		case "06":
+			a.CustomerLabel = tlv.Value
+		case "07":
+			a.TerminalLabel = tlv.Value
+		case "08":
+			a.Purpose = tlv.Value
+		case "09":
+			a.ConsumerDataRequest = tlv.Value
+		default:
+			a.Fields = append(a.Fields, tlv)
+		}
+	}
+
+	return nil
+}
+
+func (l *EMVCoLanguage) parse(s string) error {
+	tlvs, err := parseTLVs(s)
+	if err != nil {
+		return err
+	}
+
+	for _, tlv := range tlvs {
+		switch tlv.ID {
+		case "00":
+			l.Preference = tlv.Value
+		case "01":
+			l.MerchantName = tlv.Value
+		case "02":
+			l.MerchantCity = tlv.Value
+		}
+	}
+
+	return nil
+}
+
+// crc16CCITT CRC-16/CCITT-FALSE, polynomial 0x1021, initial value 0xFFFF
+func crc16CCITT(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// EMVCo generate EMVCo merchant-presented mode payment QRCode
+func EMVCo(p *EMVCoPayload) (*QR, error) {
+	s, err := p.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	return Text(s)
+}
+
+// DecodeEMVCo decode scanned QRCode image as EMVCo payload
+func DecodeEMVCo(img image.Image) (*EMVCoPayload, error) {
+	s, err := Decode(img)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseEMVCo(s)
+}
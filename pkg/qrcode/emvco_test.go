@@ -0,0 +1,132 @@
+package qrcode
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCRC16CCITT(t *testing.T) {
+	require.Equal(t, uint16(0x29B1), crc16CCITT([]byte("123456789")))
+}
+
+func newTestEMVCoPayload() *EMVCoPayload {
+	return &EMVCoPayload{
+		InitiationMethod: EMVCoDynamic,
+		MerchantAccounts: []EMVCoMerchantAccount{
+			{ID: "02", Value: "4000123456789012"},
+			{ID: "26", GUID: "A000000677010111", Fields: []EMVCoTLV{{ID: "01", Value: "0066812345678"}}},
+		},
+		MerchantCategory: "5812",
+		Currency:         "410",
+		Amount:           "15000",
+		CountryCode:      "KR",
+		MerchantName:     "Coffee Shop",
+		MerchantCity:     "Seoul",
+		PostalCode:       "04524",
+		AdditionalData: EMVCoAdditionalData{
+			BillNumber:    "INV-0001",
+			TerminalLabel: "T01",
+		},
+		Language: EMVCoLanguage{
+			Preference:   "KO",
+			MerchantName: "커피숍",
+			MerchantCity: "서울",
+		},
+	}
+}
+
+func TestEMVCo(t *testing.T) {
+	type args struct {
+		payload func(p *EMVCoPayload)
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{`valid`, args{func(p *EMVCoPayload) {}}, false},
+		{`static`, args{func(p *EMVCoPayload) { p.InitiationMethod = EMVCoStatic; p.Amount = "" }}, false},
+		{`tip`, args{func(p *EMVCoPayload) { p.TipIndicator = EMVCoTipPercentage; p.ConvenienceFeePct = "10" }}, false},
+		{`unreserved`, args{func(p *EMVCoPayload) { p.Fields = []EMVCoTLV{{ID: "80", Value: "unreserved"}} }}, false},
+		{`missing account`, args{func(p *EMVCoPayload) { p.MerchantAccounts = nil }}, true},
+		{`invalid account id`, args{func(p *EMVCoPayload) { p.MerchantAccounts[0].ID = "52" }}, true},
+		{`invalid currency`, args{func(p *EMVCoPayload) { p.Currency = "KRW" }}, true},
+		{`invalid country`, args{func(p *EMVCoPayload) { p.CountryCode = "KOR" }}, true},
+		{`invalid amount`, args{func(p *EMVCoPayload) { p.Amount = "12,000" }}, true},
+		{`decimal amount`, args{func(p *EMVCoPayload) { p.Amount = "98.73" }}, false},
+		{`negative amount`, args{func(p *EMVCoPayload) { p.Amount = "-5" }}, true},
+		{`negative fee`, args{func(p *EMVCoPayload) { p.TipIndicator = EMVCoTipFixed; p.ConvenienceFee = "-5" }}, true},
+		{`empty template account`, args{func(p *EMVCoPayload) { p.MerchantAccounts[1].GUID, p.MerchantAccounts[1].Fields = "", nil }}, true},
+		{`empty primitive account`, args{func(p *EMVCoPayload) { p.MerchantAccounts[0].Value = "" }}, true},
+		{`long name`, args{func(p *EMVCoPayload) { p.MerchantName = "12345678901234567890123456" }}, true},
+		{`invalid field id`, args{func(p *EMVCoPayload) { p.Fields = []EMVCoTLV{{ID: "62", Value: "x"}} }}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := newTestEMVCoPayload()
+			tt.args.payload(payload)
+
+			qr, err := EMVCo(payload)
+			require.Truef(t, (err != nil) == tt.wantErr, `EMVCo() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			img, err := qr.Render(200, 200)
+			require.NoError(t, err)
+
+			got, err := DecodeEMVCo(img)
+			require.NoError(t, err)
+			require.Equal(t, qr.Content[len(qr.Content)-4:], got.CRC)
+
+			payload.PayloadFormat = "01"
+			payload.CRC = got.CRC
+			require.Equal(t, payload, got)
+		})
+	}
+}
+
+func TestParseEMVCo(t *testing.T) {
+	valid, err := newTestEMVCoPayload().Encode()
+	require.NoError(t, err)
+
+	type args struct {
+		s string
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{`valid`, args{valid}, false},
+		{`lowercase crc`, args{valid[:len(valid)-4] + strings.ToLower(valid[len(valid)-4:])}, false},
+		{`empty`, args{""}, true},
+		{`missing crc`, args{valid[:len(valid)-8]}, true},
+		{`crc mismatch`, args{valid[:len(valid)-1] + "0"}, true},
+		{`not payload format first`, args{withCRC("01021253034105802KR5905Hello6005Seoul")}, true},
+		{`length overflow`, args{withCRC("000201010212529912")}, true},
+		{`invalid id`, args{withCRC("000201xx0212")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseEMVCo(tt.args.s)
+			require.Truef(t, (err != nil) == tt.wantErr, `ParseEMVCo() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+		})
+	}
+}
+
+func withCRC(s string) string {
+	s += "6304"
+	return s + fmt.Sprintf("%04X", crc16CCITT([]byte(s)))
+}
+
+func FuzzParseEMVCo(f *testing.F) {
+	valid, _ := newTestEMVCoPayload().Encode()
+	f.Add(valid)
+	f.Fuzz(func(t *testing.T, s string) {
+		ParseEMVCo(s)
+	})
+}
@@ -0,0 +1,299 @@
+package qrcode
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/whitekid/goxp"
+	"github.com/whitekid/goxp/validate"
+
+	"qrcodeapi/pkg/ical"
+)
+
+// Event event of friendly fields for web forms; converted to VEVENT with generated UID and DTSTAMP
+//
+// start and end are RFC3339, local date-time(2006-01-02T15:04) in tz, or date(2006-01-02) of all day event;
+// local date-time without tz is floating time. end of all day event is the last day, inclusive.
+type Event struct {
+	Title       string      `json:"title" validate:"required,max=500"`
+	Start       string      `json:"start" validate:"required"`
+	End         string      `json:"end,omitempty"`
+	Duration    string      `json:"duration,omitempty"` // RFC 5545 duration, ex. PT1H30M; exclusive with end
+	AllDay      bool        `json:"allDay,omitempty"`
+	TZ          string      `json:"tz,omitempty" validate:"max=100"` // IANA time zone, ex. Asia/Seoul
+	Location    string      `json:"location,omitempty" validate:"max=500"`
+	Description string      `json:"description,omitempty" validate:"max=500"`
+	URL         string      `json:"url,omitempty" validate:"omitempty,url,max=100"`
+	Attendees   []Attendee  `json:"attendees,omitempty" validate:"max=100,dive"`
+	Recurrence  *Recurrence `json:"recurrence,omitempty"`
+}
+
+type Attendee struct {
+	Email string `json:"email" validate:"required,email,max=100"`
+	Name  string `json:"name,omitempty" validate:"max=100"`
+	Role  string `json:"role,omitempty" validate:"omitempty,oneof=chair required optional non-participant"` // required(*)
+	RSVP  bool   `json:"rsvp,omitempty"`
+}
+
+// Recurrence simple recurrence rule; converted to RRULE
+type Recurrence struct {
+	Freq       string   `json:"freq" validate:"required,oneof=daily weekly monthly yearly"`
+	Interval   int      `json:"interval,omitempty" validate:"min=0"`
+	Count      int      `json:"count,omitempty" validate:"min=0"`
+	Until      string   `json:"until,omitempty"`                        // same format as start; exclusive with count
+	ByDay      []string `json:"byDay,omitempty" validate:"max=7"`       // MO, TU, ...; with ordinal for monthly or yearly, ex. 1MO, -1FR
+	ByMonthDay []int    `json:"byMonthDay,omitempty" validate:"max=31"` // 1..31, -1 for the last day
+	ByMonth    []int    `json:"byMonth,omitempty" validate:"max=12"`    // 1..12
+}
+
+var attendeeRoles = map[string]string{
+	"":                ical.RoleReqParticipant,
+	"chair":           ical.RoleChair,
+	"required":        ical.RoleReqParticipant,
+	"optional":        ical.RoleOptParticipant,
+	"non-participant": ical.RoleNonParticipant,
+}
+
+// FieldError invalid field; Field is JSON path of the field, ex. attendees[0].email
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *FieldError) Error() string { return e.Field + ": " + e.Message }
+
+// FieldErrors errors of fields
+type FieldErrors []*FieldError
+
+func (e FieldErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e FieldErrors) has(field string) bool {
+	for _, err := range e {
+		if err.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// VEvent returns VEVENT of the event; invalid fields are returned as FieldErrors
+func (e *Event) VEvent() (*ical.VEvent, error) {
+	errs := fieldErrors(validate.Struct(e), e)
+	add := func(field string, err error) { errs = append(errs, &FieldError{Field: field, Message: err.Error()}) }
+
+	loc := time.Local
+	if e.TZ != "" {
+		if tz, err := time.LoadLocation(e.TZ); err != nil {
+			add("tz", fmt.Errorf("unknown time zone: %s", e.TZ))
+		} else {
+			loc = tz
+		}
+	}
+
+	evt := &ical.VEvent{
+		UID:         hex.EncodeToString(goxp.RandomByte(16)) + "@qrcodeapi",
+		DtStamp:     ical.DateTime{Time: time.Now().UTC().Truncate(time.Second)},
+		Summary:     ical.Text{Value: e.Title},
+		Location:    ical.Text{Value: e.Location},
+		Description: ical.Text{Value: e.Description},
+		URL:         e.URL,
+	}
+
+	if e.Start != "" {
+		start, err := parseEventTime(e.Start, e.AllDay, loc)
+		if err != nil {
+			add("start", err)
+		}
+		evt.DtStart = start
+	}
+
+	switch {
+	case e.End != "" && e.Duration != "":
+		add("duration", errors.New("must not be given with end"))
+	case e.End != "":
+		end, err := parseEventTime(e.End, e.AllDay, loc)
+		if err != nil {
+			add("end", err)
+			break
+		}
+		if e.AllDay {
+			end = ical.NewDate(end.Year(), end.Month(), end.Day()+1)
+		}
+		if !evt.DtStart.IsZero() && !end.After(evt.DtStart.Time) {
+			add("end", errors.New("must be after start"))
+		}
+		evt.DtEnd = end
+	case e.Duration != "":
+		d, err := ical.ParseDuration(e.Duration)
+		if err != nil || d.Duration <= 0 {
+			add("duration", fmt.Errorf("invalid duration: %s", e.Duration))
+			break
+		}
+		if e.AllDay && d.Duration%ical.Day != 0 {
+			add("duration", fmt.Errorf("must be whole days for all day event: %s", e.Duration))
+			break
+		}
+		evt.Duration = d
+	}
+
+	for _, attendee := range e.Attendees {
+		evt.Attendees = append(evt.Attendees, ical.CalAddress{Value: "mailto:" + attendee.Email, Parameters: ical.Parameters{
+			CN:       attendee.Name,
+			Role:     attendeeRoles[attendee.Role],
+			PartStat: ical.PartStatNeedsAction,
+			RSVP:     attendee.RSVP,
+		}})
+	}
+
+	// invalid freq is already reported by validation
+	if e.Recurrence != nil && !errs.has("recurrence.freq") {
+		rule, err := e.Recurrence.rule(e.AllDay, loc)
+		if err != nil {
+			var fieldErr *FieldError
+			if errors.As(err, &fieldErr) {
+				fieldErr.Field = "recurrence." + fieldErr.Field
+				errs = append(errs, fieldErr)
+			} else {
+				add("recurrence", err)
+			}
+		}
+		evt.RRule = rule
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return evt, nil
+}
+
+// rule returns RRULE; until is converted to UTC
+func (r *Recurrence) rule(allDay bool, loc *time.Location) (*ical.RecurrenceRule, error) {
+	parts := []string{"FREQ=" + strings.ToUpper(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != "" {
+		until, err := parseEventTime(r.Until, allDay, loc)
+		if err != nil {
+			return nil, &FieldError{Field: "until", Message: err.Error()}
+		}
+		if !allDay {
+			until.Time = until.UTC()
+		}
+		parts = append(parts, "UNTIL="+until.String())
+	}
+	if len(r.ByDay) > 0 {
+		parts = append(parts, "BYDAY="+strings.ToUpper(strings.Join(r.ByDay, ",")))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.ByMonth) > 0 {
+		parts = append(parts, "BYMONTH="+joinInts(r.ByMonth))
+	}
+
+	return ical.ParseRecurrenceRule(strings.Join(parts, ";"))
+}
+
+func joinInts(values []int) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = strconv.Itoa(v)
+	}
+	return strings.Join(s, ",")
+}
+
+// parseEventTime parse date of all day event, or RFC3339 or local date-time in loc
+func parseEventTime(s string, allDay bool, loc *time.Location) (ical.DateTime, error) {
+	if allDay {
+		t, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			return ical.DateTime{}, fmt.Errorf("invalid date: %s", s)
+		}
+		return ical.NewDate(t.Year(), t.Month(), t.Day()), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		if loc == time.Local {
+			return ical.DateTime{Time: t.UTC()}, nil
+		}
+		return ical.DateTime{Time: t.In(loc)}, nil
+	}
+
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return ical.DateTime{Time: t}, nil
+		}
+	}
+
+	return ical.DateTime{}, fmt.Errorf("invalid date-time: %s", s)
+}
+
+// fieldErrors returns validation errors with JSON path of the field
+func fieldErrors(err error, v any) FieldErrors {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return nil
+	}
+
+	errs := FieldErrors{}
+	for _, verr := range verrs {
+		message := verr.Tag()
+		switch verr.Tag() {
+		case "required":
+		case "email", "url":
+			message = "invalid " + verr.Tag()
+		case "oneof":
+			message = "must be one of " + verr.Param()
+		case "max":
+			message = "must be at most " + verr.Param()
+		case "min":
+			message = "must be at least " + verr.Param()
+		default:
+			if verr.Param() != "" {
+				message += "=" + verr.Param()
+			}
+		}
+
+		errs = append(errs, &FieldError{Field: jsonPath(reflect.TypeOf(v), verr.StructNamespace()), Message: message})
+	}
+	return errs
+}
+
+// jsonPath returns JSON path of the struct namespace; ex. Event.Attendees[0].Email -> attendees[0].email
+func jsonPath(t reflect.Type, namespace string) string {
+	parts := strings.Split(namespace, ".")[1:]
+	for i, part := range parts {
+		name, index, _ := strings.Cut(part, "[")
+		for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+			t = t.Elem()
+		}
+
+		if f, ok := t.FieldByName(name); ok {
+			if tag, _, _ := strings.Cut(f.Tag.Get("json"), ","); tag != "" {
+				name = tag
+			}
+			t = f.Type
+		}
+		if index != "" {
+			name += "[" + index
+		}
+		parts[i] = name
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,118 @@
+package qrcode
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"qrcodeapi/pkg/ical"
+)
+
+func TestEventVEvent(t *testing.T) {
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	require.NoError(t, err)
+
+	type args struct {
+		event *Event
+	}
+	tests := [...]struct {
+		name       string
+		args       args
+		wantErrs   []string // field
+		wantStart  ical.DateTime
+		wantEnd    ical.DateTime
+		wantRRule  string
+		wantFields func(t *testing.T, evt *ical.VEvent)
+	}{
+		{`local time in tz`, args{&Event{Title: "Meeting", Start: "2023-07-14T17:00", End: "2023-07-14T18:30", TZ: "Asia/Seoul"}}, nil,
+			ical.DateTime{Time: time.Date(2023, 7, 14, 17, 0, 0, 0, seoul)}, ical.DateTime{Time: time.Date(2023, 7, 14, 18, 30, 0, 0, seoul)}, "", nil},
+		{`rfc3339 in tz`, args{&Event{Title: "Meeting", Start: "2023-07-14T08:00:00Z", TZ: "Asia/Seoul"}}, nil,
+			ical.DateTime{Time: time.Date(2023, 7, 14, 17, 0, 0, 0, seoul)}, ical.DateTime{}, "", nil},
+		{`rfc3339 without tz`, args{&Event{Title: "Meeting", Start: "2023-07-14T17:00:00+09:00"}}, nil,
+			ical.DateTime{Time: time.Date(2023, 7, 14, 8, 0, 0, 0, time.UTC)}, ical.DateTime{}, "", nil},
+		{`all day`, args{&Event{Title: "Vacation", Start: "2023-07-14", End: "2023-07-16", AllDay: true}}, nil,
+			ical.NewDate(2023, 7, 14), ical.NewDate(2023, 7, 17), "", nil},
+		{`all day duration`, args{&Event{Title: "Vacation", Start: "2023-07-14", AllDay: true, Duration: "P3D"}}, nil,
+			ical.NewDate(2023, 7, 14), ical.DateTime{}, "", func(t *testing.T, evt *ical.VEvent) {
+				require.Equal(t, 3*ical.Day, evt.Duration.Duration)
+			}},
+		{`duration`, args{&Event{Title: "Meeting", Start: "2023-07-14T17:00", TZ: "Asia/Seoul", Duration: "PT1H30M"}}, nil,
+			ical.DateTime{Time: time.Date(2023, 7, 14, 17, 0, 0, 0, seoul)}, ical.DateTime{}, "", func(t *testing.T, evt *ical.VEvent) {
+				require.Equal(t, 90*time.Minute, evt.Duration.Duration)
+			}},
+		{`fields`, args{&Event{Title: "Meeting", Start: "2023-07-14T17:00", TZ: "Asia/Seoul",
+			Location: "Room 1", Description: "Agenda", URL: "https://example.com/meeting",
+			Attendees: []Attendee{{Email: "john@example.com", Name: "John", Role: "chair", RSVP: true}, {Email: "jane@example.com"}}}}, nil,
+			ical.DateTime{Time: time.Date(2023, 7, 14, 17, 0, 0, 0, seoul)}, ical.DateTime{}, "", func(t *testing.T, evt *ical.VEvent) {
+				require.Equal(t, "Meeting", evt.Summary.Value)
+				require.Equal(t, "Room 1", evt.Location.Value)
+				require.Equal(t, "Agenda", evt.Description.Value)
+				require.Equal(t, "https://example.com/meeting", evt.URL)
+				require.Equal(t, []ical.CalAddress{
+					{Value: "mailto:john@example.com", Parameters: ical.Parameters{CN: "John", Role: ical.RoleChair, PartStat: ical.PartStatNeedsAction, RSVP: true}},
+					{Value: "mailto:jane@example.com", Parameters: ical.Parameters{Role: ical.RoleReqParticipant, PartStat: ical.PartStatNeedsAction}},
+				}, evt.Attendees)
+			}},
+		{`recurrence`, args{&Event{Title: "Weekly", Start: "2023-07-14T17:00", TZ: "Asia/Seoul",
+			Recurrence: &Recurrence{Freq: "weekly", Interval: 2, Until: "2023-12-31T17:00", ByDay: []string{"mo", "fr"}}}}, nil,
+			ical.DateTime{Time: time.Date(2023, 7, 14, 17, 0, 0, 0, seoul)}, ical.DateTime{}, "FREQ=WEEKLY;UNTIL=20231231T080000Z;INTERVAL=2;BYDAY=MO,FR", nil},
+		{`all day recurrence`, args{&Event{Title: "Birthday", Start: "2023-07-14", AllDay: true, Recurrence: &Recurrence{Freq: "yearly", Count: 10}}}, nil,
+			ical.NewDate(2023, 7, 14), ical.DateTime{}, "FREQ=YEARLY;COUNT=10", nil},
+
+		{`required`, args{&Event{}}, []string{"title", "start"}, ical.DateTime{}, ical.DateTime{}, "", nil},
+		{`invalid fields`, args{&Event{Title: "Meeting", Start: "tomorrow", TZ: "Mars/Olympus", URL: "not a url",
+			Attendees: []Attendee{{Email: "john@example.com", Role: "host"}, {Name: "Jane"}}}},
+			[]string{"url", "attendees[0].role", "attendees[1].email", "tz", "start"}, ical.DateTime{}, ical.DateTime{}, "", nil},
+		{`end before start`, args{&Event{Title: "Meeting", Start: "2023-07-14T17:00", End: "2023-07-14T16:00"}}, []string{"end"},
+			ical.DateTime{}, ical.DateTime{}, "", nil},
+		{`end and duration`, args{&Event{Title: "Meeting", Start: "2023-07-14T17:00", End: "2023-07-14T18:00", Duration: "PT1H"}}, []string{"duration"},
+			ical.DateTime{}, ical.DateTime{}, "", nil},
+		{`invalid duration`, args{&Event{Title: "Meeting", Start: "2023-07-14T17:00", Duration: "1h"}}, []string{"duration"},
+			ical.DateTime{}, ical.DateTime{}, "", nil},
+		{`all day with hours`, args{&Event{Title: "Vacation", Start: "2023-07-14", AllDay: true, Duration: "PT90M"}}, []string{"duration"},
+			ical.DateTime{}, ical.DateTime{}, "", nil},
+		{`all day with date-time`, args{&Event{Title: "Vacation", Start: "2023-07-14T17:00", AllDay: true}}, []string{"start"},
+			ical.DateTime{}, ical.DateTime{}, "", nil},
+		{`invalid freq`, args{&Event{Title: "Meeting", Start: "2023-07-14T17:00", Recurrence: &Recurrence{Freq: "hourly"}}}, []string{"recurrence.freq"},
+			ical.DateTime{}, ical.DateTime{}, "", nil},
+		{`invalid until`, args{&Event{Title: "Meeting", Start: "2023-07-14T17:00", Recurrence: &Recurrence{Freq: "daily", Until: "never"}}}, []string{"recurrence.until"},
+			ical.DateTime{}, ical.DateTime{}, "", nil},
+		{`invalid rule`, args{&Event{Title: "Meeting", Start: "2023-07-14T17:00", Recurrence: &Recurrence{Freq: "daily", ByDay: []string{"XX"}}}}, []string{"recurrence"},
+			ical.DateTime{}, ical.DateTime{}, "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.args.event.VEvent()
+			require.Truef(t, (err != nil) == (tt.wantErrs != nil), `VEvent() failed: error = %+v, wantErrs = %v`, err, tt.wantErrs)
+			if tt.wantErrs != nil {
+				var errs FieldErrors
+				require.True(t, errors.As(err, &errs))
+
+				fields := make([]string, len(errs))
+				for i, e := range errs {
+					fields[i] = e.Field
+					require.NotEmpty(t, e.Message)
+				}
+				require.Equal(t, tt.wantErrs, fields)
+				return
+			}
+
+			require.NotEmpty(t, got.UID)
+			require.WithinDuration(t, time.Now(), got.DtStamp.Time, time.Minute)
+			require.Equal(t, time.UTC, got.DtStamp.Location())
+			require.Equal(t, tt.wantStart, got.DtStart)
+			require.Equal(t, tt.wantEnd, got.DtEnd)
+			if tt.wantRRule != "" {
+				require.Equal(t, tt.wantRRule, got.RRule.String())
+			}
+			if tt.wantFields != nil {
+				tt.wantFields(t, got)
+			}
+
+			_, err = VEvent(got)
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package qrcode
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"qrcodeapi/pkg/ical"
+)
+
+// "add to calendar" link formats of web calendars
+const (
+	LinkGoogle    = "google"
+	LinkOutlook   = "outlook"   // outlook.com
+	LinkOffice365 = "office365" // outlook of Microsoft 365
+	LinkYahoo     = "yahoo"
+)
+
+// VEventLink returns QR of "add to calendar" link of the event; much smaller than VEVENT
+func VEventLink(evt *ical.VEvent, format string) (*QR, error) {
+	link, err := EventLink(evt, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return fitQR(link)
+}
+
+// EventLink returns "add to calendar" link of the event for web calendar of the format
+//
+// times in TZID are given with time zone for google, in UTC for others; floating times are in user's time zone.
+// recurrence and attendees are supported by google only
+func EventLink(evt *ical.VEvent, format string) (string, error) {
+	if evt.DtStart.IsZero() {
+		return "", fmt.Errorf("DTSTART required")
+	}
+
+	start, end := evt.DtStart, eventEnd(evt)
+
+	switch format {
+	case LinkGoogle:
+		q := url.Values{}
+		q.Set("action", "TEMPLATE")
+		q.Set("text", evt.Summary.Value)
+		q.Set("dates", start.String()+"/"+end.String())
+		setNonEmpty(q, "details", evt.Description.Value)
+		setNonEmpty(q, "location", evt.Location.Value)
+		setNonEmpty(q, "ctz", start.TZID())
+		if evt.RRule != nil {
+			q.Set("recur", "RRULE:"+evt.RRule.String())
+		}
+
+		emails := []string{}
+		for _, attendee := range evt.Attendees {
+			if email, ok := cutPrefixFold(attendee.Value, "mailto:"); ok {
+				emails = append(emails, email)
+			}
+		}
+		setNonEmpty(q, "add", strings.Join(emails, ","))
+
+		return "https://calendar.google.com/calendar/render?" + q.Encode(), nil
+
+	case LinkOutlook, LinkOffice365:
+		host := "outlook.live.com"
+		if format == LinkOffice365 {
+			host = "outlook.office.com"
+		}
+
+		q := url.Values{}
+		q.Set("path", "/calendar/action/compose")
+		q.Set("rru", "addevent")
+		q.Set("subject", evt.Summary.Value)
+		q.Set("startdt", linkTime(start, "2006-01-02", "2006-01-02T15:04:05"))
+		q.Set("enddt", linkTime(end, "2006-01-02", "2006-01-02T15:04:05"))
+		setNonEmpty(q, "body", evt.Description.Value)
+		setNonEmpty(q, "location", evt.Location.Value)
+		if start.IsDate() {
+			q.Set("allday", "true")
+		}
+
+		return "https://" + host + "/calendar/0/deeplink/compose?" + q.Encode(), nil
+
+	case LinkYahoo:
+		q := url.Values{}
+		q.Set("v", "60")
+		q.Set("title", evt.Summary.Value)
+		q.Set("st", linkTime(start, "20060102", "20060102T150405"))
+		q.Set("et", linkTime(end, "20060102", "20060102T150405"))
+		setNonEmpty(q, "desc", evt.Description.Value)
+		setNonEmpty(q, "in_loc", evt.Location.Value)
+		if start.IsDate() {
+			q.Set("dur", "allday")
+		}
+
+		return "https://calendar.yahoo.com/?" + q.Encode(), nil
+	}
+
+	return "", fmt.Errorf("unsupported link format: %s", format)
+}
+
+// eventEnd returns DTEND, or end by DURATION; end of event without them is the next day for date, start for date-time
+func eventEnd(evt *ical.VEvent) ical.DateTime {
+	start := evt.DtStart
+	switch {
+	case !evt.DtEnd.IsZero():
+		return evt.DtEnd
+	case start.IsDate():
+		days := 1
+		if evt.Duration.Duration > 0 {
+			days = int(evt.Duration.Duration / ical.Day)
+		}
+		return ical.NewDate(start.Year(), start.Month(), start.Day()+days)
+	default:
+		return ical.DateTime{Time: start.Add(evt.Duration.Duration)}
+	}
+}
+
+// linkTime format date, or date-time in UTC with Z suffix; floating time is formatted as is
+func linkTime(dt ical.DateTime, dateLayout, layout string) string {
+	switch {
+	case dt.IsDate():
+		return dt.Format(dateLayout)
+	case dt.Location() == time.Local:
+		return dt.Format(layout)
+	}
+	return dt.UTC().Format(layout) + "Z"
+}
+
+func setNonEmpty(q url.Values, key, value string) {
+	if value != "" {
+		q.Set(key, value)
+	}
+}
+
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
@@ -0,0 +1,124 @@
+package qrcode
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"qrcodeapi/pkg/ical"
+)
+
+func TestEventLink(t *testing.T) {
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	require.NoError(t, err)
+
+	meeting := &ical.VEvent{
+		UID:         "1@example.com",
+		DtStart:     ical.DateTime{Time: time.Date(2023, 7, 14, 17, 0, 0, 0, seoul)},
+		DtEnd:       ical.DateTime{Time: time.Date(2023, 7, 14, 18, 30, 0, 0, seoul)},
+		Summary:     ical.Text{Value: "Weekly sync"},
+		Description: ical.Text{Value: "Agenda & notes"},
+		Location:    ical.Text{Value: "Room 1"},
+		Attendees:   []ical.CalAddress{{Value: "mailto:john@example.com"}, {Value: "MAILTO:jane@example.com"}},
+		RRule:       &ical.RecurrenceRule{Freq: ical.FreqWeekly, Count: 10},
+	}
+	vacation := &ical.VEvent{
+		UID:     "2@example.com",
+		DtStart: ical.NewDate(2023, 7, 14),
+		Summary: ical.Text{Value: "Vacation"},
+	}
+	utc := &ical.VEvent{
+		UID:      "3@example.com",
+		DtStart:  ical.DateTime{Time: time.Date(2023, 7, 14, 8, 0, 0, 0, time.UTC)},
+		Duration: ical.Duration{Duration: time.Hour},
+		Summary:  ical.Text{Value: "Call"},
+	}
+
+	type args struct {
+		evt    *ical.VEvent
+		format string
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+		want    string // link without query
+		wantQ   url.Values
+	}{
+		{`google`, args{meeting, LinkGoogle}, false, "https://calendar.google.com/calendar/render", url.Values{
+			"action":   {"TEMPLATE"},
+			"text":     {"Weekly sync"},
+			"dates":    {"20230714T170000/20230714T183000"},
+			"ctz":      {"Asia/Seoul"},
+			"details":  {"Agenda & notes"},
+			"location": {"Room 1"},
+			"recur":    {"RRULE:FREQ=WEEKLY;COUNT=10"},
+			"add":      {"john@example.com,jane@example.com"},
+		}},
+		{`google all day`, args{vacation, LinkGoogle}, false, "https://calendar.google.com/calendar/render", url.Values{
+			"action": {"TEMPLATE"},
+			"text":   {"Vacation"},
+			"dates":  {"20230714/20230715"},
+		}},
+		{`google duration`, args{utc, LinkGoogle}, false, "https://calendar.google.com/calendar/render", url.Values{
+			"action": {"TEMPLATE"},
+			"text":   {"Call"},
+			"dates":  {"20230714T080000Z/20230714T090000Z"},
+		}},
+		{`outlook`, args{meeting, LinkOutlook}, false, "https://outlook.live.com/calendar/0/deeplink/compose", url.Values{
+			"path":     {"/calendar/action/compose"},
+			"rru":      {"addevent"},
+			"subject":  {"Weekly sync"},
+			"startdt":  {"2023-07-14T08:00:00Z"},
+			"enddt":    {"2023-07-14T09:30:00Z"},
+			"body":     {"Agenda & notes"},
+			"location": {"Room 1"},
+		}},
+		{`office365 all day`, args{vacation, LinkOffice365}, false, "https://outlook.office.com/calendar/0/deeplink/compose", url.Values{
+			"path":    {"/calendar/action/compose"},
+			"rru":     {"addevent"},
+			"subject": {"Vacation"},
+			"startdt": {"2023-07-14"},
+			"enddt":   {"2023-07-15"},
+			"allday":  {"true"},
+		}},
+		{`yahoo`, args{utc, LinkYahoo}, false, "https://calendar.yahoo.com/", url.Values{
+			"v":     {"60"},
+			"title": {"Call"},
+			"st":    {"20230714T080000Z"},
+			"et":    {"20230714T090000Z"},
+		}},
+		{`yahoo all day`, args{vacation, LinkYahoo}, false, "https://calendar.yahoo.com/", url.Values{
+			"v":     {"60"},
+			"title": {"Vacation"},
+			"st":    {"20230714"},
+			"et":    {"20230715"},
+			"dur":   {"allday"},
+		}},
+		{`unsupported format`, args{meeting, "icloud"}, true, "", nil},
+		{`no start`, args{&ical.VEvent{UID: "4@example.com"}, LinkGoogle}, true, "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EventLink(tt.args.evt, tt.args.format)
+			require.Truef(t, (err != nil) == tt.wantErr, `EventLink() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			link, query, _ := strings.Cut(got, "?")
+			require.Equal(t, tt.want, link)
+
+			q, err := url.ParseQuery(query)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantQ, q)
+
+			qr, err := VEventLink(tt.args.evt, tt.args.format)
+			require.NoError(t, err)
+			require.Equal(t, got, qr.Content)
+		})
+	}
+}
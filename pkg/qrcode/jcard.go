@@ -0,0 +1,385 @@
+package qrcode
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/emersion/go-vcard"
+	"github.com/pkg/errors"
+)
+
+// jCard(RFC 7095) JSON format of vCard
+//
+//	["vcard", [
+//	  ["version", {}, "text", "4.0"],
+//	  ["n", {}, "text", ["Doe", "John", "", "", ""]],
+//	  ["tel", {"type": ["cell", "voice"]}, "uri", "tel:+1-555-555-5555"]
+//	]]
+
+// properties with structured value; components are separated by ';' in vCard, array in jCard
+var jcardStructuredProperties = map[string]bool{
+	vcard.FieldName:         true,
+	vcard.FieldAddress:      true,
+	vcard.FieldOrganization: true,
+	vcard.FieldGender:       true,
+	vcard.FieldClientPIDMap: true,
+}
+
+// properties with multiple values; values are separated by ',' in vCard, additional elements in jCard
+var jcardMultiValuedProperties = map[string]bool{
+	vcard.FieldNickname:   true,
+	vcard.FieldCategories: true,
+}
+
+// default value type of properties, text if not listed
+var jcardDefaultTypes = map[string]string{
+	vcard.FieldSource:             "uri",
+	vcard.FieldPhoto:              "uri",
+	vcard.FieldLogo:               "uri",
+	vcard.FieldSound:              "uri",
+	vcard.FieldURL:                "uri",
+	vcard.FieldIMPP:               "uri",
+	vcard.FieldKey:                "uri",
+	vcard.FieldMember:             "uri",
+	vcard.FieldRelated:            "uri",
+	vcard.FieldGeolocation:        "uri",
+	vcard.FieldUID:                "uri",
+	vcard.FieldFreeOrBusyURL:      "uri",
+	vcard.FieldCalendarURI:        "uri",
+	vcard.FieldCalendarAddressURI: "uri",
+	PropertySocialProfile:         "uri",
+	vcard.FieldBirthday:           "date-and-or-time",
+	vcard.FieldAnniversary:        "date-and-or-time",
+	vcard.FieldRevision:           "timestamp",
+	vcard.FieldTimezone:           "utc-offset",
+}
+
+func jcardDefaultType(key string) string {
+	if typ, ok := jcardDefaultTypes[key]; ok {
+		return typ
+	}
+	return "text"
+}
+
+// UnmarshalJCard decode jCard to vcard.Card
+func UnmarshalJCard(data []byte) (vcard.Card, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Wrap(err, "invalid jcard")
+	}
+
+	var name string
+	if len(raw) != 2 || json.Unmarshal(raw[0], &name) != nil || name != "vcard" {
+		return nil, errors.New(`invalid jcard: must be ["vcard", [properties...]]`)
+	}
+
+	var properties [][]json.RawMessage
+	if err := json.Unmarshal(raw[1], &properties); err != nil {
+		return nil, errors.Wrap(err, "invalid jcard properties")
+	}
+
+	card := vcard.Card{}
+	for i, prop := range properties {
+		key, field, err := jcardField(prop)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid jcard property #%d", i)
+		}
+		card.Add(key, field)
+	}
+
+	if card.Get(vcard.FieldVersion) == nil {
+		return nil, errors.New("invalid jcard: version property missing")
+	}
+
+	return card, nil
+}
+
+// jcardField decode jCard property ["name", {params}, "type", value...]
+func jcardField(prop []json.RawMessage) (string, *vcard.Field, error) {
+	if len(prop) < 4 {
+		return "", nil, errors.New("must be [name, params, type, value...]")
+	}
+
+	var name, typ string
+	if err := json.Unmarshal(prop[0], &name); err != nil || name == "" {
+		return "", nil, errors.New("name must be string")
+	}
+	if err := json.Unmarshal(prop[2], &typ); err != nil {
+		return "", nil, errors.New("type must be string")
+	}
+	key := strings.ToUpper(name)
+
+	var rawParams map[string]json.RawMessage
+	if err := json.Unmarshal(prop[1], &rawParams); err != nil {
+		return "", nil, errors.New("params must be object")
+	}
+
+	field := &vcard.Field{Params: vcard.Params{}}
+	for k, v := range rawParams {
+		values, err := jcardStrings(v)
+		if err != nil {
+			return "", nil, errors.Wrapf(err, "param %s", k)
+		}
+
+		if strings.EqualFold(k, "group") {
+			field.Group = strings.Join(values, "")
+			continue
+		}
+		field.Params[strings.ToUpper(k)] = values
+	}
+
+	if typ != "unknown" && typ != jcardDefaultType(key) {
+		field.Params.Set(vcard.ParamValue, typ)
+	}
+
+	values := make([]string, 0, len(prop)-3)
+	for _, v := range prop[3:] {
+		value, err := jcardValue(v)
+		if err != nil {
+			return "", nil, err
+		}
+		values = append(values, jcardToVCardValue(typ, value))
+	}
+	field.Value = strings.Join(values, ",")
+
+	return key, field, nil
+}
+
+// jcardValue returns vCard value of jCard value; structured value is joined with ';', multiple component values with ','
+func jcardValue(raw json.RawMessage) (string, error) {
+	var components []json.RawMessage
+	if err := json.Unmarshal(raw, &components); err != nil {
+		return jcardScalar(raw)
+	}
+
+	values := make([]string, len(components))
+	for i, c := range components {
+		parts, err := jcardStrings(c)
+		if err != nil {
+			return "", err
+		}
+		values[i] = strings.Join(parts, ",")
+	}
+	return strings.Join(values, ";"), nil
+}
+
+// jcardStrings returns string or array of strings
+func jcardStrings(raw json.RawMessage) ([]string, error) {
+	var values []json.RawMessage
+	if err := json.Unmarshal(raw, &values); err != nil {
+		s, err := jcardScalar(raw)
+		if err != nil {
+			return nil, err
+		}
+		return []string{s}, nil
+	}
+
+	result := make([]string, len(values))
+	for i, v := range values {
+		s, err := jcardScalar(v)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = s
+	}
+	return result, nil
+}
+
+// jcardScalar returns string of string, number, boolean or null value
+func jcardScalar(raw json.RawMessage) (string, error) {
+	var v any
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return "", err
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return fmt.Sprint(v), nil
+	}
+	return "", errors.Errorf("unexpected value: %s", raw)
+}
+
+// MarshalJCard encode vcard.Card to jCard; properties are sorted with version first
+func MarshalJCard(card vcard.Card) ([]byte, error) {
+	if card.Get(vcard.FieldVersion) == nil {
+		return nil, errors.New("vcard: VERSION field missing")
+	}
+
+	keys := make([]string, 0, len(card))
+	for k := range card {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if (keys[i] == vcard.FieldVersion) != (keys[j] == vcard.FieldVersion) {
+			return keys[i] == vcard.FieldVersion
+		}
+		return keys[i] < keys[j]
+	})
+
+	properties := []any{}
+	for _, k := range keys {
+		for _, field := range card[k] {
+			properties = append(properties, jcardProperty(k, field))
+		}
+	}
+
+	return json.Marshal([]any{"vcard", properties})
+}
+
+func jcardProperty(key string, field *vcard.Field) []any {
+	key = strings.ToUpper(key)
+
+	params := map[string]any{}
+	if field.Group != "" {
+		params["group"] = field.Group
+	}
+
+	typ := jcardDefaultType(key)
+	for k, values := range field.Params {
+		if k == vcard.ParamValue {
+			typ = strings.ToLower(field.Params.Get(k))
+			continue
+		}
+
+		if k == vcard.ParamType {
+			// 3.0 style TYPE=WORK,VOICE
+			values = strings.Split(strings.Join(values, ","), ",")
+		}
+		if len(values) == 1 {
+			params[strings.ToLower(k)] = values[0]
+		} else {
+			params[strings.ToLower(k)] = values
+		}
+	}
+
+	prop := []any{strings.ToLower(key), params, typ}
+	switch {
+	case jcardStructuredProperties[key]:
+		components := strings.Split(field.Value, ";")
+		value := make([]any, len(components))
+		for i, c := range components {
+			if parts := strings.Split(c, ","); len(parts) > 1 && vcardListProperties[key] {
+				value[i] = parts
+			} else {
+				value[i] = c
+			}
+		}
+		if len(value) == 1 {
+			return append(prop, value[0])
+		}
+		return append(prop, value)
+	case jcardMultiValuedProperties[key]:
+		for _, v := range strings.Split(field.Value, ",") {
+			prop = append(prop, v)
+		}
+		return prop
+	}
+
+	return append(prop, vcardToJCardValue(typ, field.Value))
+}
+
+// jcardToVCardValue convert date, time and utc-offset of extended format in jCard to basic format in vCard
+//
+//	1985-04-12 -> 19850412, --04-12 -> --0412, 1985-04 -> 1985-04
+//	T10:22:00+08:00 -> T102200+0800, -05:00 -> -0500
+func jcardToVCardValue(typ, value string) string {
+	switch typ {
+	case "date", "time", "date-time", "date-and-or-time", "timestamp":
+		date, tm, ok := strings.Cut(value, "T")
+		if typ == "time" {
+			date, tm, ok = "", value, true
+		}
+
+		if len(date) != len("1985-04") || strings.HasPrefix(date, "-") {
+			trimmed := strings.TrimLeft(date, "-")
+			date = date[:len(date)-len(trimmed)] + strings.ReplaceAll(trimmed, "-", "")
+		}
+		if !ok {
+			return date
+		}
+
+		tm = strings.ReplaceAll(tm, ":", "")
+		if typ == "time" {
+			return tm
+		}
+		return date + "T" + tm
+	case "utc-offset":
+		return strings.ReplaceAll(value, ":", "")
+	}
+
+	return value
+}
+
+// vcardToJCardValue convert date, time and utc-offset of basic format in vCard to extended format in jCard
+//
+//	19850412 -> 1985-04-12, --0412 -> --04-12
+//	T102200+0800 -> T10:22:00+08:00, -0500 -> -05:00
+func vcardToJCardValue(typ, value string) string {
+	switch typ {
+	case "date", "time", "date-time", "date-and-or-time", "timestamp":
+		date, tm, ok := strings.Cut(value, "T")
+		if typ == "time" {
+			date, tm, ok = "", value, true
+		}
+
+		switch {
+		case len(date) == len("19850412") && !strings.HasPrefix(date, "-"):
+			date = date[:4] + "-" + date[4:6] + "-" + date[6:]
+		case len(date) == len("--0412") && strings.HasPrefix(date, "--") && date[2] != '-':
+			date = date[:4] + "-" + date[4:]
+		}
+		if !ok {
+			return date
+		}
+
+		if typ == "time" {
+			return jcardTime(tm)
+		}
+		return date + "T" + jcardTime(tm)
+	case "utc-offset":
+		return jcardTime(value)
+	}
+
+	return value
+}
+
+// jcardTime returns time or utc-offset of basic format with ':' between hour, minute and second
+//
+//	102200Z -> 10:22:00Z, -2200 -> -22:00, +0800 -> +08:00
+func jcardTime(value string) string {
+	zone := ""
+	trimmed := strings.TrimLeft(value, "-")
+	if i := strings.IndexAny(trimmed, "Z+-"); i >= 0 {
+		trimmed, zone = trimmed[:i], trimmed[i:]
+	}
+
+	s := value[:len(value)-len(trimmed)-len(zone)] + jcardDigits(trimmed)
+	if len(zone) > 1 {
+		zone = zone[:1] + jcardDigits(zone[1:])
+	}
+	return s + zone
+}
+
+// jcardDigits returns digits separated by ':' every two digits; 102200 -> 10:22:00
+func jcardDigits(s string) string {
+	if strings.Contains(s, ":") {
+		return s
+	}
+
+	parts := []string{}
+	for len(s) > 2 {
+		parts, s = append(parts, s[:2]), s[2:]
+	}
+	return strings.Join(append(parts, s), ":")
+}
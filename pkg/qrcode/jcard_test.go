@@ -0,0 +1,176 @@
+package qrcode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/emersion/go-vcard"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalJCard(t *testing.T) {
+	type args struct {
+		jcard string
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+		want    string
+	}{
+		{`valid`, args{`["vcard", [
+			["version", {}, "text", "4.0"],
+			["fn", {}, "text", "John Doe"],
+			["n", {}, "text", ["Doe", "John", "", ["Mr.", "Dr."], ""]],
+			["nickname", {}, "text", "JD", "Johnny"],
+			["org", {}, "text", ["Example", "Sales"]],
+			["tel", {"type": ["cell", "voice"], "pref": "1"}, "uri", "tel:+1-555-555-5555"],
+			["email", {"group": "item1", "type": "work"}, "text", "john@example.com"],
+			["adr", {"type": "home"}, "text", ["", "", "123 Main St", "Any Town", "CA", "91921", "USA"]],
+			["url", {}, "uri", "https://example.com/a,b"],
+			["bday", {}, "date", "1985-04-12"],
+			["anniversary", {}, "date-and-or-time", "--04-12T10:22:00+08:00"],
+			["rev", {}, "timestamp", "2013-02-14T12:30:00Z"],
+			["tz", {}, "utc-offset", "-05:00"],
+			["x-time", {}, "time", "10:22"],
+			["x-month", {}, "date", "1985-04"],
+			["note", {}, "text", "line1\nline2, end"],
+			["x-number", {}, "integer", 42],
+			["x-flag", {}, "unknown", true]
+		]]`}, false, `BEGIN:VCARD
+VERSION:4.0
+ADR;TYPE=home:;;123 Main St;Any Town;CA;91921;USA
+ANNIVERSARY:--0412T102200+0800
+BDAY;VALUE=date:19850412
+item1.EMAIL;TYPE=work:john@example.com
+FN:John Doe
+N:Doe;John;;Mr.,Dr.;
+NICKNAME:JD,Johnny
+NOTE:line1\nline2\, end
+ORG:Example;Sales
+REV:20130214T123000Z
+TEL;TYPE=cell;TYPE=voice;PREF=1;VALUE=uri:tel:+1-555-555-5555
+TZ:-0500
+URL:https://example.com/a,b
+X-FLAG:true
+X-MONTH;VALUE=date:1985-04
+X-NUMBER;VALUE=integer:42
+X-TIME;VALUE=time:1022
+END:VCARD`},
+		{`not jcard`, args{`{"fn": "John Doe"}`}, true, ""},
+		{`not vcard`, args{`["vcalendar", []]`}, true, ""},
+		{`missing version`, args{`["vcard", [["fn", {}, "text", "John Doe"]]]`}, true, ""},
+		{`missing value`, args{`["vcard", [["version", {}, "text", "4.0"], ["fn", {}, "text"]]]`}, true, ""},
+		{`invalid params`, args{`["vcard", [["version", [], "text", "4.0"]]]`}, true, ""},
+		{`invalid value`, args{`["vcard", [["version", {}, "text", "4.0"], ["fn", {}, "text", {"a": 1}]]]`}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card, err := UnmarshalJCard([]byte(tt.args.jcard))
+			require.Truef(t, (err != nil) == tt.wantErr, `UnmarshalJCard() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			qr, err := VCard(card)
+			require.NoError(t, err)
+			require.Equal(t, strings.ReplaceAll(tt.want, "\n", "\r\n"), qr.Content)
+		})
+	}
+}
+
+func TestMarshalJCard(t *testing.T) {
+	type args struct {
+		vcard string
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+		want    string
+	}{
+		{`4.0`, args{`BEGIN:VCARD
+VERSION:4.0
+FN:John Doe
+N:Doe;John;;Mr.,Dr.;
+NICKNAME:JD,Johnny
+TEL;TYPE=cell;VALUE=uri:tel:+1-555-555-5555
+item1.EMAIL:john@example.com
+PHOTO:https://example.com/photo.jpg
+END:VCARD`}, false, `["vcard",[` +
+			`["version",{},"text","4.0"],` +
+			`["email",{"group":"item1"},"text","john@example.com"],` +
+			`["fn",{},"text","John Doe"],` +
+			`["n",{},"text",["Doe","John","",["Mr.","Dr."],""]],` +
+			`["nickname",{},"text","JD","Johnny"],` +
+			`["photo",{},"uri","https://example.com/photo.jpg"],` +
+			`["tel",{"type":"cell"},"uri","tel:+1-555-555-5555"]]]`},
+		{`date and time`, args{`BEGIN:VCARD
+VERSION:4.0
+BDAY:19850412
+ANNIVERSARY:--0412T102200+0800
+ORG:Example\, Inc.
+REV:20130214T123000Z
+TZ:-0500
+END:VCARD`}, false, `["vcard",[` +
+			`["version",{},"text","4.0"],` +
+			`["anniversary",{},"date-and-or-time","--04-12T10:22:00+08:00"],` +
+			`["bday",{},"date-and-or-time","1985-04-12"],` +
+			`["org",{},"text","Example, Inc."],` +
+			`["rev",{},"timestamp","2013-02-14T12:30:00Z"],` +
+			`["tz",{},"utc-offset","-05:00"]]]`},
+		{`3.0 type`, args{`BEGIN:VCARD
+VERSION:3.0
+ORG:Example
+TEL;TYPE=WORK,VOICE:555-1234
+END:VCARD`}, false, `["vcard",[` +
+			`["version",{},"text","3.0"],` +
+			`["org",{},"text","Example"],` +
+			`["tel",{"type":["WORK","VOICE"]},"text","555-1234"]]]`},
+		{`missing version`, args{`BEGIN:VCARD
+FN:John Doe
+END:VCARD`}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card, err := vcard.NewDecoder(strings.NewReader(tt.args.vcard)).Decode()
+			require.NoError(t, err)
+
+			got, err := MarshalJCard(card)
+			require.Truef(t, (err != nil) == tt.wantErr, `MarshalJCard() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			require.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestJCardRoundTrip(t *testing.T) {
+	contact, err := ContactAs(&Card{
+		FirstName: "John",
+		LastName:  "Doe",
+		Company:   "Example",
+		Mobile:    "010-1234-5678",
+		Email:     "john@example.com",
+		HomeAddr:  Address{City: "Seoul", Street: "Gangnam-daero 1"},
+		Note:      "hello, world",
+	}, FormatVCard4)
+	require.NoError(t, err)
+
+	card, err := vcard.NewDecoder(strings.NewReader(contact.Content)).Decode()
+	require.NoError(t, err)
+
+	data, err := MarshalJCard(card)
+	require.NoError(t, err)
+
+	decoded, err := UnmarshalJCard(data)
+	require.NoError(t, err)
+
+	want, err := VCardAs(card, VCardVersion40)
+	require.NoError(t, err)
+	got, err := VCardAs(decoded, VCardVersion40)
+	require.NoError(t, err)
+	require.Equal(t, want.Content, got.Content)
+}
@@ -0,0 +1,79 @@
+package qrcode
+
+import (
+	"strings"
+
+	"github.com/whitekid/goxp/validate"
+)
+
+// MeCard generate contact QRCode as MECARD, more compact than vCard
+// SPEC: https://www.nttdocomo.co.jp/english/service/developer/make/content/barcode/function/application/addressbook/
+//
+// fields not supported by MECARD(department, title, fax, pager, social profiles, photo, logo) are dropped
+func MeCard(card *Card) (*QR, error) {
+	if err := validate.Struct(card); err != nil {
+		return nil, err
+	}
+
+	s := new(strings.Builder)
+	s.WriteString("MECARD:")
+
+	write := func(key string, values ...string) {
+		for _, value := range values {
+			if value == "" {
+				continue
+			}
+			s.WriteString(key + ":" + value + ";")
+		}
+	}
+
+	name := escapeMeCard(card.LastName)
+	firstName := strings.TrimSpace(card.FirstName + " " + card.MiddleName)
+	if firstName != "" {
+		name += "," + escapeMeCard(firstName)
+	}
+	s.WriteString("N:" + name + ";") // N is required
+	write("NICKNAME", escapeMeCard(card.NickName))
+	write("TEL", escapeMeCard(card.Mobile), escapeMeCard(card.Tel), escapeMeCard(card.WorkTel), escapeMeCard(card.HomeTel))
+	write("EMAIL", escapeMeCard(card.Email), escapeMeCard(card.WorkEmail), escapeMeCard(card.HomeEmail))
+	write("ORG", escapeMeCard(card.Company))
+	write("ADR", card.WorkAddr.meCard(), card.HomeAddr.meCard())
+	write("URL", escapeMeCardURL(card.Homepage), escapeMeCardURL(card.WorkHomepage), escapeMeCardURL(card.HomeHomepage))
+	write("NOTE", escapeMeCard(card.Note))
+	s.WriteString(";")
+
+	return Text(s.String())
+}
+
+var meCardEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`;`, `\;`,
+	`:`, `\:`,
+	`,`, `\,`,
+	"\n", ` `,
+)
+
+func escapeMeCard(s string) string { return meCardEscaper.Replace(s) }
+
+// URL is not a compound field; escaped ':' of scheme and port breaks the link on most scanners
+var meCardURLEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`;`, `\;`,
+)
+
+func escapeMeCardURL(s string) string { return meCardURLEscaper.Replace(s) }
+
+// meCard returns MECARD ADR: po box, room number, house number, city, prefecture, zip code, country
+func (addr *Address) meCard() string {
+	if addr.String() == "" {
+		return ""
+	}
+
+	street := strings.TrimSpace(addr.Street + " " + addr.Street2)
+	values := []string{"", "", street, addr.City, addr.Province, addr.PostCode, addr.CountryOrRegion}
+	for i, value := range values {
+		values[i] = escapeMeCard(value)
+	}
+
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,104 @@
+package qrcode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeCard(t *testing.T) {
+	type args struct {
+		card *Card
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+		want    string
+	}{
+		{`empty`, args{&Card{}}, false, "MECARD:N:;;"},
+		{`name`, args{&Card{LastName: "Doe", FirstName: "John", MiddleName: "M"}}, false, "MECARD:N:Doe,John M;;"},
+		{`full`, args{&Card{
+			LastName:  "Sowa",
+			FirstName: "Taro",
+			NickName:  "taro",
+			Company:   "ACME, Inc.",
+			Mobile:    "+82-10-1234-5678",
+			WorkTel:   "02-123-4567",
+			WorkEmail: "taro@example.com",
+			WorkAddr:  Address{Street: "1 Main St", City: "Seoul", PostCode: "04524", CountryOrRegion: "Korea"},
+			Homepage:  "https://example.com:8080/a;b",
+			Note:      "note;with:special\\chars",
+		}}, false, `MECARD:N:Sowa,Taro;NICKNAME:taro;TEL:+82-10-1234-5678;TEL:02-123-4567;EMAIL:taro@example.com;ORG:ACME\, Inc.;ADR:,,1 Main St,Seoul,,04524,Korea;URL:https://example.com:8080/a\;b;NOTE:note\;with\:special\\chars;;`},
+		{`invalid email`, args{&Card{Email: "invalid"}}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qr, err := MeCard(tt.args.card)
+			require.Truef(t, (err != nil) == tt.wantErr, `MeCard() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			require.Equal(t, tt.want, qr.Content)
+
+			img, err := qr.Render(200, 200)
+			require.NoError(t, err)
+
+			got, err := Decode(img)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestContactAs(t *testing.T) {
+	card := &Card{
+		LastName:  "Doe",
+		FirstName: "John",
+		Mobile:    "+82-10-1234-5678",
+		WorkEmail: "john@example.com",
+	}
+
+	type args struct {
+		format string
+	}
+	tests := [...]struct {
+		name       string
+		args       args
+		wantErr    bool
+		wantPrefix string
+		wantLines  []string
+	}{
+		{`default`, args{""}, false, "BEGIN:VCARD\r\nVERSION:4.0\r\n", nil},
+		{`vcard4`, args{FormatVCard4}, false, "BEGIN:VCARD\r\nVERSION:4.0\r\n",
+			[]string{"EMAIL;TYPE=work:john@example.com", "TEL;TYPE=cell,voice;PREF=1:+82-10-1234-5678"}},
+		{`vcard3`, args{FormatVCard3}, false, "BEGIN:VCARD\r\nVERSION:3.0\r\n",
+			[]string{"EMAIL;TYPE=INTERNET,WORK:john@example.com", "TEL;TYPE=CELL,VOICE,PREF:+82-10-1234-5678"}},
+		{`vcard21`, args{FormatVCard21}, false, "BEGIN:VCARD\r\nVERSION:2.1\r\n",
+			[]string{"EMAIL;INTERNET;WORK:john@example.com", "TEL;CELL;VOICE;PREF:+82-10-1234-5678"}},
+		{`mecard`, args{FormatMeCard}, false, "MECARD:", nil},
+		{`unknown`, args{"vcard2"}, true, "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qr, err := ContactAs(card, tt.args.format)
+			require.Truef(t, (err != nil) == tt.wantErr, `ContactAs() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			require.Regexp(t, "^"+tt.wantPrefix, qr.Content)
+			for _, line := range tt.wantLines {
+				require.Contains(t, strings.Split(qr.Content, "\r\n"), line)
+			}
+		})
+	}
+
+	vc, err := ContactAs(card, FormatVCard4)
+	require.NoError(t, err)
+	mc, err := ContactAs(card, FormatMeCard)
+	require.NoError(t, err)
+	require.Less(t, len(mc.Content), len(vc.Content)/2)
+}
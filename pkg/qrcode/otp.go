@@ -0,0 +1,113 @@
+package qrcode
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/whitekid/goxp/validate"
+)
+
+// OTP key types
+const (
+	OTPTypeTOTP = "totp"
+	OTPTypeHOTP = "hotp"
+)
+
+// OTPAuth otpauth:// key provisioning
+// https://github.com/google/google-authenticator/wiki/Key-Uri-Format
+type OTPAuth struct {
+	Type      string `validate:"required,oneof=totp hotp"`
+	Secret    string `validate:"required,max=128"` // base32 encoded
+	Issuer    string `validate:"max=100,excludes=:"`
+	Account   string `validate:"required,max=100"`
+	Algorithm string `validate:"omitempty,oneof=SHA1 SHA256 SHA512"`
+	Digits    int    `validate:"omitempty,oneof=6 8"`
+	Period    int    `validate:"omitempty,min=1,max=3600"` // totp only, seconds
+	Counter   uint64 // hotp only, initial counter
+}
+
+var otpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// URI returns otpauth:// uri
+func (o *OTPAuth) URI() (string, error) {
+	if err := validate.Struct(o); err != nil {
+		return "", err
+	}
+
+	secret, err := normalizeOTPSecret(o.Secret)
+	if err != nil {
+		return "", err
+	}
+
+	label := url.PathEscape(o.Account)
+	if o.Issuer != "" {
+		label = url.PathEscape(o.Issuer) + ":" + label
+	}
+
+	params := []string{"secret=" + secret}
+	if o.Issuer != "" {
+		params = append(params, "issuer="+uriEscape(o.Issuer))
+	}
+	if o.Algorithm != "" {
+		params = append(params, "algorithm="+o.Algorithm)
+	}
+	if o.Digits != 0 {
+		params = append(params, "digits="+strconv.Itoa(o.Digits))
+	}
+
+	switch o.Type {
+	case OTPTypeTOTP:
+		if o.Period != 0 {
+			params = append(params, "period="+strconv.Itoa(o.Period))
+		}
+	case OTPTypeHOTP:
+		params = append(params, "counter="+strconv.FormatUint(o.Counter, 10))
+	}
+
+	return fmt.Sprintf("otpauth://%s/%s?%s", o.Type, label, strings.Join(params, "&")), nil
+}
+
+// normalizeOTPSecret returns upper cased base32 secret without spaces and paddings
+func normalizeOTPSecret(secret string) (string, error) {
+	secret = strings.ToUpper(strings.TrimRight(strings.ReplaceAll(secret, " ", ""), "="))
+
+	b, err := otpEncoding.DecodeString(secret)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid secret")
+	}
+
+	if len(b) < 10 {
+		return "", fmt.Errorf("secret too short: %d bytes, 10 bytes or more required", len(b))
+	}
+
+	return secret, nil
+}
+
+// GenerateOTPSecret generate random base32 encoded secret of size bytes
+func GenerateOTPSecret(size int) (string, error) {
+	if size < 10 || size > 64 {
+		return "", fmt.Errorf("invalid secret size: %d", size)
+	}
+
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return otpEncoding.EncodeToString(b), nil
+}
+
+// OTP generate OTP provisioning QRCode
+func OTP(o *OTPAuth) (*QR, error) {
+	uri, err := o.URI()
+	if err != nil {
+		return nil, err
+	}
+
+	return Text(uri)
+}
@@ -0,0 +1,101 @@
+package qrcode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOTP(t *testing.T) {
+	type args struct {
+		otp *OTPAuth
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+		want    string
+	}{
+		{`totp`, args{&OTPAuth{Type: OTPTypeTOTP, Secret: "JBSWY3DPEHPK3PXP", Issuer: "Example", Account: "alice@google.com"}}, false,
+			"otpauth://totp/Example:alice@google.com?secret=JBSWY3DPEHPK3PXP&issuer=Example"},
+		{`totp full`, args{&OTPAuth{
+			Type: OTPTypeTOTP, Secret: "jbsw y3dp ehpk 3pxp", Issuer: "ACME Co", Account: "john.doe@email.com",
+			Algorithm: "SHA256", Digits: 8, Period: 60,
+		}}, false, "otpauth://totp/ACME%20Co:john.doe@email.com?secret=JBSWY3DPEHPK3PXP&issuer=ACME%20Co&algorithm=SHA256&digits=8&period=60"},
+		{`hotp`, args{&OTPAuth{Type: OTPTypeHOTP, Secret: "JBSWY3DPEHPK3PXP", Account: "alice", Counter: 3}}, false,
+			"otpauth://hotp/alice?secret=JBSWY3DPEHPK3PXP&counter=3"},
+		{`padded secret`, args{&OTPAuth{Type: OTPTypeTOTP, Secret: "GEZDGNBVGY3TQOJQGEZA====", Account: "alice"}}, false,
+			"otpauth://totp/alice?secret=GEZDGNBVGY3TQOJQGEZA"},
+		{`invalid secret`, args{&OTPAuth{Type: OTPTypeTOTP, Secret: "JBSWY3DPEHPK3PX1", Account: "alice"}}, true, ""},
+		{`short secret`, args{&OTPAuth{Type: OTPTypeTOTP, Secret: "JBSWY3DP", Account: "alice"}}, true, ""},
+		{`issuer with colon`, args{&OTPAuth{Type: OTPTypeTOTP, Secret: "JBSWY3DPEHPK3PXP", Issuer: "a:b", Account: "alice"}}, true, ""},
+		{`invalid type`, args{&OTPAuth{Type: "motp", Secret: "JBSWY3DPEHPK3PXP", Account: "alice"}}, true, ""},
+		{`invalid algorithm`, args{&OTPAuth{Type: OTPTypeTOTP, Secret: "JBSWY3DPEHPK3PXP", Account: "alice", Algorithm: "MD5"}}, true, ""},
+		{`invalid digits`, args{&OTPAuth{Type: OTPTypeTOTP, Secret: "JBSWY3DPEHPK3PXP", Account: "alice", Digits: 7}}, true, ""},
+		{`missing account`, args{&OTPAuth{Type: OTPTypeTOTP, Secret: "JBSWY3DPEHPK3PXP"}}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qr, err := OTP(tt.args.otp)
+			require.Truef(t, (err != nil) == tt.wantErr, `OTP() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			require.Equal(t, tt.want, qr.Content)
+		})
+	}
+}
+
+func TestGenerateOTPSecret(t *testing.T) {
+	type args struct {
+		size int
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+		wantLen int
+	}{
+		{`default`, args{20}, false, 32},
+		{`minimum`, args{10}, false, 16},
+		{`too short`, args{8}, true, 0},
+		{`too long`, args{65}, true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GenerateOTPSecret(tt.args.size)
+			require.Truef(t, (err != nil) == tt.wantErr, `GenerateOTPSecret() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			require.Len(t, got, tt.wantLen)
+
+			normalized, err := normalizeOTPSecret(got)
+			require.NoError(t, err)
+			require.Equal(t, got, normalized)
+
+			other, err := GenerateOTPSecret(tt.args.size)
+			require.NoError(t, err)
+			require.NotEqual(t, got, other)
+		})
+	}
+}
+
+func TestOTPRender(t *testing.T) {
+	for i := 0; i < 300; i++ {
+		secret, err := GenerateOTPSecret(20)
+		require.NoError(t, err)
+
+		qr, err := OTP(&OTPAuth{Type: OTPTypeTOTP, Secret: secret, Issuer: "Example", Account: "alice"})
+		require.NoError(t, err)
+
+		img, err := qr.Render(200, 200)
+		require.NoError(t, err)
+
+		got, err := Decode(img)
+		require.NoErrorf(t, err, "decode failed: secret=%s", secret)
+		require.Equal(t, qr.Content, got)
+	}
+}
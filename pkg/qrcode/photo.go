@@ -0,0 +1,150 @@
+package qrcode
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+
+	"github.com/makiuchi-d/gozxing/qrcode/decoder"
+	"github.com/makiuchi-d/gozxing/qrcode/encoder"
+	"github.com/pkg/errors"
+	"github.com/whitekid/goxp/fx"
+)
+
+// MaxVersion largest QR code version of contact and calendar; rendered at least 250px with 2px modules
+// larger versions need too large image to be scanned by phone cameras
+const MaxVersion = 25
+
+// MaxCapacity bytes of QR code MaxVersion with error correction level L
+const MaxCapacity = 1273
+
+// CapacityError content does not fit in QR code
+type CapacityError struct {
+	Size     int
+	Capacity int
+}
+
+func (e *CapacityError) Error() string {
+	return fmt.Sprintf("content too large for qrcode: %d bytes, capacity %d bytes", e.Size, e.Capacity)
+}
+
+// fitQR returns QR for content larger than Text allows, up to MaxVersion
+// NOTE structured append is not supported by gozxing encoder; use url instead of embedded image for large contact
+func fitQR(content string) (*QR, error) {
+	if len(content) > MaxCapacity {
+		return nil, &CapacityError{Size: len(content), Capacity: MaxCapacity}
+	}
+
+	// version depends on encoding mode and charset of the content
+	code, err := encoder.Encoder_encodeWithoutHint(content, decoder.ErrorCorrectionLevel_L)
+	if err != nil {
+		return nil, err
+	}
+	if code.GetVersion().GetVersionNumber() > MaxVersion {
+		return nil, &CapacityError{Size: len(content), Capacity: MaxCapacity}
+	}
+
+	return &QR{Content: content}, nil
+}
+
+// CardImage PHOTO, LOGO of contact
+// Data is downscaled and embedded as jpeg; URL is used if Data is empty or embedded image does not fit
+type CardImage struct {
+	URL    string `validate:"omitempty,url,max=200"`
+	Data   []byte `validate:"max=5242880"`
+	Budget int    `validate:"min=0,max=1273"` // max bytes of embedded image, remaining QR capacity if 0
+}
+
+func (img *CardImage) empty() bool { return img.URL == "" && len(img.Data) == 0 }
+
+// maxImageSize max width and height of image to embed; checked before decoding, so small file of huge image is rejected
+const maxImageSize = 4096
+
+// thumbnail sizes and jpeg qualities trying in order
+var (
+	thumbnailSizes     = []int{128, 96, 72, 64, 48, 40, 32, 24, 16}
+	thumbnailQualities = []int{60, 40, 20}
+)
+
+// embedImage returns jpeg image that fits in budget bytes as base64 encoded
+func embedImage(data []byte, budget int) ([]byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid image")
+	}
+	if config.Width > maxImageSize || config.Height > maxImageSize {
+		return nil, fmt.Errorf("image too large: %dx%d, max %dx%d", config.Width, config.Height, maxImageSize, maxImageSize)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid image")
+	}
+
+	// shrink the source once to the largest thumbnail; smaller thumbnails are downscaled from it
+	src = downscale(src, thumbnailSizes[0])
+
+	// grayscale jpeg has smaller header(~200 bytes) so try it when color does not fit
+	for _, gray := range []bool{false, true} {
+		for _, size := range thumbnailSizes {
+			thumb := downscale(src, size)
+			if gray {
+				grayThumb := image.NewGray(thumb.Bounds())
+				draw.Draw(grayThumb, grayThumb.Bounds(), thumb, image.Point{}, draw.Src)
+				thumb = grayThumb
+			}
+
+			for _, quality := range thumbnailQualities {
+				buf := new(bytes.Buffer)
+				if err := jpeg.Encode(buf, thumb, &jpeg.Options{Quality: quality}); err != nil {
+					return nil, err
+				}
+
+				if base64.StdEncoding.EncodedLen(buf.Len()) <= budget {
+					return buf.Bytes(), nil
+				}
+			}
+		}
+	}
+
+	return nil, &CapacityError{Size: base64.StdEncoding.EncodedLen(len(data)), Capacity: budget}
+}
+
+// downscale resize image to fit in size x size with box filter, on white background
+func downscale(src image.Image, size int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w > size || h > size {
+		if w >= h {
+			w, h = size, fx.Max(1, h*size/w)
+		} else {
+			w, h = fx.Max(1, w*size/h), size
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		y0, y1 := b.Min.Y+y*b.Dy()/h, b.Min.Y+(y+1)*b.Dy()/h
+		for x := 0; x < w; x++ {
+			x0, x1 := b.Min.X+x*b.Dx()/w, b.Min.X+(x+1)*b.Dx()/w
+
+			// flatten alpha for jpeg; premultiplied color over white
+			var r, g, bl, n uint64
+			for sy := y0; sy < fx.Max(y1, y0+1); sy++ {
+				for sx := x0; sx < fx.Max(x1, x0+1); sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, n = r+uint64(cr+0xffff-ca), g+uint64(cg+0xffff-ca), bl+uint64(cb+0xffff-ca), n+1
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{uint8(r / n >> 8), uint8(g / n >> 8), uint8(bl / n >> 8), 0xff})
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,172 @@
+package qrcode
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/emersion/go-vcard"
+	"github.com/makiuchi-d/gozxing/qrcode/decoder"
+	"github.com/makiuchi-d/gozxing/qrcode/encoder"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestImage(t *testing.T, w, h int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.NRGBA{uint8(x * 255 / w), uint8(y * 255 / h), 0x80, uint8(0xff - x%2*0x80)})
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, png.Encode(buf, img))
+	return buf.Bytes()
+}
+
+func TestEmbedImage(t *testing.T) {
+	type args struct {
+		data   []byte
+		budget int
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{`landscape`, args{newTestImage(t, 300, 200), 2000}, false},
+		{`portrait`, args{newTestImage(t, 200, 300), 2000}, false},
+		{`small budget`, args{newTestImage(t, 300, 200), 800}, false},
+		{`tiny image`, args{newTestImage(t, 8, 8), 1000}, false},
+		{`budget too small`, args{newTestImage(t, 300, 200), 10}, true},
+		{`invalid image`, args{[]byte("not an image"), 2000}, true},
+		{`too large image`, args{newTestImage(t, maxImageSize+1, 8), 2000}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := embedImage(tt.args.data, tt.args.budget)
+			require.Truef(t, (err != nil) == tt.wantErr, `embedImage() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			require.LessOrEqual(t, base64.StdEncoding.EncodedLen(len(got)), tt.args.budget)
+
+			img, err := jpeg.Decode(bytes.NewReader(got))
+			require.NoError(t, err)
+			require.LessOrEqual(t, img.Bounds().Dx(), thumbnailSizes[0])
+			require.LessOrEqual(t, img.Bounds().Dy(), thumbnailSizes[0])
+		})
+	}
+}
+
+func TestContactPhoto(t *testing.T) {
+	photo := newTestImage(t, 300, 200)
+
+	type args struct {
+		format string
+		photo  CardImage
+		logo   CardImage
+		note   string
+	}
+	tests := [...]struct {
+		name       string
+		args       args
+		wantErr    bool
+		wantPhoto  string
+		wantLogo   string
+		wantPrefix bool
+	}{
+		{`url`, args{FormatVCard4, CardImage{URL: "https://example.com/photo.jpg"}, CardImage{}, ""}, false,
+			"PHOTO:https://example.com/photo.jpg", "", false},
+		{`url vcard3`, args{FormatVCard3, CardImage{URL: "https://example.com/photo.jpg"}, CardImage{URL: "https://example.com/logo.png"}, ""}, false,
+			"PHOTO;VALUE=uri:https://example.com/photo.jpg", "LOGO;VALUE=uri:https://example.com/logo.png", false},
+		{`embed`, args{FormatVCard4, CardImage{Data: photo}, CardImage{}, ""}, false,
+			"PHOTO:data:image/jpeg;base64,", "", true},
+		{`embed vcard3`, args{FormatVCard3, CardImage{Data: photo}, CardImage{}, ""}, false,
+			"PHOTO;TYPE=JPEG;ENCODING=b:", "", true},
+		{`embed photo and logo`, args{FormatVCard4, CardImage{Data: photo}, CardImage{Data: photo}, ""}, false,
+			"PHOTO:data:image/jpeg;base64,", "LOGO:data:image/jpeg;base64,", true},
+		{`embed photo and logo with budget`, args{FormatVCard4, CardImage{Data: photo}, CardImage{Data: photo, Budget: 540}, ""}, false,
+			"PHOTO:data:image/jpeg;base64,", "LOGO:data:image/jpeg;base64,", true},
+		{`fallback to url`, args{FormatVCard4, CardImage{Data: photo, URL: "https://example.com/photo.jpg", Budget: 10}, CardImage{}, ""}, false,
+			"PHOTO:https://example.com/photo.jpg", "", false},
+		{`exceed budget`, args{FormatVCard4, CardImage{Data: photo, Budget: 10}, CardImage{}, ""}, true, "", "", false},
+		{`exceed capacity`, args{FormatVCard4, CardImage{Data: photo, Budget: MaxCapacity}, CardImage{}, strings.Repeat("x", 1000)}, true, "", "", false},
+		{`invalid image`, args{FormatVCard4, CardImage{Data: []byte("not an image"), URL: "https://example.com/photo.jpg"}, CardImage{}, ""}, true, "", "", false},
+		{`invalid url`, args{FormatVCard4, CardImage{URL: "not url"}, CardImage{}, ""}, true, "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qr, err := ContactAs(&Card{
+				FirstName: "John",
+				LastName:  "Doe",
+				Note:      tt.args.note,
+				Photo:     tt.args.photo,
+				Logo:      tt.args.logo,
+			}, tt.args.format)
+			require.Truef(t, (err != nil) == tt.wantErr, `ContactAs() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			require.LessOrEqual(t, len(qr.Content), MaxCapacity)
+
+			lines := map[string]string{}
+			for _, line := range strings.Split(qr.Content, "\r\n") {
+				if strings.HasPrefix(line, "PHOTO") || strings.HasPrefix(line, "LOGO") {
+					lines[line[:4]] = line
+				}
+			}
+
+			for key, want := range map[string]string{"PHOT": tt.wantPhoto, "LOGO": tt.wantLogo} {
+				if tt.wantPrefix {
+					require.True(t, strings.HasPrefix(lines[key], want), lines[key])
+				} else {
+					require.Equal(t, want, lines[key])
+				}
+			}
+
+			card, err := vcard.NewDecoder(strings.NewReader(qr.Content)).Decode()
+			require.NoError(t, err)
+			if tt.args.photo.Data == nil || !tt.wantPrefix {
+				return
+			}
+
+			field := card.Get(vcard.FieldPhoto)
+			encoded := strings.TrimPrefix(field.Value, "data:image/jpeg;base64,")
+			data, err := base64.StdEncoding.DecodeString(encoded)
+			require.NoError(t, err)
+
+			_, err = jpeg.Decode(bytes.NewReader(data))
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestContactPhotoRender(t *testing.T) {
+	qr, err := Contact(&Card{FirstName: "John", LastName: "Doe", Photo: CardImage{Data: newTestImage(t, 300, 200)}})
+	require.NoError(t, err)
+	require.Greater(t, len(qr.Content), 1024)
+
+	img, err := qr.Render(200, 200)
+	require.NoError(t, err)
+
+	code, err := encoder.Encoder_encodeWithoutHint(qr.Content, decoder.ErrorCorrectionLevel_L)
+	require.NoError(t, err)
+	require.LessOrEqual(t, code.GetVersion().GetVersionNumber(), MaxVersion)
+	require.GreaterOrEqual(t, img.Bounds().Dx(), (code.GetMatrix().GetWidth()+2*quietZone)*minModuleSize)
+
+	got, err := Decode(img)
+	require.NoError(t, err)
+	require.Equal(t, qr.Content, got)
+
+	_, err = fitQR(strings.Repeat("x", MaxCapacity+1))
+	var capacityErr *CapacityError
+	require.ErrorAs(t, err, &capacityErr)
+}
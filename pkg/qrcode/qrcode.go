@@ -1,13 +1,18 @@
 package qrcode
 
 import (
+	"bytes"
+	"encoding/base64"
 	"fmt"
 	"image"
-	"strings" 
+	"strings"
 
 	"github.com/emersion/go-vcard"
 	"github.com/makiuchi-d/gozxing"
 	"github.com/makiuchi-d/gozxing/qrcode"
+	"github.com/makiuchi-d/gozxing/qrcode/decoder"
+	"github.com/makiuchi-d/gozxing/qrcode/encoder"
+	"github.com/pkg/errors"
 	"github.com/whitekid/goxp"
 	"github.com/whitekid/goxp/fx"
 	"github.com/whitekid/goxp/log"
@@ -21,10 +26,23 @@ type QR struct {
 	Content string
 }
 
+// minModuleSize pixels per module; code of 1px modules is decoded from the image but not by phone cameras
+// quietZone modules of margin of gozxing writer
+const (
+	minModuleSize = 2
+	quietZone     = 4
+)
+
+// Render renders QR code image; width and height are the minimum size,
+// scaled up to minModuleSize pixels per module if the size is too small for the version.
 func (q *QR) Render(width, height int) (image.Image, error) {
-	return qrcode.NewQRCodeWriter().
-		Encode(q.Content, gozxing.BarcodeFormat_QR_CODE,
-			width, height, nil)
+	if code, err := encoder.Encoder_encodeWithoutHint(q.Content, decoder.ErrorCorrectionLevel_L); err == nil {
+		size := (code.GetMatrix().GetWidth() + 2*quietZone) * minModuleSize
+		width, height = fx.Max(width, size), fx.Max(height, size)
+	}
+
+	writer := qrcode.NewQRCodeWriter()
+	return writer.Encode(q.Content, gozxing.BarcodeFormat_QR_CODE, width, height, nil)
 }
 
 func Text(text string) (*QR, error) {
@@ -157,6 +175,9 @@ type Card struct {
 	Note string `validate:"max=1024"`
 
 	SocialProfiles []SocialProfile `validate:"dive"`
+
+	Photo CardImage // vcf
+	Logo  CardImage // vcf
 }
 
 type Address struct {
@@ -182,84 +203,189 @@ type SocialProfile struct {
 	ID   string `validate:"max=100"`
 }
 
-func setField(vc vcard.Card, key, value string) {
-	_, exists := vc[key]
+// contact formats
+const (
+	FormatVCard4  = "vcard4"
+	FormatVCard3  = "vcard3"
+	FormatVCard21 = "vcard21" // for old android scanners
+	FormatMeCard  = "mecard"
+)
 
-	if (exists && value == "") || value != "" {
-		vc[key] = []*vcard.Field{{Value: value}}
+// Contact generate contact QRCode as vCard 4.0
+func Contact(card *Card) (*QR, error) { return ContactAs(card, FormatVCard4) }
+
+// ContactAs generate contact QRCode as given format; vCard 4.0 if format is empty
+func ContactAs(card *Card, format string) (*QR, error) {
+	switch format {
+	case FormatVCard4, "":
+		return contactVCard(card, VCardVersion40)
+	case FormatVCard3:
+		return contactVCard(card, VCardVersion30)
+	case FormatVCard21:
+		return contactVCard(card, VCardVersion21)
+	case FormatMeCard:
+		return MeCard(card)
 	}
+
+	return nil, fmt.Errorf("unsupported contact format: %s", format)
 }
 
-func Contact(card *Card) (*QR, error) {
+func contactVCard(card *Card, version string) (*QR, error) {
 	if err := validate.Struct(card); err != nil {
 		return nil, err
 	}
 
-	vc := vcard.Card{
-		"VERSION": []*vcard.Field{{Value: "4.0"}},
-		"N":       []*vcard.Field{{Value: fmt.Sprintf("%s;%s;%s;%s;%s", card.LastName, card.FirstName, card.MiddleName, card.PrefixName, card.SuffixName)}}, // Name
+	vc := vcard.Card{}
+	vc.SetValue(vcard.FieldVersion, version)
+	vc.SetValue(vcard.FieldFormattedName, card.formattedName()) // FN is required
+	vc.SetName(&vcard.Name{
+		FamilyName:      card.LastName,
+		GivenName:       card.FirstName,
+		AdditionalName:  card.MiddleName,
+		HonorificPrefix: card.PrefixName,
+		HonorificSuffix: card.SuffixName,
+	})
+
+	add := func(key, value string, types ...string) {
+		if value == "" {
+			return
+		}
+
+		field := &vcard.Field{Value: value}
+		if len(types) > 0 {
+			field.Params = vcard.Params{vcard.ParamType: types}
+		}
+		vc.Add(key, field)
 	}
 
-	setField(vc, "EMAIL;type=INTERNET;type=HOME;type=pref", card.HomeEmail)
-	setField(vc, "EMAIL;type=INTERNET;type=WORK", card.WorkEmail)
-	setField(vc, "TEL;type=CELL;type=VOICE;type=pref", card.Mobile)
-	setField(vc, "TEL;type=HOME;type=VOICE", card.HomeTel)
-	setField(vc, "TEL;type=WORK;type=VOICE", card.WorkTel)
-	setField(vc, "TEL;type=MAIN", card.Tel)
+	add(vcard.FieldNickname, card.NickName)
+	add(vcard.FieldOrganization, goxp.Ternary(card.Department == "", card.Company, card.Company+";"+card.Department))
+	add(vcard.FieldTitle, card.JobTitle)
+
+	// INTERNET is default and not defined as TYPE of EMAIL in 4.0
+	internet := goxp.Ternary(version == VCardVersion40, []string{}, []string{"INTERNET"})
+	add(vcard.FieldEmail, card.Email, append(internet, "pref")...)
+	add(vcard.FieldEmail, card.HomeEmail, append(internet, goxp.Ternary(card.Email == "", []string{"HOME", "pref"}, []string{"HOME"})...)...)
+	add(vcard.FieldEmail, card.WorkEmail, append(internet, "WORK")...)
 
-	setField(vc, "TEL;type=HOME;type=FAX", card.HomeFax)
-	setField(vc, "TEL;type=WORK;type=FAX", card.WorkFax)
+	add(vcard.FieldTelephone, card.Mobile, "CELL", "VOICE", "pref")
+	add(vcard.FieldTelephone, card.HomeTel, "HOME", "VOICE")
+	add(vcard.FieldTelephone, card.WorkTel, "WORK", "VOICE")
+	add(vcard.FieldTelephone, card.Tel, "MAIN")
+	add(vcard.FieldTelephone, card.HomeFax, "HOME", "FAX")
+	add(vcard.FieldTelephone, card.WorkFax, "WORK", "FAX")
+	add(vcard.FieldTelephone, card.Pager, "PAGER")
 
-	setField(vc, "TEL;type=PAGER", card.Pager)
-	setField(vc, "ADR;type=HOME;type=pref", card.HomeAddr.String())
-	setField(vc, "ADR;type=WORK", card.WorkAddr.String())
+	add(vcard.FieldAddress, card.HomeAddr.String(), "HOME", "pref")
+	add(vcard.FieldAddress, card.WorkAddr.String(), "WORK")
+
+	add(vcard.FieldURL, card.Homepage)
+	add(vcard.FieldURL, card.WorkHomepage, "WORK")
+	add(vcard.FieldURL, card.HomeHomepage, "HOME")
 
 	for _, social := range card.SocialProfiles {
-		typ := social.Type
-		ID := social.ID
-
-		switch social.Type {
-		case "twitter":
-			ID = "http://twitter.com/" + ID
-		case "facebook":
-			ID = "http://facebook.com/" + ID
-		case "flickr":
-			ID = "http://www.fickr.com/photos/" + ID
-		case "linkedin":
-			ID = "http://www.fickr.com/in/" + ID
-		case "myspace":
-			ID = "http://www.myspace.com/" + ID
-		case "sinaweibo":
-			ID = "http://weibo.com/n/" + ID
-		case "JabberInstant":
-			typ = "X-SOCIALPROFILE;type=JabberInstant;x-user=" + ID
-			ID = "http://t.qq.com/" + ID
-		case "yelp":
-			typ = "X-SOCIALPROFILE;type=Yelp:x-apple"
-		default:
-			return nil, fmt.Errorf("unsupported social type: %s", social.Type)
+		key, field, err := social.field()
+		if err != nil {
+			return nil, err
 		}
-		setField(vc, "X-SOCIALPROFILE;type="+typ, ID)
+		vc.Add(key, field)
 	}
 
-	setField(vc, "NOTE", card.Note)
+	add(vcard.FieldNote, card.Note)
+
+	// uploaded LOGO reserves its budget from PHOTO, or shares the remaining capacity in half
+	reserved, shares := 0, 1
+	if len(card.Logo.Data) > 0 {
+		reserved = imageOverhead(vcard.FieldLogo) + card.Logo.Budget
+		if card.Logo.Budget == 0 {
+			shares = 2
+		}
+	}
+	if err := addImage(vc, vcard.FieldPhoto, version, &card.Photo, reserved, shares); err != nil {
+		return nil, err
+	}
+	if err := addImage(vc, vcard.FieldLogo, version, &card.Logo, 0, 1); err != nil {
+		return nil, err
+	}
 
 	s := new(strings.Builder)
-	if err := vcard.NewEncoder(s).Encode(vc); err != nil {
+	if err := encodeVCard(s, vc, version); err != nil {
 		return nil, err
 	}
 
-	return Text(s.String())
+	return fitQR(s.String())
+}
+
+// imageOverhead bytes of the property of embedded image except the base64 data
+func imageOverhead(key string) int {
+	return len(key + ";TYPE=JPEG;ENCODING=b:data:image/jpeg;base64,\r\n")
+}
+
+// addImage add PHOTO or LOGO; uploaded image is embedded within budget or shares of remaining QR capacity except reserved
+// falls back to url if embedded image does not fit
+func addImage(vc vcard.Card, key string, version string, img *CardImage, reserved int, shares int) error {
+	if img.empty() {
+		return nil
+	}
+
+	if len(img.Data) > 0 {
+		budget := img.Budget
+		if budget == 0 {
+			s := new(strings.Builder)
+			if err := encodeVCard(s, vc, version); err != nil {
+				return err
+			}
+			budget = (MaxCapacity - s.Len() - imageOverhead(key) - reserved) / shares
+		}
+
+		data, err := embedImage(img.Data, budget)
+		if err == nil {
+			vc.Add(key, &vcard.Field{Value: "data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(data)})
+			return nil
+		}
+
+		var capacityErr *CapacityError
+		if !errors.As(err, &capacityErr) || img.URL == "" {
+			return err
+		}
+	}
+
+	vc.Add(key, &vcard.Field{Value: img.URL})
+	return nil
 }
 
-func VCard(card vcard.Card) (*QR, error) {
+// formattedName returns FormattedName or name parts joined
+func (card *Card) formattedName() string {
+	if card.FormattedName != "" {
+		return card.FormattedName
+	}
+
+	return strings.Join(fx.Filter([]string{card.PrefixName, card.FirstName, card.MiddleName, card.LastName, card.SuffixName},
+		func(s string) bool { return s != "" }), " ")
+}
+
+// VCard generate QRCode from vcard as is
+func VCard(card vcard.Card) (*QR, error) { return VCardAs(card, "") }
+
+// VCardAs generate QRCode from vcard converting to the version; keep the version of card if version is empty
+func VCardAs(card vcard.Card, version string) (*QR, error) {
 	s := new(strings.Builder)
 
-	if err := vcard.NewEncoder(s).Encode(card); err != nil {
+	if err := encodeVCard(s, card, version); err != nil {
 		return nil, err
 	}
 
-	return Text(strings.TrimSpace(s.String()))
+	return fitQR(strings.TrimSpace(s.String()))
+}
+
+// MarshalVCard encode card as .vcf converting to the version; keep the version of card if version is empty
+func MarshalVCard(card vcard.Card, version string) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := encodeVCard(buf, card, version); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
 }
 
 type ICSVEvent struct {
@@ -273,3 +399,31 @@ func VEvent(evt *ical.VEvent) (*QR, error) {
 
 	return Text(buf.String())
 }
+
+func VTodo(todo *ical.VTodo) (*QR, error) {
+	buf := new(strings.Builder)
+	if err := ical.NewTodoEncoder(buf).Encode(todo); err != nil {
+		return nil, err
+	}
+
+	return Text(buf.String())
+}
+
+func VJournal(journal *ical.VJournal) (*QR, error) {
+	buf := new(strings.Builder)
+	if err := ical.NewJournalEncoder(buf).Encode(journal); err != nil {
+		return nil, err
+	}
+
+	return Text(buf.String())
+}
+
+// VCalendar returns QR of VCALENDAR; phone calendar apps import VCALENDAR rather than bare VEVENT
+func VCalendar(cal *ical.VCalendar) (*QR, error) {
+	buf := new(strings.Builder)
+	if err := ical.NewCalendarEncoder(buf).Encode(cal); err != nil {
+		return nil, err
+	}
+
+	return fitQR(strings.TrimSpace(buf.String()))
+}
@@ -210,6 +210,134 @@ func TestContact(t *testing.T) {
 	require.Regexp(t, `^BEGIN:VCARD`, s)
 }
 
+func TestContactVCard(t *testing.T) {
+	card := &Card{
+		LastName:     "Doe",
+		FirstName:    "John",
+		MiddleName:   "Q",
+		PrefixName:   "Dr.",
+		SuffixName:   "Jr.",
+		NickName:     "Johnny",
+		Company:      "ACME",
+		Department:   "R&D",
+		JobTitle:     "Engineer",
+		Email:        "john@example.com",
+		HomeEmail:    "john@home.com",
+		WorkEmail:    "john@work.com",
+		Tel:          "+82-2-000-0000",
+		Mobile:       "+82-10-0000-0000",
+		HomeTel:      "+82-2-111-1111",
+		WorkTel:      "+82-2-222-2222",
+		HomeFax:      "+82-2-333-3333",
+		WorkFax:      "+82-2-444-4444",
+		Pager:        "+82-15-0000",
+		HomeAddr:     Address{PostCode: "04524", CountryOrRegion: "Korea", City: "Seoul", Street: "Sejong-daero 110"},
+		WorkAddr:     Address{Province: "Gyeonggi", Street: "Street 1", Street2: "Suite 2"},
+		Homepage:     "https://example.com",
+		WorkHomepage: "https://work.example.com",
+		HomeHomepage: "https://home.example.com",
+		Note:         "note, with comma",
+		SocialProfiles: []SocialProfile{
+			{Type: "twitter", ID: "johndoe"},
+			{Type: "JabberInstant", ID: "john@jabber.org"},
+		},
+	}
+
+	qr, err := Contact(card)
+	require.NoError(t, err)
+
+	vc, err := vcard.NewDecoder(strings.NewReader(qr.Content)).Decode()
+	require.NoError(t, err)
+
+	value := func(key string, types ...string) string {
+		for _, f := range vc[key] {
+			if len(f.Params.Types()) != len(types) {
+				continue
+			}
+
+			matched := true
+			for _, typ := range types {
+				matched = matched && f.Params.HasType(typ)
+			}
+			if matched {
+				return f.Value
+			}
+		}
+		return ""
+	}
+
+	require.Equal(t, "4.0", vc.Value(vcard.FieldVersion))
+	require.Equal(t, "Dr. John Q Doe Jr.", vc.Value(vcard.FieldFormattedName))
+	require.Equal(t, &vcard.Name{
+		Field:           vc.Get(vcard.FieldName),
+		FamilyName:      "Doe",
+		GivenName:       "John",
+		AdditionalName:  "Q",
+		HonorificPrefix: "Dr.",
+		HonorificSuffix: "Jr.",
+	}, vc.Name())
+	require.Equal(t, card.NickName, vc.Value(vcard.FieldNickname))
+	require.Equal(t, "ACME;R&D", vc.Value(vcard.FieldOrganization))
+	require.Equal(t, card.JobTitle, vc.Value(vcard.FieldTitle))
+
+	require.Equal(t, card.Email, vc.PreferredValue(vcard.FieldEmail))
+	require.Equal(t, card.HomeEmail, value(vcard.FieldEmail, "HOME"))
+	require.Equal(t, card.WorkEmail, value(vcard.FieldEmail, "WORK"))
+
+	require.Equal(t, card.Mobile, vc.PreferredValue(vcard.FieldTelephone))
+	require.Equal(t, card.HomeTel, value(vcard.FieldTelephone, "HOME", "VOICE"))
+	require.Equal(t, card.WorkTel, value(vcard.FieldTelephone, "WORK", "VOICE"))
+	require.Equal(t, card.Tel, value(vcard.FieldTelephone, "MAIN"))
+	require.Equal(t, card.HomeFax, value(vcard.FieldTelephone, "HOME", "FAX"))
+	require.Equal(t, card.WorkFax, value(vcard.FieldTelephone, "WORK", "FAX"))
+	require.Equal(t, card.Pager, value(vcard.FieldTelephone, "PAGER"))
+
+	home := vc.Address()
+	require.Equal(t, "Sejong-daero 110", home.StreetAddress)
+	require.Equal(t, "Seoul", home.Locality)
+	require.Equal(t, "04524", home.PostalCode)
+	require.Equal(t, "Korea", home.Country)
+	require.Equal(t, card.WorkAddr.String(), value(vcard.FieldAddress, "WORK"))
+
+	require.Equal(t, card.Homepage, value(vcard.FieldURL))
+	require.Equal(t, card.WorkHomepage, value(vcard.FieldURL, "WORK"))
+	require.Equal(t, card.HomeHomepage, value(vcard.FieldURL, "HOME"))
+
+	require.Equal(t, "https://twitter.com/johndoe", value("X-SOCIALPROFILE", "twitter"))
+	require.Equal(t, "xmpp:john@jabber.org", vc.Value(vcard.FieldIMPP))
+	require.Equal(t, card.Note, vc.Value(vcard.FieldNote))
+}
+
+func TestContactFormattedName(t *testing.T) {
+	type args struct {
+		card *Card
+	}
+	tests := [...]struct {
+		name string
+		args args
+		want string
+	}{
+		{`empty`, args{&Card{}}, ""},
+		{`formatted`, args{&Card{FirstName: "John", LastName: "Doe", FormattedName: "J. Doe"}}, "J. Doe"},
+		{`first last`, args{&Card{FirstName: "John", LastName: "Doe"}}, "John Doe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qr, err := Contact(tt.args.card)
+			require.NoError(t, err)
+
+			vc, err := vcard.NewDecoder(strings.NewReader(qr.Content)).Decode()
+			require.NoError(t, err)
+			require.Equal(t, tt.want, vc.Value(vcard.FieldFormattedName))
+		})
+	}
+}
+
+func TestContactSocialProfile(t *testing.T) {
+	_, err := Contact(&Card{SocialProfiles: []SocialProfile{{Type: "unknown", ID: "id"}}})
+	require.Error(t, err)
+}
+
 func FuzzContact(f *testing.F) {
 	f.Add("Last Name", "middle name", "first name")
 	f.Fuzz(func(t *testing.T, lastName, middleName, firstName string) {
@@ -246,10 +374,22 @@ func TestVEvent(t *testing.T) {
 		wantErr bool
 	}{
 		{`valid`, args{&ical.VEvent{
-			Summary:     "summary",
-			Description: "동해물과 백두산이 마르고 닳도록 동해물과 백두산이 마르고 닳도록 동해물과 백두산이 마르고 닳도록 동해물과 백두산이 마르고 닳도록",
+			Summary:     ical.Text{Value: "summary"},
+			Description: ical.Text{Value: "동해물과 백두산이 마르고 닳도록 동해물과 백두산이 마르고 닳도록 동해물과 백두산이 마르고 닳도록 동해물과 백두산이 마르고 닳도록"},
 			DtStamp:     ical.DateTime{Time: time.Date(2023, 1, 16, 3, 4, 5, 0, time.UTC)},
 		}}, false},
+		{`multiple attendees`, args{&ical.VEvent{
+			Summary: ical.Text{Value: "weekly"},
+			DtStamp: ical.DateTime{Time: time.Date(2023, 1, 16, 3, 4, 5, 0, time.UTC)},
+			Attendees: []ical.CalAddress{
+				{Value: "mailto:john@example.com", Parameters: ical.Parameters{CN: "John"}},
+				{Value: "mailto:jane@example.com", Parameters: ical.Parameters{CN: "Jane"}},
+			},
+			ExDates: []ical.DateTime{
+				{Time: time.Date(2023, 1, 23, 9, 0, 0, 0, time.UTC)},
+				{Time: time.Date(2023, 1, 30, 9, 0, 0, 0, time.UTC)},
+			},
+		}}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -270,12 +410,82 @@ func TestVEvent(t *testing.T) {
 	}
 }
 
+func TestVTodo(t *testing.T) {
+	todo := &ical.VTodo{
+		Summary:         "Replace filter",
+		Due:             ical.DateTime{Time: time.Date(2023, 2, 1, 9, 0, 0, 0, time.UTC)},
+		DtStamp:         ical.DateTime{Time: time.Date(2023, 1, 16, 3, 4, 5, 0, time.UTC)},
+		PercentComplete: 20,
+	}
+
+	qr, err := VTodo(todo)
+	require.NoError(t, err)
+
+	img, err := qr.Render(200, 200)
+	require.NoError(t, err)
+
+	s, err := Decode(img)
+	require.NoError(t, err)
+	require.Regexp(t, `^BEGIN:VTODO`, s)
+
+	got := new(ical.VTodo)
+	require.NoError(t, ical.NewTodoDecoder(strings.NewReader(s)).Decode(got))
+	require.Equal(t, todo, got)
+}
+
+func TestVJournal(t *testing.T) {
+	journal := &ical.VJournal{
+		Summary:     "Filter replaced",
+		Description: "replaced air filter of unit #3",
+		DtStart:     ical.DateTime{Time: time.Date(2023, 2, 1, 9, 0, 0, 0, time.UTC)},
+		DtStamp:     ical.DateTime{Time: time.Date(2023, 1, 16, 3, 4, 5, 0, time.UTC)},
+	}
+
+	qr, err := VJournal(journal)
+	require.NoError(t, err)
+
+	img, err := qr.Render(200, 200)
+	require.NoError(t, err)
+
+	s, err := Decode(img)
+	require.NoError(t, err)
+	require.Regexp(t, `^BEGIN:VJOURNAL`, s)
+
+	got := new(ical.VJournal)
+	require.NoError(t, ical.NewJournalDecoder(strings.NewReader(s)).Decode(got))
+	require.Equal(t, journal, got)
+}
+
+func TestVCalendar(t *testing.T) {
+	cal := &ical.VCalendar{
+		Events: []*ical.VEvent{{
+			Summary: ical.Text{Value: "summary"},
+			DtStart: ical.DateTime{Time: time.Date(2023, 1, 17, 9, 0, 0, 0, time.UTC)},
+			DtStamp: ical.DateTime{Time: time.Date(2023, 1, 16, 3, 4, 5, 0, time.UTC)},
+		}},
+	}
+
+	qr, err := VCalendar(cal)
+	require.NoError(t, err)
+
+	img, err := qr.Render(200, 200)
+	require.NoError(t, err)
+
+	s, err := Decode(img)
+	require.NoError(t, err)
+	require.Regexp(t, "^BEGIN:VCALENDAR\r\nPRODID:.+\r\nVERSION:2.0\r\nBEGIN:VEVENT\r\n", s)
+
+	got := new(ical.VCalendar)
+	require.NoError(t, ical.NewCalendarDecoder(strings.NewReader(s)).Decode(got))
+	require.Equal(t, cal.Events, got.Events)
+}
+
 func FuzzVEvent(f *testing.F) {
 	f.Add("summary", "description", time.Now().Unix())
 	f.Fuzz(func(t *testing.T, summary, description string, dtStamp int64) {
 		evt := &ical.VEvent{
-			Summary:     summary,
-			Description: description,
+			Summary:     ical.Text{Value: summary},
+			Description: ical.Text{Value: description},
 			DtStamp:     ical.DateTime{Time: time.Unix(dtStamp, 0)},
 		}
 		qr, err := VEvent(evt)
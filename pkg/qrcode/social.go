@@ -0,0 +1,171 @@
+package qrcode
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/emersion/go-vcard"
+	"github.com/whitekid/goxp/validate"
+)
+
+// vCard property for social profiles
+const (
+	PropertySocialProfile = "X-SOCIALPROFILE"
+	PropertyIMPP          = vcard.FieldIMPP
+)
+
+// SocialNetwork describe how the social profile is represented in vCard
+//
+//	X-SOCIALPROFILE;TYPE=twitter;x-user=johndoe:https://twitter.com/johndoe
+//	IMPP;X-SERVICE-TYPE=Jabber:xmpp:johndoe@jabber.org
+type SocialNetwork struct {
+	Name     string            `validate:"required,max=100"` // TYPE of X-SOCIALPROFILE
+	URL      string            `validate:"required,max=200"` // url template; {id} is replaced by escaped id
+	Property string            `validate:"omitempty,oneof=X-SOCIALPROFILE IMPP"`
+	Params   map[string]string // vendor specific params; {id} is replaced by id
+}
+
+var defaultSocialNetworks = []*SocialNetwork{
+	{Name: "twitter", URL: "https://twitter.com/{id}", Params: map[string]string{"x-user": "{id}"}},
+	{Name: "facebook", URL: "https://www.facebook.com/{id}", Params: map[string]string{"x-user": "{id}"}},
+	{Name: "instagram", URL: "https://www.instagram.com/{id}", Params: map[string]string{"x-user": "{id}"}},
+	{Name: "linkedin", URL: "https://www.linkedin.com/in/{id}", Params: map[string]string{"x-user": "{id}"}},
+	{Name: "github", URL: "https://github.com/{id}", Params: map[string]string{"x-user": "{id}"}},
+	{Name: "youtube", URL: "https://www.youtube.com/@{id}"},
+	{Name: "flickr", URL: "https://www.flickr.com/photos/{id}", Params: map[string]string{"x-user": "{id}"}},
+	{Name: "myspace", URL: "https://myspace.com/{id}", Params: map[string]string{"x-user": "{id}"}},
+	{Name: "sinaweibo", URL: "https://weibo.com/n/{id}", Params: map[string]string{"x-user": "{id}"}},
+	{Name: "tencentweibo", URL: "https://t.qq.com/{id}", Params: map[string]string{"x-user": "{id}"}},
+	{Name: "yelp", URL: "https://www.yelp.com/user_details?userid={id}"},
+	{Name: "telegram", URL: "https://t.me/{id}"},
+	{Name: "jabber", URL: "xmpp:{id}", Property: PropertyIMPP, Params: map[string]string{"X-SERVICE-TYPE": "Jabber"}},
+	{Name: "skype", URL: "skype:{id}", Property: PropertyIMPP, Params: map[string]string{"X-SERVICE-TYPE": "Skype"}},
+}
+
+// socialNetworkAliases other names of social networks; resolved at lookup, so the alias follows the replaced network
+var socialNetworkAliases = map[string]string{
+	"jabberinstant": "jabber", // for backward compatibility
+}
+
+var socialNetworks = struct {
+	sync.RWMutex
+	m map[string]*SocialNetwork
+}{m: map[string]*SocialNetwork{}}
+
+func init() {
+	for _, network := range defaultSocialNetworks {
+		if err := RegisterSocialNetwork(network); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterSocialNetwork add or replace social network; name is case insensitive
+func RegisterSocialNetwork(network *SocialNetwork) error {
+	if err := validate.Struct(network); err != nil {
+		return err
+	}
+
+	if !strings.Contains(network.URL, "{id}") {
+		return fmt.Errorf("url template requires {id}: %s", network.URL)
+	}
+
+	socialNetworks.Lock()
+	defer socialNetworks.Unlock()
+
+	socialNetworks.m[strings.ToLower(network.Name)] = network
+	return nil
+}
+
+// LookupSocialNetwork returns registered social network
+func LookupSocialNetwork(name string) (*SocialNetwork, bool) {
+	socialNetworks.RLock()
+	defer socialNetworks.RUnlock()
+
+	name = strings.ToLower(name)
+	if network, ok := socialNetworks.m[name]; ok {
+		return network, true
+	}
+
+	network, ok := socialNetworks.m[socialNetworkAliases[name]]
+	return network, ok
+}
+
+// SocialNetworks returns names of registered social networks
+func SocialNetworks() []string {
+	socialNetworks.RLock()
+	defer socialNetworks.RUnlock()
+
+	names := make([]string, 0, len(socialNetworks.m))
+	for name := range socialNetworks.m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// LoadSocialNetworks register social networks from configuration
+// format: name=template[ name=template...]; separated by white spaces which never appear in url, unlike comma
+// template without http(s) scheme is represented as IMPP; ex) mastodon=https://mastodon.social/@{id} signal=sgnl:{id}
+func LoadSocialNetworks(spec string) error {
+	for _, item := range strings.Fields(spec) {
+		name, template, ok := strings.Cut(item, "=")
+		if !ok {
+			return fmt.Errorf("invalid social network: %s", item)
+		}
+
+		network := &SocialNetwork{Name: name, URL: template}
+		if !strings.HasPrefix(network.URL, "http://") && !strings.HasPrefix(network.URL, "https://") {
+			network.Property = PropertyIMPP
+		}
+
+		if err := RegisterSocialNetwork(network); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// field returns vCard property and field of the social profile
+// ID of unregistered social network is accepted if it is absolute http(s) url
+func (social *SocialProfile) field() (string, *vcard.Field, error) {
+	network, ok := LookupSocialNetwork(social.Type)
+	if !ok {
+		if u, err := url.Parse(social.ID); err == nil && social.Type != "" && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+			return PropertySocialProfile, &vcard.Field{
+				Value:  social.ID,
+				Params: vcard.Params{vcard.ParamType: {social.Type}},
+			}, nil
+		}
+
+		return "", nil, fmt.Errorf("unsupported social type: %s", social.Type)
+	}
+
+	if social.ID == "" {
+		return "", nil, fmt.Errorf("social profile id required: %s", social.Type)
+	}
+
+	field := &vcard.Field{
+		Value:  strings.ReplaceAll(network.URL, "{id}", url.PathEscape(social.ID)),
+		Params: vcard.Params{},
+	}
+
+	property := network.Property
+	if property == "" {
+		property = PropertySocialProfile
+	}
+	if property == PropertySocialProfile {
+		field.Params.Set(vcard.ParamType, network.Name)
+	}
+
+	for k, v := range network.Params {
+		field.Params.Set(k, strings.ReplaceAll(v, "{id}", social.ID))
+	}
+
+	return property, field, nil
+}
@@ -0,0 +1,122 @@
+package qrcode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSocialProfile(t *testing.T) {
+	type args struct {
+		social SocialProfile
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+		want    string
+	}{
+		{`twitter`, args{SocialProfile{Type: "twitter", ID: "johndoe"}}, false,
+			"X-SOCIALPROFILE;TYPE=twitter;x-user=johndoe:https://twitter.com/johndoe"},
+		{`case insensitive`, args{SocialProfile{Type: "LinkedIn", ID: "johndoe"}}, false,
+			"X-SOCIALPROFILE;TYPE=linkedin;x-user=johndoe:https://www.linkedin.com/in/johndoe"},
+		{`without vendor param`, args{SocialProfile{Type: "yelp", ID: "abc"}}, false,
+			"X-SOCIALPROFILE;TYPE=yelp:https://www.yelp.com/user_details?userid=abc"},
+		{`impp`, args{SocialProfile{Type: "jabber", ID: "john@jabber.org"}}, false,
+			"IMPP;X-SERVICE-TYPE=Jabber:xmpp:john@jabber.org"},
+		{`legacy jabber`, args{SocialProfile{Type: "JabberInstant", ID: "john@jabber.org"}}, false,
+			"IMPP;X-SERVICE-TYPE=Jabber:xmpp:john@jabber.org"},
+		{`escape id`, args{SocialProfile{Type: "github", ID: "john doe"}}, false,
+			"X-SOCIALPROFILE;TYPE=github;x-user=john doe:https://github.com/john%20doe"},
+		{`unregistered with url`, args{SocialProfile{Type: "mastodon", ID: "https://mastodon.social/@johndoe"}}, false,
+			"X-SOCIALPROFILE;TYPE=mastodon:https://mastodon.social/@johndoe"},
+		{`unregistered`, args{SocialProfile{Type: "mastodon", ID: "johndoe"}}, true, ""},
+		{`missing type`, args{SocialProfile{ID: "https://example.com"}}, true, ""},
+		{`missing id`, args{SocialProfile{Type: "twitter"}}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, field, err := tt.args.social.field()
+			require.Truef(t, (err != nil) == tt.wantErr, `field() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			require.Equal(t, tt.want, formatVCardLine(key, field))
+		})
+	}
+}
+
+func TestLoadSocialNetworks(t *testing.T) {
+	type args struct {
+		spec string
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+		social  SocialProfile
+		want    string
+	}{
+		{`empty`, args{""}, false, SocialProfile{Type: "twitter", ID: "johndoe"},
+			"X-SOCIALPROFILE;TYPE=twitter;x-user=johndoe:https://twitter.com/johndoe"},
+		{`url`, args{"test-mastodon=https://mastodon.social/@{id}"}, false, SocialProfile{Type: "test-mastodon", ID: "johndoe"},
+			"X-SOCIALPROFILE;TYPE=test-mastodon:https://mastodon.social/@johndoe"},
+		{`impp`, args{" test-signal=sgnl:{id}\ttest-matrix=matrix:u/{id} "}, false, SocialProfile{Type: "test-signal", ID: "johndoe"},
+			"IMPP:sgnl:johndoe"},
+		{`comma in template`, args{"test-comma=https://example.com/{id},profile"}, false, SocialProfile{Type: "test-comma", ID: "johndoe"},
+			"X-SOCIALPROFILE;TYPE=test-comma:https://example.com/johndoe,profile"},
+		{`missing template`, args{"test-invalid"}, true, SocialProfile{}, ""},
+		{`missing id`, args{"test-invalid=https://example.com"}, true, SocialProfile{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := LoadSocialNetworks(tt.args.spec)
+			require.Truef(t, (err != nil) == tt.wantErr, `LoadSocialNetworks() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			key, field, err := tt.social.field()
+			require.NoError(t, err)
+			require.Equal(t, tt.want, formatVCardLine(key, field))
+		})
+	}
+
+	require.Contains(t, SocialNetworks(), "test-matrix")
+	require.NotContains(t, SocialNetworks(), "jabberinstant")
+}
+
+// alias follows the network replaced by configuration
+func TestSocialNetworkAlias(t *testing.T) {
+	jabber, ok := LookupSocialNetwork("jabber")
+	require.True(t, ok)
+	defer func() { require.NoError(t, RegisterSocialNetwork(jabber)) }()
+
+	require.NoError(t, LoadSocialNetworks("jabber=xmpp:{id}?message"))
+
+	key, field, err := (&SocialProfile{Type: "jabberinstant", ID: "john@jabber.org"}).field()
+	require.NoError(t, err)
+	require.Equal(t, "IMPP:xmpp:john@jabber.org?message", formatVCardLine(key, field))
+}
+
+func TestContactStable(t *testing.T) {
+	card := &Card{
+		FirstName: "John",
+		SocialProfiles: []SocialProfile{
+			{Type: "twitter", ID: "johndoe"},
+			{Type: "jabber", ID: "john@jabber.org"},
+		},
+	}
+
+	want, err := Contact(card)
+	require.NoError(t, err)
+	require.True(t, strings.Contains(want.Content, "X-SOCIALPROFILE;TYPE=twitter;x-user=johndoe:https://twitter.com/johndoe\r\n"), want.Content)
+
+	for i := 0; i < 10; i++ {
+		got, err := Contact(card)
+		require.NoError(t, err)
+		require.Equal(t, want.Content, got.Content)
+	}
+}
@@ -0,0 +1,314 @@
+package qrcode
+
+import (
+	"fmt"
+	"io"
+	"mime/quotedprintable"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/emersion/go-vcard"
+	"github.com/pkg/errors"
+)
+
+// vCard versions
+const (
+	VCardVersion21 = "2.1"
+	VCardVersion30 = "3.0"
+	VCardVersion40 = "4.0"
+)
+
+// encodeVCard encode card as go-vcard does but with stable parameter order, TYPE first
+// go-vcard iterates params map, so the output changes on every call
+//
+// if version is given, VERSION is replaced and parameters, escaping and charset are converted to the version
+func encodeVCard(w io.Writer, card vcard.Card, version string) error {
+	format := formatVCardLine
+	switch version {
+	case "":
+		if card.Get(vcard.FieldVersion) == nil {
+			return errors.New("vcard: VERSION field missing")
+		}
+		version = card.Value(vcard.FieldVersion)
+	case VCardVersion21, VCardVersion30, VCardVersion40:
+		format = func(key string, field *vcard.Field) string { return formatVCardLineAs(key, field, version) }
+	default:
+		return fmt.Errorf("unsupported vcard version: %s", version)
+	}
+
+	lines := []string{"BEGIN:VCARD", vcard.FieldVersion + ":" + version}
+
+	keys := make([]string, 0, len(card))
+	for k := range card {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if strings.EqualFold(k, vcard.FieldVersion) {
+			continue
+		}
+
+		for _, field := range card[k] {
+			lines = append(lines, format(k, field))
+		}
+	}
+	lines = append(lines, "END:VCARD")
+
+	_, err := io.WriteString(w, strings.Join(lines, "\r\n")+"\r\n")
+	return err
+}
+
+var vcardValueFormatter = strings.NewReplacer("\\", "\\\\", "\n", "\\n", ",", "\\,")
+
+// list separators are written as is
+var vcardListValueFormatter = strings.NewReplacer("\\", "\\\\", "\n", "\\n")
+
+// formatVCardValue returns escaped value of the property
+func formatVCardValue(key, value string) string {
+	switch {
+	case vcardURIProperties[key]:
+		return value
+	case vcardListProperties[key]:
+		return vcardListValueFormatter.Replace(value)
+	}
+	return vcardValueFormatter.Replace(value)
+}
+
+func formatVCardLine(key string, field *vcard.Field) string {
+	params := make([]string, 0, len(field.Params))
+	for _, k := range sortedParamKeys(field.Params) {
+		for _, v := range field.Params[k] {
+			params = append(params, k+"="+vcardValueFormatter.Replace(v))
+		}
+	}
+
+	return joinVCardLine(field.Group, key, params, formatVCardValue(strings.ToUpper(key), field.Value))
+}
+
+// formatVCardLineAs format line converting parameters, escaping and charset to the version
+//
+//	4.0: TEL;TYPE=cell,voice;PREF=1:...
+//	3.0: TEL;TYPE=CELL,VOICE,PREF:...
+//	2.1: TEL;CELL;VOICE;PREF:...
+func formatVCardLineAs(key string, field *vcard.Field, version string) string {
+	key = strings.ToUpper(key)
+	field = decodeVCardField(field)
+	if key == vcard.FieldPhoto || key == vcard.FieldLogo {
+		field = convertImageField(field, version)
+	}
+
+	types, pref := []string{}, ""
+	for _, v := range field.Params[vcard.ParamType] {
+		for _, typ := range strings.Split(v, ",") {
+			switch {
+			case typ == "":
+			case strings.EqualFold(typ, "pref"):
+				pref = "1"
+			default:
+				types = append(types, typ)
+			}
+		}
+	}
+	if p := field.Params.Get(vcard.ParamPreferred); p != "" {
+		pref = p
+	}
+
+	params := []string{}
+	switch version {
+	case VCardVersion40:
+		if len(types) > 0 {
+			params = append(params, vcard.ParamType+"="+joinParamValues(types, strings.ToLower))
+		}
+		if pref != "" {
+			params = append(params, vcard.ParamPreferred+"="+pref)
+		}
+	case VCardVersion30:
+		if pref != "" {
+			types = append(types, "PREF")
+		}
+		if len(types) > 0 {
+			params = append(params, vcard.ParamType+"="+joinParamValues(types, strings.ToUpper))
+		}
+	case VCardVersion21:
+		if pref != "" {
+			types = append(types, "PREF")
+		}
+		for _, typ := range types {
+			params = append(params, strings.ToUpper(typ))
+		}
+	}
+
+	for _, k := range sortedParamKeys(field.Params) {
+		if k == vcard.ParamType || k == vcard.ParamPreferred {
+			continue
+		}
+
+		for _, v := range field.Params[k] {
+			params = append(params, k+"="+vcardValueFormatter.Replace(v))
+		}
+	}
+
+	value := field.Value
+	switch {
+	case version == VCardVersion21:
+		// 2.1 has no escaping; newline requires quoted-printable and non-ascii requires charset
+		if !isASCII(value) {
+			params = append(params, "CHARSET=UTF-8")
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			params = append(params, "ENCODING=QUOTED-PRINTABLE")
+			value = quotedPrintable(value)
+		}
+	default:
+		value = formatVCardValue(key, value)
+	}
+
+	return joinVCardLine(field.Group, key, params, value)
+}
+
+// decodeVCardField returns field with quoted-printable value decoded and CHARSET removed
+// 3.0 and 4.0 are always UTF-8, 2.1 charset is added on encoding
+func decodeVCardField(field *vcard.Field) *vcard.Field {
+	decoded := &vcard.Field{Value: field.Value, Group: field.Group, Params: vcard.Params{}}
+	for k, v := range field.Params {
+		switch {
+		case k == "CHARSET":
+		case k == "ENCODING" && strings.EqualFold(field.Params.Get(k), "QUOTED-PRINTABLE"):
+			if b, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(field.Value))); err == nil {
+				decoded.Value = strings.ReplaceAll(string(b), "\r\n", "\n")
+			}
+		default:
+			decoded.Params[k] = v
+		}
+	}
+
+	return decoded
+}
+
+// joinParamValues returns comma separated escaped param values
+func joinParamValues(values []string, fn func(string) string) string {
+	escaped := make([]string, len(values))
+	for i, v := range values {
+		escaped[i] = vcardValueFormatter.Replace(fn(v))
+	}
+	return strings.Join(escaped, ",")
+}
+
+func joinVCardLine(group, key string, params []string, value string) string {
+	s := new(strings.Builder)
+	if group != "" {
+		s.WriteString(group + ".")
+	}
+	s.WriteString(key)
+	for _, param := range params {
+		s.WriteString(";" + param)
+	}
+	s.WriteString(":" + value)
+	return s.String()
+}
+
+// sortedParamKeys returns param keys, TYPE first
+func sortedParamKeys(params vcard.Params) []string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if (keys[i] == vcard.ParamType) != (keys[j] == vcard.ParamType) {
+			return keys[i] == vcard.ParamType
+		}
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+// properties with uri value which is not escaped; data:image/jpeg;base64,... is broken if comma is escaped
+var vcardURIProperties = map[string]bool{
+	vcard.FieldPhoto:      true,
+	vcard.FieldLogo:       true,
+	vcard.FieldSound:      true,
+	vcard.FieldURL:        true,
+	vcard.FieldIMPP:       true,
+	PropertySocialProfile: true,
+}
+
+// properties of which components are comma separated lists; ',' in the value is a list separator
+//
+//	N:Doe;John;;Mr.,Dr.;
+//	NICKNAME:JD,Johnny
+var vcardListProperties = map[string]bool{
+	vcard.FieldName:       true,
+	vcard.FieldAddress:    true,
+	vcard.FieldNickname:   true,
+	vcard.FieldCategories: true,
+}
+
+// convertImageField convert PHOTO, LOGO of any version to the version
+//
+//	4.0: PHOTO:data:image/jpeg;base64,... or PHOTO:https://...
+//	3.0: PHOTO;TYPE=JPEG;ENCODING=b:... or PHOTO;VALUE=uri:https://...
+//	2.1: PHOTO;JPEG;ENCODING=BASE64:... or PHOTO;VALUE=URL:https://...
+func convertImageField(field *vcard.Field, version string) *vcard.Field {
+	subtype, data := "", ""
+	encoding := strings.ToLower(field.Params.Get("ENCODING"))
+	switch {
+	case strings.HasPrefix(field.Value, "data:"):
+		mediaType, encoded, ok := strings.Cut(strings.TrimPrefix(field.Value, "data:"), ",")
+		if !ok || !strings.HasSuffix(mediaType, ";base64") {
+			return field
+		}
+		subtype, data = strings.TrimPrefix(strings.TrimSuffix(mediaType, ";base64"), "image/"), encoded
+	case encoding == "b" || encoding == "base64":
+		subtype, data = field.Params.Get(vcard.ParamType), field.Value
+		if subtype == "" {
+			// 2.1 bare type is not parsed by go-vcard
+			subtype = "jpeg"
+		}
+	}
+
+	if data == "" {
+		converted := &vcard.Field{Value: field.Value, Group: field.Group}
+		switch version {
+		case VCardVersion30:
+			converted.Params = vcard.Params{vcard.ParamValue: {"uri"}}
+		case VCardVersion21:
+			converted.Params = vcard.Params{vcard.ParamValue: {"URL"}}
+		}
+		return converted
+	}
+
+	switch version {
+	case VCardVersion30:
+		return &vcard.Field{Value: data, Group: field.Group, Params: vcard.Params{vcard.ParamType: {strings.ToUpper(subtype)}, "ENCODING": {"b"}}}
+	case VCardVersion21:
+		return &vcard.Field{Value: data, Group: field.Group, Params: vcard.Params{vcard.ParamType: {strings.ToUpper(subtype)}, "ENCODING": {"BASE64"}}}
+	}
+
+	return &vcard.Field{Value: "data:image/" + strings.ToLower(subtype) + ";base64," + data, Group: field.Group}
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+// quotedPrintable encode without soft line break
+func quotedPrintable(s string) string {
+	b := new(strings.Builder)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '=' || c < ' ' || c >= 0x7f {
+			b.WriteString("=" + strings.ToUpper(strconv.FormatInt(int64(c)|0x100, 16)[1:]))
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
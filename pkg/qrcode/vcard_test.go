@@ -0,0 +1,143 @@
+package qrcode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/emersion/go-vcard"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVCardAs(t *testing.T) {
+	card := vcard.Card{
+		vcard.FieldVersion:       {{Value: "4.0"}},
+		vcard.FieldName:          {{Value: "홍;길동;;;"}},
+		vcard.FieldFormattedName: {{Value: "홍길동"}},
+		vcard.FieldTelephone: {
+			{Value: "010-1234-5678", Params: vcard.Params{vcard.ParamType: {"CELL", "VOICE", "pref"}}},
+			{Value: "02-123-4567", Params: vcard.Params{vcard.ParamType: {"work,voice"}}},
+		},
+		vcard.FieldNote:  {{Value: "line1\nline2, =end"}},
+		vcard.FieldPhoto: {{Value: "data:image/jpeg;base64,/9j/4AAQ"}},
+		vcard.FieldURL:   {{Value: "https://example.com/a,b", Params: vcard.Params{vcard.ParamPreferred: {"2"}}}},
+	}
+
+	type args struct {
+		version string
+	}
+	tests := [...]struct {
+		name    string
+		args    args
+		wantErr bool
+		want    string
+	}{
+		{`as is`, args{""}, false, `BEGIN:VCARD
+VERSION:4.0
+FN:홍길동
+N:홍;길동;;;
+NOTE:line1\nline2\, =end
+PHOTO:data:image/jpeg;base64,/9j/4AAQ
+TEL;TYPE=CELL;TYPE=VOICE;TYPE=pref:010-1234-5678
+TEL;TYPE=work\,voice:02-123-4567
+URL;PREF=2:https://example.com/a,b
+END:VCARD`},
+		{`4.0`, args{VCardVersion40}, false, `BEGIN:VCARD
+VERSION:4.0
+FN:홍길동
+N:홍;길동;;;
+NOTE:line1\nline2\, =end
+PHOTO:data:image/jpeg;base64,/9j/4AAQ
+TEL;TYPE=cell,voice;PREF=1:010-1234-5678
+TEL;TYPE=work,voice:02-123-4567
+URL;PREF=2:https://example.com/a,b
+END:VCARD`},
+		{`3.0`, args{VCardVersion30}, false, `BEGIN:VCARD
+VERSION:3.0
+FN:홍길동
+N:홍;길동;;;
+NOTE:line1\nline2\, =end
+PHOTO;TYPE=JPEG;ENCODING=b:/9j/4AAQ
+TEL;TYPE=CELL,VOICE,PREF:010-1234-5678
+TEL;TYPE=WORK,VOICE:02-123-4567
+URL;TYPE=PREF:https://example.com/a,b
+END:VCARD`},
+		{`2.1`, args{VCardVersion21}, false, `BEGIN:VCARD
+VERSION:2.1
+FN;CHARSET=UTF-8:홍길동
+N;CHARSET=UTF-8:홍;길동;;;
+NOTE;ENCODING=QUOTED-PRINTABLE:line1=0Aline2, =3Dend
+PHOTO;JPEG;ENCODING=BASE64:/9j/4AAQ
+TEL;CELL;VOICE;PREF:010-1234-5678
+TEL;WORK;VOICE:02-123-4567
+URL;PREF:https://example.com/a,b
+END:VCARD`},
+		{`unsupported`, args{"5.0"}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qr, err := VCardAs(card, tt.args.version)
+			require.Truef(t, (err != nil) == tt.wantErr, `VCardAs() failed: error = %+v, wantErr = %v`, err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			require.Equal(t, strings.ReplaceAll(tt.want, "\n", "\r\n"), qr.Content)
+		})
+	}
+}
+
+func TestVCardAsRoundTrip(t *testing.T) {
+	type args struct {
+		content string
+		version string
+	}
+	tests := [...]struct {
+		name string
+		args args
+		want string
+	}{
+		{`2.1 to 4.0`, args{`BEGIN:VCARD
+VERSION:2.1
+N;CHARSET=UTF-8:홍;길동;;;
+NOTE;CHARSET=UTF-8;ENCODING=QUOTED-PRINTABLE:line1=0D=0Aline2
+PHOTO;VALUE=URL:https://example.com/photo.jpg
+END:VCARD`, VCardVersion40}, `BEGIN:VCARD
+VERSION:4.0
+N:홍;길동;;;
+NOTE:line1\nline2
+PHOTO:https://example.com/photo.jpg
+END:VCARD`},
+		{`3.0 to 4.0`, args{`BEGIN:VCARD
+VERSION:3.0
+N:Doe;John;;;
+PHOTO;ENCODING=b;TYPE=PNG:iVBORw0K
+EMAIL;TYPE=INTERNET,HOME,PREF:john@example.com
+END:VCARD`, VCardVersion40}, `BEGIN:VCARD
+VERSION:4.0
+EMAIL;TYPE=internet,home;PREF=1:john@example.com
+N:Doe;John;;;
+PHOTO:data:image/png;base64,iVBORw0K
+END:VCARD`},
+		{`4.0 to 2.1`, args{`BEGIN:VCARD
+VERSION:4.0
+N:Doe;John;;;
+PHOTO:https://example.com/photo.jpg
+EMAIL;TYPE=home;PREF=1:john@example.com
+END:VCARD`, VCardVersion21}, `BEGIN:VCARD
+VERSION:2.1
+EMAIL;HOME;PREF:john@example.com
+N:Doe;John;;;
+PHOTO;VALUE=URL:https://example.com/photo.jpg
+END:VCARD`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card, err := vcard.NewDecoder(strings.NewReader(tt.args.content)).Decode()
+			require.NoError(t, err)
+
+			qr, err := VCardAs(card, tt.args.version)
+			require.NoError(t, err)
+			require.Equal(t, strings.ReplaceAll(tt.want, "\n", "\r\n"), qr.Content)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+type boltStore struct {
+	db      *bolt.DB
+	options *options
+}
+
+var _ Interface = (*boltStore)(nil)
+
+// NewBolt returns store of bbolt database file; the file is created if not exists
+func NewBolt(path string, opts ...Option) (Interface, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open store: %s: %w", path, err)
+	}
+
+	return &boltStore{db: db, options: newOptions(opts)}, nil
+}
+
+func (s *boltStore) Put(ctx context.Context, bucket string, value []byte) (string, error) {
+	var id string
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		if err != nil {
+			return err
+		}
+
+		// sequence of the bucket counts records; records are never deleted
+		if limit := s.options.maxRecords; limit > 0 && b.Sequence() >= uint64(limit) {
+			return ErrQuotaExceeded
+		}
+		if _, err := b.NextSequence(); err != nil {
+			return err
+		}
+
+		id = newID()
+		for b.Get([]byte(id)) != nil {
+			id = newID()
+		}
+		return b.Put([]byte(id), value)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (s *boltStore) Get(ctx context.Context, bucket, id string) ([]byte, error) {
+	var value []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return ErrNotFound
+		}
+
+		v := b.Get([]byte(id))
+		if v == nil {
+			return ErrNotFound
+		}
+		// v is valid only in the transaction
+		value = append([]byte{}, v...)
+		return nil
+	})
+
+	return value, err
+}
+
+func (s *boltStore) Update(ctx context.Context, bucket, id string, value []byte) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil || b.Get([]byte(id)) == nil {
+			return ErrNotFound
+		}
+		return b.Put([]byte(id), value)
+	})
+}
+
+func (s *boltStore) Close() error { return s.db.Close() }
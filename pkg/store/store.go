@@ -0,0 +1,111 @@
+package store
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"sync"
+
+	"github.com/whitekid/goxp"
+)
+
+// Interface stores values in buckets by generated short id
+type Interface interface {
+	Put(ctx context.Context, bucket string, value []byte) (string, error)
+	Get(ctx context.Context, bucket, id string) ([]byte, error)
+	Update(ctx context.Context, bucket, id string, value []byte) error // ErrNotFound if id does not exist
+	Close() error
+}
+
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrQuotaExceeded = errors.New("quota exceeded")
+)
+
+type options struct {
+	maxRecords int
+}
+
+// Option configures store
+type Option func(o *options)
+
+// WithMaxRecords limits records of a bucket; Put fails with ErrQuotaExceeded over the limit, unlimited if 0
+// records are never deleted, so the limit bounds the size of the store with the size of a record
+func WithMaxRecords(n int) Option { return func(o *options) { o.maxRecords = n } }
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// New returns store of bbolt database file; in memory if path is empty
+func New(path string, opts ...Option) (Interface, error) {
+	if path == "" {
+		return NewMemory(opts...), nil
+	}
+	return NewBolt(path, opts...)
+}
+
+// newID returns URL safe short id
+func newID() string { return base64.RawURLEncoding.EncodeToString(goxp.RandomByte(6)) }
+
+type memoryStore struct {
+	mu      sync.RWMutex
+	buckets map[string]map[string][]byte
+	options *options
+}
+
+var _ Interface = (*memoryStore)(nil)
+
+// NewMemory returns store in memory; values are lost on restart
+func NewMemory(opts ...Option) Interface {
+	return &memoryStore{buckets: map[string]map[string][]byte{}, options: newOptions(opts)}
+}
+
+func (s *memoryStore) Put(ctx context.Context, bucket string, value []byte) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values, ok := s.buckets[bucket]
+	if !ok {
+		values = map[string][]byte{}
+		s.buckets[bucket] = values
+	}
+	if limit := s.options.maxRecords; limit > 0 && len(values) >= limit {
+		return "", ErrQuotaExceeded
+	}
+
+	id := newID()
+	for values[id] != nil {
+		id = newID()
+	}
+	values[id] = append([]byte{}, value...)
+	return id, nil
+}
+
+func (s *memoryStore) Get(ctx context.Context, bucket, id string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	value, ok := s.buckets[bucket][id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]byte{}, value...), nil
+}
+
+func (s *memoryStore) Update(ctx context.Context, bucket, id string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.buckets[bucket][id]; !ok {
+		return ErrNotFound
+	}
+	s.buckets[bucket][id] = append([]byte{}, value...)
+	return nil
+}
+
+func (s *memoryStore) Close() error { return nil }
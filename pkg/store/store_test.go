@@ -0,0 +1,115 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	type args struct {
+		path string
+	}
+	tests := [...]struct {
+		name string
+		args args
+	}{
+		{`memory`, args{""}},
+		{`bolt`, args{filepath.Join(t.TempDir(), "qrcodeapi.db")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			s, err := New(tt.args.path)
+			require.NoError(t, err)
+			defer s.Close()
+
+			id, err := s.Put(ctx, "events", []byte("BEGIN:VCALENDAR"))
+			require.NoError(t, err)
+			require.Len(t, id, 8)
+
+			other, err := s.Put(ctx, "events", []byte("BEGIN:VCALENDAR"))
+			require.NoError(t, err)
+			require.NotEqual(t, id, other)
+
+			got, err := s.Get(ctx, "events", id)
+			require.NoError(t, err)
+			require.Equal(t, []byte("BEGIN:VCALENDAR"), got)
+
+			require.NoError(t, s.Update(ctx, "events", id, []byte("BEGIN:VCALENDAR\r\n")))
+			got, err = s.Get(ctx, "events", id)
+			require.NoError(t, err)
+			require.Equal(t, []byte("BEGIN:VCALENDAR\r\n"), got)
+
+			_, err = s.Get(ctx, "contacts", id)
+			require.ErrorIs(t, err, ErrNotFound)
+
+			_, err = s.Get(ctx, "events", "unknown")
+			require.ErrorIs(t, err, ErrNotFound)
+
+			require.ErrorIs(t, s.Update(ctx, "events", "unknown", nil), ErrNotFound)
+			require.ErrorIs(t, s.Update(ctx, "contacts", id, nil), ErrNotFound)
+		})
+	}
+}
+
+func TestStoreQuota(t *testing.T) {
+	type args struct {
+		path string
+	}
+	tests := [...]struct {
+		name string
+		args args
+	}{
+		{`memory`, args{""}},
+		{`bolt`, args{filepath.Join(t.TempDir(), "qrcodeapi.db")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			s, err := New(tt.args.path, WithMaxRecords(2))
+			require.NoError(t, err)
+			defer s.Close()
+
+			id, err := s.Put(ctx, "events", []byte("BEGIN:VCALENDAR"))
+			require.NoError(t, err)
+			_, err = s.Put(ctx, "events", []byte("BEGIN:VCALENDAR"))
+			require.NoError(t, err)
+
+			_, err = s.Put(ctx, "events", []byte("BEGIN:VCALENDAR"))
+			require.ErrorIs(t, err, ErrQuotaExceeded)
+
+			// quota is per bucket, and records are updated over the quota
+			_, err = s.Put(ctx, "contacts", []byte("BEGIN:VCARD"))
+			require.NoError(t, err)
+			require.NoError(t, s.Update(ctx, "events", id, []byte("BEGIN:VCALENDAR\r\n")))
+		})
+	}
+}
+
+func TestBoltReopen(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "qrcodeapi.db")
+	s, err := NewBolt(path)
+	require.NoError(t, err)
+
+	id, err := s.Put(ctx, "contacts", []byte("BEGIN:VCARD"))
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+
+	s, err = NewBolt(path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	got, err := s.Get(ctx, "contacts", id)
+	require.NoError(t, err)
+	require.Equal(t, []byte("BEGIN:VCARD"), got)
+}
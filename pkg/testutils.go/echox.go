@@ -7,9 +7,11 @@ import (
 	"github.com/whitekid/echox"
 )
 
-func NewTestServer(ctx context.Context, r echox.Router) *httptest.Server {
+func NewTestServer(ctx context.Context, routers ...echox.Router) *httptest.Server {
 	e := echox.New()
-	e.Route(nil, r)
+	for _, r := range routers {
+		e.Route(nil, r)
+	}
 
 	ts := httptest.NewServer(e)
 	go func() {
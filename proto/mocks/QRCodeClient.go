@@ -20,6 +20,138 @@ type QRCodeClient struct {
 	mock.Mock
 }
 
+// Contact provides a mock function with given fields: ctx, in, opts
+func (_m *QRCodeClient) Contact(ctx context.Context, in *proto.ContactRequest, opts ...grpc.CallOption) (*proto.Response, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *proto.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.ContactRequest, ...grpc.CallOption) (*proto.Response, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.ContactRequest, ...grpc.CallOption) *proto.Response); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proto.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proto.ContactRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Crypto provides a mock function with given fields: ctx, in, opts
+func (_m *QRCodeClient) Crypto(ctx context.Context, in *proto.CryptoRequest, opts ...grpc.CallOption) (*proto.Response, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *proto.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.CryptoRequest, ...grpc.CallOption) (*proto.Response, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.CryptoRequest, ...grpc.CallOption) *proto.Response); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proto.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proto.CryptoRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Emvco provides a mock function with given fields: ctx, in, opts
+func (_m *QRCodeClient) Emvco(ctx context.Context, in *proto.EMVCoRequest, opts ...grpc.CallOption) (*proto.Response, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *proto.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.EMVCoRequest, ...grpc.CallOption) (*proto.Response, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.EMVCoRequest, ...grpc.CallOption) *proto.Response); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proto.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proto.EMVCoRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Event provides a mock function with given fields: ctx, in, opts
+func (_m *QRCodeClient) Event(ctx context.Context, in *proto.EventRequest, opts ...grpc.CallOption) (*proto.Response, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *proto.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.EventRequest, ...grpc.CallOption) (*proto.Response, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.EventRequest, ...grpc.CallOption) *proto.Response); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proto.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proto.EventRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Generate provides a mock function with given fields: ctx, in, opts
 func (_m *QRCodeClient) Generate(ctx context.Context, in *proto.Request, opts ...grpc.CallOption) (*proto.Response, error) {
 	_va := make([]interface{}, len(opts))
@@ -53,6 +185,72 @@ func (_m *QRCodeClient) Generate(ctx context.Context, in *proto.Request, opts ..
 	return r0, r1
 }
 
+// Otp provides a mock function with given fields: ctx, in, opts
+func (_m *QRCodeClient) Otp(ctx context.Context, in *proto.OTPRequest, opts ...grpc.CallOption) (*proto.OTPResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *proto.OTPResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.OTPRequest, ...grpc.CallOption) (*proto.OTPResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.OTPRequest, ...grpc.CallOption) *proto.OTPResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proto.OTPResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proto.OTPRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Vcard provides a mock function with given fields: ctx, in, opts
+func (_m *QRCodeClient) Vcard(ctx context.Context, in *proto.VCardRequest, opts ...grpc.CallOption) (*proto.Response, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *proto.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.VCardRequest, ...grpc.CallOption) (*proto.Response, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *proto.VCardRequest, ...grpc.CallOption) *proto.Response); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*proto.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *proto.VCardRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Version provides a mock function with given fields: ctx, in, opts
 func (_m *QRCodeClient) Version(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*wrapperspb.StringValue, error) {
 	_va := make([]interface{}, len(opts))
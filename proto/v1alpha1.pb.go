@@ -172,6 +172,1988 @@ func (x *Response) GetImage() []byte {
 	return nil
 }
 
+// EMVCo data object
+type TLV struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *TLV) Reset() {
+	*x = TLV{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha1_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TLV) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TLV) ProtoMessage() {}
+
+func (x *TLV) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TLV.ProtoReflect.Descriptor instead.
+func (*TLV) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TLV) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TLV) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type MerchantAccount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Value  string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"` // primitive value, 02~25
+	Guid   string `protobuf:"bytes,3,opt,name=guid,proto3" json:"guid,omitempty"`   // template, 26~51
+	Fields []*TLV `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`
+}
+
+func (x *MerchantAccount) Reset() {
+	*x = MerchantAccount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha1_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MerchantAccount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MerchantAccount) ProtoMessage() {}
+
+func (x *MerchantAccount) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MerchantAccount.ProtoReflect.Descriptor instead.
+func (*MerchantAccount) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MerchantAccount) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MerchantAccount) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *MerchantAccount) GetGuid() string {
+	if x != nil {
+		return x.Guid
+	}
+	return ""
+}
+
+func (x *MerchantAccount) GetFields() []*TLV {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type EMVCoAdditionalData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BillNumber          string `protobuf:"bytes,1,opt,name=bill_number,json=billNumber,proto3" json:"bill_number,omitempty"`
+	MobileNumber        string `protobuf:"bytes,2,opt,name=mobile_number,json=mobileNumber,proto3" json:"mobile_number,omitempty"`
+	StoreLabel          string `protobuf:"bytes,3,opt,name=store_label,json=storeLabel,proto3" json:"store_label,omitempty"`
+	LoyaltyNumber       string `protobuf:"bytes,4,opt,name=loyalty_number,json=loyaltyNumber,proto3" json:"loyalty_number,omitempty"`
+	ReferenceLabel      string `protobuf:"bytes,5,opt,name=reference_label,json=referenceLabel,proto3" json:"reference_label,omitempty"`
+	CustomerLabel       string `protobuf:"bytes,6,opt,name=customer_label,json=customerLabel,proto3" json:"customer_label,omitempty"`
+	TerminalLabel       string `protobuf:"bytes,7,opt,name=terminal_label,json=terminalLabel,proto3" json:"terminal_label,omitempty"`
+	Purpose             string `protobuf:"bytes,8,opt,name=purpose,proto3" json:"purpose,omitempty"`
+	ConsumerDataRequest string `protobuf:"bytes,9,opt,name=consumer_data_request,json=consumerDataRequest,proto3" json:"consumer_data_request,omitempty"`
+	Fields              []*TLV `protobuf:"bytes,10,rep,name=fields,proto3" json:"fields,omitempty"`
+}
+
+func (x *EMVCoAdditionalData) Reset() {
+	*x = EMVCoAdditionalData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha1_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EMVCoAdditionalData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EMVCoAdditionalData) ProtoMessage() {}
+
+func (x *EMVCoAdditionalData) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EMVCoAdditionalData.ProtoReflect.Descriptor instead.
+func (*EMVCoAdditionalData) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EMVCoAdditionalData) GetBillNumber() string {
+	if x != nil {
+		return x.BillNumber
+	}
+	return ""
+}
+
+func (x *EMVCoAdditionalData) GetMobileNumber() string {
+	if x != nil {
+		return x.MobileNumber
+	}
+	return ""
+}
+
+func (x *EMVCoAdditionalData) GetStoreLabel() string {
+	if x != nil {
+		return x.StoreLabel
+	}
+	return ""
+}
+
+func (x *EMVCoAdditionalData) GetLoyaltyNumber() string {
+	if x != nil {
+		return x.LoyaltyNumber
+	}
+	return ""
+}
+
+func (x *EMVCoAdditionalData) GetReferenceLabel() string {
+	if x != nil {
+		return x.ReferenceLabel
+	}
+	return ""
+}
+
+func (x *EMVCoAdditionalData) GetCustomerLabel() string {
+	if x != nil {
+		return x.CustomerLabel
+	}
+	return ""
+}
+
+func (x *EMVCoAdditionalData) GetTerminalLabel() string {
+	if x != nil {
+		return x.TerminalLabel
+	}
+	return ""
+}
+
+func (x *EMVCoAdditionalData) GetPurpose() string {
+	if x != nil {
+		return x.Purpose
+	}
+	return ""
+}
+
+func (x *EMVCoAdditionalData) GetConsumerDataRequest() string {
+	if x != nil {
+		return x.ConsumerDataRequest
+	}
+	return ""
+}
+
+func (x *EMVCoAdditionalData) GetFields() []*TLV {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type EMVCoLanguage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Preference   string `protobuf:"bytes,1,opt,name=preference,proto3" json:"preference,omitempty"`
+	MerchantName string `protobuf:"bytes,2,opt,name=merchant_name,json=merchantName,proto3" json:"merchant_name,omitempty"`
+	MerchantCity string `protobuf:"bytes,3,opt,name=merchant_city,json=merchantCity,proto3" json:"merchant_city,omitempty"`
+}
+
+func (x *EMVCoLanguage) Reset() {
+	*x = EMVCoLanguage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha1_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EMVCoLanguage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EMVCoLanguage) ProtoMessage() {}
+
+func (x *EMVCoLanguage) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EMVCoLanguage.ProtoReflect.Descriptor instead.
+func (*EMVCoLanguage) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *EMVCoLanguage) GetPreference() string {
+	if x != nil {
+		return x.Preference
+	}
+	return ""
+}
+
+func (x *EMVCoLanguage) GetMerchantName() string {
+	if x != nil {
+		return x.MerchantName
+	}
+	return ""
+}
+
+func (x *EMVCoLanguage) GetMerchantCity() string {
+	if x != nil {
+		return x.MerchantCity
+	}
+	return ""
+}
+
+// EMVCo merchant-presented mode
+type EMVCoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InitiationMethod  string               `protobuf:"bytes,1,opt,name=initiation_method,json=initiationMethod,proto3" json:"initiation_method,omitempty"`
+	MerchantAccounts  []*MerchantAccount   `protobuf:"bytes,2,rep,name=merchant_accounts,json=merchantAccounts,proto3" json:"merchant_accounts,omitempty"`
+	MerchantCategory  string               `protobuf:"bytes,3,opt,name=merchant_category,json=merchantCategory,proto3" json:"merchant_category,omitempty"`
+	Currency          string               `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	Amount            string               `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	TipIndicator      string               `protobuf:"bytes,6,opt,name=tip_indicator,json=tipIndicator,proto3" json:"tip_indicator,omitempty"`
+	ConvenienceFee    string               `protobuf:"bytes,7,opt,name=convenience_fee,json=convenienceFee,proto3" json:"convenience_fee,omitempty"`
+	ConvenienceFeePct string               `protobuf:"bytes,8,opt,name=convenience_fee_pct,json=convenienceFeePct,proto3" json:"convenience_fee_pct,omitempty"`
+	CountryCode       string               `protobuf:"bytes,9,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	MerchantName      string               `protobuf:"bytes,10,opt,name=merchant_name,json=merchantName,proto3" json:"merchant_name,omitempty"`
+	MerchantCity      string               `protobuf:"bytes,11,opt,name=merchant_city,json=merchantCity,proto3" json:"merchant_city,omitempty"`
+	PostalCode        string               `protobuf:"bytes,12,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	AdditionalData    *EMVCoAdditionalData `protobuf:"bytes,13,opt,name=additional_data,json=additionalData,proto3" json:"additional_data,omitempty"`
+	Language          *EMVCoLanguage       `protobuf:"bytes,14,opt,name=language,proto3" json:"language,omitempty"`
+	Fields            []*TLV               `protobuf:"bytes,15,rep,name=fields,proto3" json:"fields,omitempty"`
+	Width             int32                `protobuf:"varint,16,opt,name=width,proto3" json:"width,omitempty"`
+	Height            int32                `protobuf:"varint,17,opt,name=height,proto3" json:"height,omitempty"`
+	Accept            string               `protobuf:"bytes,18,opt,name=accept,proto3" json:"accept,omitempty"`
+}
+
+func (x *EMVCoRequest) Reset() {
+	*x = EMVCoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha1_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EMVCoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EMVCoRequest) ProtoMessage() {}
+
+func (x *EMVCoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EMVCoRequest.ProtoReflect.Descriptor instead.
+func (*EMVCoRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EMVCoRequest) GetInitiationMethod() string {
+	if x != nil {
+		return x.InitiationMethod
+	}
+	return ""
+}
+
+func (x *EMVCoRequest) GetMerchantAccounts() []*MerchantAccount {
+	if x != nil {
+		return x.MerchantAccounts
+	}
+	return nil
+}
+
+func (x *EMVCoRequest) GetMerchantCategory() string {
+	if x != nil {
+		return x.MerchantCategory
+	}
+	return ""
+}
+
+func (x *EMVCoRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *EMVCoRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *EMVCoRequest) GetTipIndicator() string {
+	if x != nil {
+		return x.TipIndicator
+	}
+	return ""
+}
+
+func (x *EMVCoRequest) GetConvenienceFee() string {
+	if x != nil {
+		return x.ConvenienceFee
+	}
+	return ""
+}
+
+func (x *EMVCoRequest) GetConvenienceFeePct() string {
+	if x != nil {
+		return x.ConvenienceFeePct
+	}
+	return ""
+}
+
+func (x *EMVCoRequest) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *EMVCoRequest) GetMerchantName() string {
+	if x != nil {
+		return x.MerchantName
+	}
+	return ""
+}
+
+func (x *EMVCoRequest) GetMerchantCity() string {
+	if x != nil {
+		return x.MerchantCity
+	}
+	return ""
+}
+
+func (x *EMVCoRequest) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *EMVCoRequest) GetAdditionalData() *EMVCoAdditionalData {
+	if x != nil {
+		return x.AdditionalData
+	}
+	return nil
+}
+
+func (x *EMVCoRequest) GetLanguage() *EMVCoLanguage {
+	if x != nil {
+		return x.Language
+	}
+	return nil
+}
+
+func (x *EMVCoRequest) GetFields() []*TLV {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *EMVCoRequest) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *EMVCoRequest) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *EMVCoRequest) GetAccept() string {
+	if x != nil {
+		return x.Accept
+	}
+	return ""
+}
+
+// BIP21 bitcoin: uri
+type BitcoinPayment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Amount  string `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Label   string `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *BitcoinPayment) Reset() {
+	*x = BitcoinPayment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha1_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BitcoinPayment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BitcoinPayment) ProtoMessage() {}
+
+func (x *BitcoinPayment) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BitcoinPayment.ProtoReflect.Descriptor instead.
+func (*BitcoinPayment) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BitcoinPayment) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *BitcoinPayment) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *BitcoinPayment) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *BitcoinPayment) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// EIP-681 ethereum: uri
+type EthereumPayment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	ChainId uint64 `protobuf:"varint,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Value   string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *EthereumPayment) Reset() {
+	*x = EthereumPayment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha1_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EthereumPayment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EthereumPayment) ProtoMessage() {}
+
+func (x *EthereumPayment) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EthereumPayment.ProtoReflect.Descriptor instead.
+func (*EthereumPayment) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EthereumPayment) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *EthereumPayment) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *EthereumPayment) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type CryptoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Payment:
+	//	*CryptoRequest_Bitcoin
+	//	*CryptoRequest_Ethereum
+	Payment isCryptoRequest_Payment `protobuf_oneof:"payment"`
+	Width   int32                   `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height  int32                   `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	Accept  string                  `protobuf:"bytes,5,opt,name=accept,proto3" json:"accept,omitempty"`
+}
+
+func (x *CryptoRequest) Reset() {
+	*x = CryptoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha1_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CryptoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CryptoRequest) ProtoMessage() {}
+
+func (x *CryptoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CryptoRequest.ProtoReflect.Descriptor instead.
+func (*CryptoRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_proto_rawDescGZIP(), []int{9}
+}
+
+func (m *CryptoRequest) GetPayment() isCryptoRequest_Payment {
+	if m != nil {
+		return m.Payment
+	}
+	return nil
+}
+
+func (x *CryptoRequest) GetBitcoin() *BitcoinPayment {
+	if x, ok := x.GetPayment().(*CryptoRequest_Bitcoin); ok {
+		return x.Bitcoin
+	}
+	return nil
+}
+
+func (x *CryptoRequest) GetEthereum() *EthereumPayment {
+	if x, ok := x.GetPayment().(*CryptoRequest_Ethereum); ok {
+		return x.Ethereum
+	}
+	return nil
+}
+
+func (x *CryptoRequest) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *CryptoRequest) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *CryptoRequest) GetAccept() string {
+	if x != nil {
+		return x.Accept
+	}
+	return ""
+}
+
+type isCryptoRequest_Payment interface {
+	isCryptoRequest_Payment()
+}
+
+type CryptoRequest_Bitcoin struct {
+	Bitcoin *BitcoinPayment `protobuf:"bytes,1,opt,name=bitcoin,proto3,oneof"`
+}
+
+type CryptoRequest_Ethereum struct {
+	Ethereum *EthereumPayment `protobuf:"bytes,2,opt,name=ethereum,proto3,oneof"`
+}
+
+func (*CryptoRequest_Bitcoin) isCryptoRequest_Payment() {}
+
+func (*CryptoRequest_Ethereum) isCryptoRequest_Payment() {}
+
+// otpauth:// key provisioning
+type OTPRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type       string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`                                // totp(default), hotp
+	Secret     string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`                            // base32 encoded
+	Generate   bool   `protobuf:"varint,3,opt,name=generate,proto3" json:"generate,omitempty"`                       // generate random secret
+	SecretSize int32  `protobuf:"varint,4,opt,name=secret_size,json=secretSize,proto3" json:"secret_size,omitempty"` // bytes of generated secret
+	Issuer     string `protobuf:"bytes,5,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	Account    string `protobuf:"bytes,6,opt,name=account,proto3" json:"account,omitempty"`
+	Algorithm  string `protobuf:"bytes,7,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Digits     int32  `protobuf:"varint,8,opt,name=digits,proto3" json:"digits,omitempty"`
+	Period     int32  `protobuf:"varint,9,opt,name=period,proto3" json:"period,omitempty"`
+	Counter    uint64 `protobuf:"varint,10,opt,name=counter,proto3" json:"counter,omitempty"`
+	Width      int32  `protobuf:"varint,11,opt,name=width,proto3" json:"width,omitempty"`
+	Height     int32  `protobuf:"varint,12,opt,name=height,proto3" json:"height,omitempty"`
+	Accept     string `protobuf:"bytes,13,opt,name=accept,proto3" json:"accept,omitempty"`
+}
+
+func (x *OTPRequest) Reset() {
+	*x = OTPRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha1_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OTPRequest) ProtoMessage() {}
+
+func (x *OTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OTPRequest.ProtoReflect.Descriptor instead.
+func (*OTPRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *OTPRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *OTPRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *OTPRequest) GetGenerate() bool {
+	if x != nil {
+		return x.Generate
+	}
+	return false
+}
+
+func (x *OTPRequest) GetSecretSize() int32 {
+	if x != nil {
+		return x.SecretSize
+	}
+	return 0
+}
+
+func (x *OTPRequest) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *OTPRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *OTPRequest) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *OTPRequest) GetDigits() int32 {
+	if x != nil {
+		return x.Digits
+	}
+	return 0
+}
+
+func (x *OTPRequest) GetPeriod() int32 {
+	if x != nil {
+		return x.Period
+	}
+	return 0
+}
+
+func (x *OTPRequest) GetCounter() uint64 {
+	if x != nil {
+		return x.Counter
+	}
+	return 0
+}
+
+func (x *OTPRequest) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *OTPRequest) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *OTPRequest) GetAccept() string {
+	if x != nil {
+		return x.Accept
+	}
+	return ""
+}
+
+type OTPResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Qrcode *Response `protobuf:"bytes,1,opt,name=qrcode,proto3" json:"qrcode,omitempty"`
+	Secret string    `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"` // generated one if generate is set
+	Uri    string    `protobuf:"bytes,3,opt,name=uri,proto3" json:"uri,omitempty"`
+}
+
+func (x *OTPResponse) Reset() {
+	*x = OTPResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha1_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OTPResponse) ProtoMessage() {}
+
+func (x *OTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OTPResponse.ProtoReflect.Descriptor instead.
+func (*OTPResponse) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *OTPResponse) GetQrcode() *Response {
+	if x != nil {
+		return x.Qrcode
+	}
+	return nil
+}
+
+func (x *OTPResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *OTPResponse) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+type Address struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PostCode        string `protobuf:"bytes,1,opt,name=post_code,json=postCode,proto3" json:"post_code,omitempty"`
+	CountryOrRegion string `protobuf:"bytes,2,opt,name=country_or_region,json=countryOrRegion,proto3" json:"country_or_region,omitempty"`
+	Province        string `protobuf:"bytes,3,opt,name=province,proto3" json:"province,omitempty"`
+	City            string `protobuf:"bytes,4,opt,name=city,proto3" json:"city,omitempty"`
+	Street          string `protobuf:"bytes,5,opt,name=street,proto3" json:"street,omitempty"`
+	Street2         string `protobuf:"bytes,6,opt,name=street2,proto3" json:"street2,omitempty"`
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha1_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Address) GetPostCode() string {
+	if x != nil {
+		return x.PostCode
+	}
+	return ""
+}
+
+func (x *Address) GetCountryOrRegion() string {
+	if x != nil {
+		return x.CountryOrRegion
+	}
+	return ""
+}
+
+func (x *Address) GetProvince() string {
+	if x != nil {
+		return x.Province
+	}
+	return ""
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *Address) GetStreet2() string {
+	if x != nil {
+		return x.Street2
+	}
+	return ""
+}
+
+type Card struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LastName       string           `protobuf:"bytes,1,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	FirstName      string           `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	MiddleName     string           `protobuf:"bytes,3,opt,name=middle_name,json=middleName,proto3" json:"middle_name,omitempty"`
+	PrefixName     string           `protobuf:"bytes,4,opt,name=prefix_name,json=prefixName,proto3" json:"prefix_name,omitempty"`
+	SuffixName     string           `protobuf:"bytes,5,opt,name=suffix_name,json=suffixName,proto3" json:"suffix_name,omitempty"`
+	FormattedName  string           `protobuf:"bytes,6,opt,name=formatted_name,json=formattedName,proto3" json:"formatted_name,omitempty"`
+	NickName       string           `protobuf:"bytes,7,opt,name=nick_name,json=nickName,proto3" json:"nick_name,omitempty"`
+	Company        string           `protobuf:"bytes,8,opt,name=company,proto3" json:"company,omitempty"`
+	Department     string           `protobuf:"bytes,9,opt,name=department,proto3" json:"department,omitempty"`
+	JobTitle       string           `protobuf:"bytes,10,opt,name=job_title,json=jobTitle,proto3" json:"job_title,omitempty"`
+	Email          string           `protobuf:"bytes,11,opt,name=email,proto3" json:"email,omitempty"`
+	HomeEmail      string           `protobuf:"bytes,12,opt,name=home_email,json=homeEmail,proto3" json:"home_email,omitempty"`
+	WorkEmail      string           `protobuf:"bytes,13,opt,name=work_email,json=workEmail,proto3" json:"work_email,omitempty"`
+	Tel            string           `protobuf:"bytes,14,opt,name=tel,proto3" json:"tel,omitempty"`
+	Mobile         string           `protobuf:"bytes,15,opt,name=mobile,proto3" json:"mobile,omitempty"`
+	HomeTel        string           `protobuf:"bytes,16,opt,name=home_tel,json=homeTel,proto3" json:"home_tel,omitempty"`
+	WorkTel        string           `protobuf:"bytes,17,opt,name=work_tel,json=workTel,proto3" json:"work_tel,omitempty"`
+	HomeFax        string           `protobuf:"bytes,18,opt,name=home_fax,json=homeFax,proto3" json:"home_fax,omitempty"`
+	WorkFax        string           `protobuf:"bytes,19,opt,name=work_fax,json=workFax,proto3" json:"work_fax,omitempty"`
+	Pager          string           `protobuf:"bytes,20,opt,name=pager,proto3" json:"pager,omitempty"`
+	HomeAddr       *Address         `protobuf:"bytes,21,opt,name=home_addr,json=homeAddr,proto3" json:"home_addr,omitempty"`
+	WorkAddr       *Address         `protobuf:"bytes,22,opt,name=work_addr,json=workAddr,proto3" json:"work_addr,omitempty"`
+	Homepage       string           `protobuf:"bytes,23,opt,name=homepage,proto3" json:"homepage,omitempty"`
+	WorkHomepage   string           `protobuf:"bytes,24,opt,name=work_homepage,json=workHomepage,proto3" json:"work_homepage,omitempty"`
+	HomeHomepage   string           `protobuf:"bytes,25,opt,name=home_homepage,json=homeHomepage,proto3" json:"home_homepage,omitempty"`
+	Note           string           `protobuf:"bytes,26,opt,name=note,proto3" json:"note,omitempty"`
+	SocialProfiles []*SocialProfile `protobuf:"bytes,27,rep,name=social_profiles,json=socialProfiles,proto3" json:"social_profiles,omitempty"`
+	Photo          *CardImage       `protobuf:"bytes,28,opt,name=photo,proto3" json:"photo,omitempty"`
+	Logo           *CardImage       `protobuf:"bytes,29,opt,name=logo,proto3" json:"logo,omitempty"`
+}
+
+func (x *Card) Reset() {
+	*x = Card{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha1_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Card) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Card) ProtoMessage() {}
+
+func (x *Card) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Card.ProtoReflect.Descriptor instead.
+func (*Card) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Card) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *Card) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *Card) GetMiddleName() string {
+	if x != nil {
+		return x.MiddleName
+	}
+	return ""
+}
+
+func (x *Card) GetPrefixName() string {
+	if x != nil {
+		return x.PrefixName
+	}
+	return ""
+}
+
+func (x *Card) GetSuffixName() string {
+	if x != nil {
+		return x.SuffixName
+	}
+	return ""
+}
+
+func (x *Card) GetFormattedName() string {
+	if x != nil {
+		return x.FormattedName
+	}
+	return ""
+}
+
+func (x *Card) GetNickName() string {
+	if x != nil {
+		return x.NickName
+	}
+	return ""
+}
+
+func (x *Card) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *Card) GetDepartment() string {
+	if x != nil {
+		return x.Department
+	}
+	return ""
+}
+
+func (x *Card) GetJobTitle() string {
+	if x != nil {
+		return x.JobTitle
+	}
+	return ""
+}
+
+func (x *Card) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Card) GetHomeEmail() string {
+	if x != nil {
+		return x.HomeEmail
+	}
+	return ""
+}
+
+func (x *Card) GetWorkEmail() string {
+	if x != nil {
+		return x.WorkEmail
+	}
+	return ""
+}
+
+func (x *Card) GetTel() string {
+	if x != nil {
+		return x.Tel
+	}
+	return ""
+}
+
+func (x *Card) GetMobile() string {
+	if x != nil {
+		return x.Mobile
+	}
+	return ""
+}
+
+func (x *Card) GetHomeTel() string {
+	if x != nil {
+		return x.HomeTel
+	}
+	return ""
+}
+
+func (x *Card) GetWorkTel() string {
+	if x != nil {
+		return x.WorkTel
+	}
+	return ""
+}
+
+func (x *Card) GetHomeFax() string {
+	if x != nil {
+		return x.HomeFax
+	}
+	return ""
+}
+
+func (x *Card) GetWorkFax() string {
+	if x != nil {
+		return x.WorkFax
+	}
+	return ""
+}
+
+func (x *Card) GetPager() string {
+	if x != nil {
+		return x.Pager
+	}
+	return ""
+}
+
+func (x *Card) GetHomeAddr() *Address {
+	if x != nil {
+		return x.HomeAddr
+	}
+	return nil
+}
+
+func (x *Card) GetWorkAddr() *Address {
+	if x != nil {
+		return x.WorkAddr
+	}
+	return nil
+}
+
+func (x *Card) GetHomepage() string {
+	if x != nil {
+		return x.Homepage
+	}
+	return ""
+}
+
+func (x *Card) GetWorkHomepage() string {
+	if x != nil {
+		return x.WorkHomepage
+	}
+	return ""
+}
+
+func (x *Card) GetHomeHomepage() string {
+	if x != nil {
+		return x.HomeHomepage
+	}
+	return ""
+}
+
+func (x *Card) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *Card) GetSocialProfiles() []*SocialProfile {
+	if x != nil {
+		return x.SocialProfiles
+	}
+	return nil
+}
+
+func (x *Card) GetPhoto() *CardImage {
+	if x != nil {
+		return x.Photo
+	}
+	return nil
+}
+
+func (x *Card) GetLogo() *CardImage {
+	if x != nil {
+		return x.Logo
+	}
+	return nil
+}
+
+// PHOTO, LOGO of contact; data is downscaled and embedded, url is used if data is empty or embedded image does not fit
+type CardImage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url    string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Data   []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Budget int32  `protobuf:"varint,3,opt,name=budget,proto3" json:"budget,omitempty"` // max bytes of embedded image, remaining QR capacity if 0
+}
+
+func (x *CardImage) Reset() {
+	*x = CardImage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha1_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CardImage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CardImage) ProtoMessage() {}
+
+func (x *CardImage) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CardImage.ProtoReflect.Descriptor instead.
+func (*CardImage) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CardImage) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CardImage) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *CardImage) GetBudget() int32 {
+	if x != nil {
+		return x.Budget
+	}
+	return 0
+}
+
+// social profile; type is registered social network name or id is absolute url
+type SocialProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // twitter, facebook, linkedin, github, jabber, ...
+	Id   string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *SocialProfile) Reset() {
+	*x = SocialProfile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha1_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SocialProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SocialProfile) ProtoMessage() {}
+
+func (x *SocialProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SocialProfile.ProtoReflect.Descriptor instead.
+func (*SocialProfile) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SocialProfile) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SocialProfile) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ContactRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Card   *Card  `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"`
+	Format string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"` // vcard4(default), vcard3, vcard21, mecard
+	Width  int32  `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height int32  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	Accept string `protobuf:"bytes,5,opt,name=accept,proto3" json:"accept,omitempty"`
+}
+
+func (x *ContactRequest) Reset() {
+	*x = ContactRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha1_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContactRequest) ProtoMessage() {}
+
+func (x *ContactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContactRequest.ProtoReflect.Descriptor instead.
+func (*ContactRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ContactRequest) GetCard() *Card {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+func (x *ContactRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ContactRequest) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ContactRequest) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ContactRequest) GetAccept() string {
+	if x != nil {
+		return x.Accept
+	}
+	return ""
+}
+
+type VCardRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Content:
+	//	*VCardRequest_Vcard
+	//	*VCardRequest_Jcard
+	Content isVCardRequest_Content `protobuf_oneof:"content"`
+	Version string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"` // 2.1, 3.0, 4.0; as is if empty
+	Width   int32                  `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
+	Height  int32                  `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	Accept  string                 `protobuf:"bytes,6,opt,name=accept,proto3" json:"accept,omitempty"`
+}
+
+func (x *VCardRequest) Reset() {
+	*x = VCardRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha1_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VCardRequest) ProtoMessage() {}
+
+func (x *VCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VCardRequest.ProtoReflect.Descriptor instead.
+func (*VCardRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_proto_rawDescGZIP(), []int{17}
+}
+
+func (m *VCardRequest) GetContent() isVCardRequest_Content {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (x *VCardRequest) GetVcard() string {
+	if x, ok := x.GetContent().(*VCardRequest_Vcard); ok {
+		return x.Vcard
+	}
+	return ""
+}
+
+func (x *VCardRequest) GetJcard() string {
+	if x, ok := x.GetContent().(*VCardRequest_Jcard); ok {
+		return x.Jcard
+	}
+	return ""
+}
+
+func (x *VCardRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *VCardRequest) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *VCardRequest) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *VCardRequest) GetAccept() string {
+	if x != nil {
+		return x.Accept
+	}
+	return ""
+}
+
+type isVCardRequest_Content interface {
+	isVCardRequest_Content()
+}
+
+type VCardRequest_Vcard struct {
+	Vcard string `protobuf:"bytes,1,opt,name=vcard,proto3,oneof"` // text/vcard
+}
+
+type VCardRequest_Jcard struct {
+	Jcard string `protobuf:"bytes,2,opt,name=jcard,proto3,oneof"` // application/vcard+json, RFC 7095
+}
+
+func (*VCardRequest_Vcard) isVCardRequest_Content() {}
+
+func (*VCardRequest_Jcard) isVCardRequest_Content() {}
+
+type Attendee struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Email string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Name  string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Role  string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"` // required(default), optional, chair, non-participant
+	Rsvp  bool   `protobuf:"varint,4,opt,name=rsvp,proto3" json:"rsvp,omitempty"`
+}
+
+func (x *Attendee) Reset() {
+	*x = Attendee{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha1_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Attendee) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attendee) ProtoMessage() {}
+
+func (x *Attendee) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attendee.ProtoReflect.Descriptor instead.
+func (*Attendee) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Attendee) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Attendee) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Attendee) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Attendee) GetRsvp() bool {
+	if x != nil {
+		return x.Rsvp
+	}
+	return false
+}
+
+type Recurrence struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Freq       string   `protobuf:"bytes,1,opt,name=freq,proto3" json:"freq,omitempty"` // daily, weekly, monthly, yearly
+	Interval   int32    `protobuf:"varint,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	Count      int32    `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Until      string   `protobuf:"bytes,4,opt,name=until,proto3" json:"until,omitempty"`              // same format as start; exclusive with count
+	ByDay      []string `protobuf:"bytes,5,rep,name=by_day,json=byDay,proto3" json:"by_day,omitempty"` // MO, TU, ...; with ordinal, ex. 1MO, -1FR
+	ByMonthDay []int32  `protobuf:"varint,6,rep,packed,name=by_month_day,json=byMonthDay,proto3" json:"by_month_day,omitempty"`
+	ByMonth    []int32  `protobuf:"varint,7,rep,packed,name=by_month,json=byMonth,proto3" json:"by_month,omitempty"`
+}
+
+func (x *Recurrence) Reset() {
+	*x = Recurrence{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha1_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Recurrence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recurrence) ProtoMessage() {}
+
+func (x *Recurrence) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recurrence.ProtoReflect.Descriptor instead.
+func (*Recurrence) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Recurrence) GetFreq() string {
+	if x != nil {
+		return x.Freq
+	}
+	return ""
+}
+
+func (x *Recurrence) GetInterval() int32 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *Recurrence) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Recurrence) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+func (x *Recurrence) GetByDay() []string {
+	if x != nil {
+		return x.ByDay
+	}
+	return nil
+}
+
+func (x *Recurrence) GetByMonthDay() []int32 {
+	if x != nil {
+		return x.ByMonthDay
+	}
+	return nil
+}
+
+func (x *Recurrence) GetByMonth() []int32 {
+	if x != nil {
+		return x.ByMonth
+	}
+	return nil
+}
+
+// event of friendly fields; start and end are RFC3339, local date-time in tz or date of all day event
+type Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Title       string      `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Start       string      `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End         string      `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`           // the last day of all day event, inclusive
+	Duration    string      `protobuf:"bytes,4,opt,name=duration,proto3" json:"duration,omitempty"` // RFC 5545 duration, ex. PT1H30M; exclusive with end
+	AllDay      bool        `protobuf:"varint,5,opt,name=all_day,json=allDay,proto3" json:"all_day,omitempty"`
+	Tz          string      `protobuf:"bytes,6,opt,name=tz,proto3" json:"tz,omitempty"` // IANA time zone, ex. Asia/Seoul
+	Location    string      `protobuf:"bytes,7,opt,name=location,proto3" json:"location,omitempty"`
+	Description string      `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`
+	Url         string      `protobuf:"bytes,9,opt,name=url,proto3" json:"url,omitempty"`
+	Attendees   []*Attendee `protobuf:"bytes,10,rep,name=attendees,proto3" json:"attendees,omitempty"`
+	Recurrence  *Recurrence `protobuf:"bytes,11,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha1_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Event) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Event) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *Event) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *Event) GetDuration() string {
+	if x != nil {
+		return x.Duration
+	}
+	return ""
+}
+
+func (x *Event) GetAllDay() bool {
+	if x != nil {
+		return x.AllDay
+	}
+	return false
+}
+
+func (x *Event) GetTz() string {
+	if x != nil {
+		return x.Tz
+	}
+	return ""
+}
+
+func (x *Event) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *Event) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Event) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Event) GetAttendees() []*Attendee {
+	if x != nil {
+		return x.Attendees
+	}
+	return nil
+}
+
+func (x *Event) GetRecurrence() *Recurrence {
+	if x != nil {
+		return x.Recurrence
+	}
+	return nil
+}
+
+type EventRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event  *Event `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	Wrap   bool   `protobuf:"varint,2,opt,name=wrap,proto3" json:"wrap,omitempty"` // wrap VEVENT in VCALENDAR
+	Width  int32  `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height int32  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	Accept string `protobuf:"bytes,5,opt,name=accept,proto3" json:"accept,omitempty"`
+}
+
+func (x *EventRequest) Reset() {
+	*x = EventRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha1_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventRequest) ProtoMessage() {}
+
+func (x *EventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventRequest.ProtoReflect.Descriptor instead.
+func (*EventRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *EventRequest) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *EventRequest) GetWrap() bool {
+	if x != nil {
+		return x.Wrap
+	}
+	return false
+}
+
+func (x *EventRequest) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *EventRequest) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *EventRequest) GetAccept() string {
+	if x != nil {
+		return x.Accept
+	}
+	return ""
+}
+
 var File_v1alpha1_proto protoreflect.FileDescriptor
 
 var file_v1alpha1_proto_rawDesc = []byte{
@@ -195,17 +2177,334 @@ var file_v1alpha1_proto_rawDesc = []byte{
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a,
 	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68,
 	0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x32, 0x84, 0x01, 0x0a, 0x06,
-	0x51, 0x52, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x3f, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69,
-	0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x39, 0x0a, 0x08, 0x67, 0x65, 0x6e, 0x65, 0x72,
-	0x61, 0x74, 0x65, 0x12, 0x15, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x61, 0x70, 0x69,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x22, 0x2b, 0x0a, 0x03, 0x54,
+	0x4c, 0x56, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x76, 0x0a, 0x0f, 0x4d, 0x65, 0x72, 0x63,
+	0x68, 0x61, 0x6e, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x75, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x67, 0x75, 0x69, 0x64, 0x12, 0x29, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x54, 0x4c, 0x56, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73,
+	0x22, 0x93, 0x03, 0x0a, 0x13, 0x45, 0x4d, 0x56, 0x43, 0x6f, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x69, 0x6c, 0x6c,
+	0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62,
+	0x69, 0x6c, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x6f, 0x62,
+	0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x6d, 0x6f, 0x62, 0x69, 0x6c, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1f,
+	0x0a, 0x0b, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12,
+	0x25, 0x0a, 0x0e, 0x6c, 0x6f, 0x79, 0x61, 0x6c, 0x74, 0x79, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x6f, 0x79, 0x61, 0x6c, 0x74, 0x79,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12,
+	0x25, 0x0a, 0x0e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x5f, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65,
+	0x72, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e,
+	0x61, 0x6c, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x18, 0x0a,
+	0x07, 0x70, 0x75, 0x72, 0x70, 0x6f, 0x73, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x70, 0x75, 0x72, 0x70, 0x6f, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x15, 0x63, 0x6f, 0x6e, 0x73, 0x75,
+	0x6d, 0x65, 0x72, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x72,
+	0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x06, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x54, 0x4c, 0x56, 0x52, 0x06,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x79, 0x0a, 0x0d, 0x45, 0x4d, 0x56, 0x43, 0x6f, 0x4c,
+	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x65, 0x72, 0x63, 0x68,
+	0x61, 0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d,
+	0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x5f, 0x63, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x43, 0x69, 0x74,
+	0x79, 0x22, 0xea, 0x05, 0x0a, 0x0c, 0x45, 0x4d, 0x56, 0x43, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x69,
+	0x6e, 0x69, 0x74, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12,
+	0x4a, 0x0a, 0x11, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x5f, 0x61, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4d, 0x65, 0x72, 0x63, 0x68, 0x61,
+	0x6e, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x10, 0x6d, 0x65, 0x72, 0x63, 0x68,
+	0x61, 0x6e, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x6d,
+	0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74,
+	0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d,
+	0x74, 0x69, 0x70, 0x5f, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x69, 0x70, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x6f,
+	0x72, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x6e, 0x69, 0x65, 0x6e, 0x63, 0x65,
+	0x5f, 0x66, 0x65, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76,
+	0x65, 0x6e, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x46, 0x65, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x63, 0x6f,
+	0x6e, 0x76, 0x65, 0x6e, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x63,
+	0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x6e, 0x69,
+	0x65, 0x6e, 0x63, 0x65, 0x46, 0x65, 0x65, 0x50, 0x63, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a,
+	0x0d, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x5f, 0x63,
+	0x69, 0x74, 0x79, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x65, 0x72, 0x63, 0x68,
+	0x61, 0x6e, 0x74, 0x43, 0x69, 0x74, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x74, 0x61,
+	0x6c, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6f,
+	0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x4a, 0x0a, 0x0f, 0x61, 0x64, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x45, 0x4d, 0x56, 0x43, 0x6f, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c,
+	0x44, 0x61, 0x74, 0x61, 0x52, 0x0e, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c,
+	0x44, 0x61, 0x74, 0x61, 0x12, 0x37, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x4d, 0x56, 0x43, 0x6f, 0x4c, 0x61, 0x6e, 0x67, 0x75,
+	0x61, 0x67, 0x65, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x29, 0x0a,
+	0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x54, 0x4c, 0x56,
+	0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74,
+	0x68, 0x18, 0x10, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16,
+	0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74,
+	0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x22, 0x72,
+	0x0a, 0x0e, 0x42, 0x69, 0x74, 0x63, 0x6f, 0x69, 0x6e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0x5c, 0x0a, 0x0f, 0x45, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x50, 0x61,
+	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x19, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x22, 0xd7, 0x01, 0x0a, 0x0d, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x38, 0x0a, 0x07, 0x62, 0x69, 0x74, 0x63, 0x6f, 0x69, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x42, 0x69, 0x74, 0x63, 0x6f, 0x69, 0x6e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x48, 0x00, 0x52, 0x07, 0x62, 0x69, 0x74, 0x63, 0x6f, 0x69, 0x6e, 0x12, 0x3b, 0x0a, 0x08,
+	0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x74,
+	0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52,
+	0x08, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64,
+	0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12,
+	0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x63, 0x65, 0x70,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x42,
+	0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0xd5, 0x02, 0x0a, 0x0a, 0x4f,
+	0x54, 0x50, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68,
+	0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74,
+	0x68, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x69, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x64, 0x69, 0x67, 0x69, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65,
+	0x72, 0x69, 0x6f, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69,
+	0x6f, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64,
+	0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63,
+	0x63, 0x65, 0x70, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x63, 0x65,
+	0x70, 0x74, 0x22, 0x67, 0x0a, 0x0b, 0x4f, 0x54, 0x50, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x2e, 0x0a, 0x06, 0x71, 0x72, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x06, 0x71, 0x72, 0x63, 0x6f, 0x64,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x69,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x69, 0x22, 0xb4, 0x01, 0x0a, 0x07,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6f, 0x73, 0x74, 0x5f,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x74,
+	0x43, 0x6f, 0x64, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x5f,
+	0x6f, 0x72, 0x5f, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x4f, 0x72, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x63, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x69, 0x74, 0x79,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x65,
+	0x65, 0x74, 0x32, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x74, 0x72, 0x65, 0x65,
+	0x74, 0x32, 0x22, 0xc4, 0x07, 0x0a, 0x04, 0x43, 0x61, 0x72, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6c, 0x61, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x72, 0x73,
+	0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69,
+	0x72, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x69, 0x64, 0x64, 0x6c,
+	0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x69,
+	0x64, 0x64, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x75, 0x66,
+	0x66, 0x69, 0x78, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x73, 0x75, 0x66, 0x66, 0x69, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x74, 0x65, 0x64, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x74, 0x65, 0x64, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x69, 0x63, 0x6b, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x61,
+	0x72, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65,
+	0x70, 0x61, 0x72, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6a, 0x6f, 0x62, 0x5f,
+	0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6a, 0x6f, 0x62,
+	0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x68,
+	0x6f, 0x6d, 0x65, 0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x68, 0x6f, 0x6d, 0x65, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x6f,
+	0x72, 0x6b, 0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x77, 0x6f, 0x72, 0x6b, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x65, 0x6c,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x6d,
+	0x6f, 0x62, 0x69, 0x6c, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x6f, 0x62,
+	0x69, 0x6c, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x68, 0x6f, 0x6d, 0x65, 0x5f, 0x74, 0x65, 0x6c, 0x18,
+	0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x68, 0x6f, 0x6d, 0x65, 0x54, 0x65, 0x6c, 0x12, 0x19,
+	0x0a, 0x08, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x74, 0x65, 0x6c, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x77, 0x6f, 0x72, 0x6b, 0x54, 0x65, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x68, 0x6f, 0x6d,
+	0x65, 0x5f, 0x66, 0x61, 0x78, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x68, 0x6f, 0x6d,
+	0x65, 0x46, 0x61, 0x78, 0x12, 0x19, 0x0a, 0x08, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x66, 0x61, 0x78,
+	0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x77, 0x6f, 0x72, 0x6b, 0x46, 0x61, 0x78, 0x12,
+	0x14, 0x0a, 0x05, 0x70, 0x61, 0x67, 0x65, 0x72, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x70, 0x61, 0x67, 0x65, 0x72, 0x12, 0x32, 0x0a, 0x09, 0x68, 0x6f, 0x6d, 0x65, 0x5f, 0x61, 0x64,
+	0x64, 0x72, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52,
+	0x08, 0x68, 0x6f, 0x6d, 0x65, 0x41, 0x64, 0x64, 0x72, 0x12, 0x32, 0x0a, 0x09, 0x77, 0x6f, 0x72,
+	0x6b, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x52, 0x08, 0x77, 0x6f, 0x72, 0x6b, 0x41, 0x64, 0x64, 0x72, 0x12, 0x1a, 0x0a,
+	0x08, 0x68, 0x6f, 0x6d, 0x65, 0x70, 0x61, 0x67, 0x65, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x68, 0x6f, 0x6d, 0x65, 0x70, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x77, 0x6f, 0x72,
+	0x6b, 0x5f, 0x68, 0x6f, 0x6d, 0x65, 0x70, 0x61, 0x67, 0x65, 0x18, 0x18, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x77, 0x6f, 0x72, 0x6b, 0x48, 0x6f, 0x6d, 0x65, 0x70, 0x61, 0x67, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x68, 0x6f, 0x6d, 0x65, 0x5f, 0x68, 0x6f, 0x6d, 0x65, 0x70, 0x61, 0x67, 0x65, 0x18,
+	0x19, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x68, 0x6f, 0x6d, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x70,
+	0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x1a, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x12, 0x44, 0x0a, 0x0f, 0x73, 0x6f, 0x63, 0x69, 0x61,
+	0x6c, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x1b, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x53, 0x6f, 0x63, 0x69, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x0e, 0x73,
+	0x6f, 0x63, 0x69, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x2d, 0x0a,
+	0x05, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x61, 0x72, 0x64,
+	0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x05, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x12, 0x2b, 0x0a, 0x04,
+	0x6c, 0x6f, 0x67, 0x6f, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x61, 0x72, 0x64, 0x49, 0x6d,
+	0x61, 0x67, 0x65, 0x52, 0x04, 0x6c, 0x6f, 0x67, 0x6f, 0x22, 0x49, 0x0a, 0x09, 0x43, 0x61, 0x72,
+	0x64, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06,
+	0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x75,
+	0x64, 0x67, 0x65, 0x74, 0x22, 0x33, 0x0a, 0x0d, 0x53, 0x6f, 0x63, 0x69, 0x61, 0x6c, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x96, 0x01, 0x0a, 0x0e, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x04,
+	0x63, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x61, 0x72, 0x64, 0x52, 0x04,
+	0x63, 0x61, 0x72, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64,
+	0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63,
+	0x63, 0x65, 0x70, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x63, 0x65,
+	0x70, 0x74, 0x22, 0xa9, 0x01, 0x0a, 0x0c, 0x56, 0x43, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x05, 0x76, 0x63, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x76, 0x63, 0x61, 0x72, 0x64, 0x12, 0x16, 0x0a, 0x05, 0x6a,
+	0x63, 0x61, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x6a, 0x63,
+	0x61, 0x72, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a,
+	0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69,
+	0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61,
+	0x63, 0x63, 0x65, 0x70, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x63,
+	0x65, 0x70, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x5c,
+	0x0a, 0x08, 0x41, 0x74, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d,
+	0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x73, 0x76, 0x70,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x72, 0x73, 0x76, 0x70, 0x22, 0xbc, 0x01, 0x0a,
+	0x0a, 0x52, 0x65, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66,
+	0x72, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x65, 0x71, 0x12,
+	0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x79, 0x5f, 0x64, 0x61,
+	0x79, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x62, 0x79, 0x44, 0x61, 0x79, 0x12, 0x20,
+	0x0a, 0x0c, 0x62, 0x79, 0x5f, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x5f, 0x64, 0x61, 0x79, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x05, 0x52, 0x0a, 0x62, 0x79, 0x4d, 0x6f, 0x6e, 0x74, 0x68, 0x44, 0x61, 0x79,
+	0x12, 0x19, 0x0a, 0x08, 0x62, 0x79, 0x5f, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x05, 0x52, 0x07, 0x62, 0x79, 0x4d, 0x6f, 0x6e, 0x74, 0x68, 0x22, 0xca, 0x02, 0x0a, 0x05,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x65, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x17, 0x0a, 0x07, 0x61, 0x6c, 0x6c, 0x5f, 0x64, 0x61, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x61, 0x6c, 0x6c, 0x44, 0x61, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x7a, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x7a, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x34, 0x0a, 0x09, 0x61, 0x74, 0x74, 0x65,
+	0x6e, 0x64, 0x65, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x6e,
+	0x64, 0x65, 0x65, 0x52, 0x09, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x65, 0x73, 0x12, 0x38,
+	0x0a, 0x0a, 0x72, 0x65, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x52, 0x65, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0a, 0x72, 0x65,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x22, 0x93, 0x01, 0x0a, 0x0c, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x05, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x72, 0x61, 0x70, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x04, 0x77, 0x72, 0x61, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74,
+	0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16,
+	0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x32, 0xf7,
+	0x03, 0x0a, 0x06, 0x51, 0x52, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x3f, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1c, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x39, 0x0a, 0x08, 0x67, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x12, 0x15, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x05, 0x65, 0x6d, 0x76, 0x63, 0x6f, 0x12, 0x1a,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x4d,
+	0x56, 0x43, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x61, 0x70, 0x69,
 	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x42, 0x11, 0x5a, 0x0f, 0x71, 0x72, 0x63, 0x6f, 0x64, 0x65, 0x61, 0x70, 0x69, 0x2f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x65, 0x12, 0x3d, 0x0a, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x12, 0x1b, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x72, 0x79, 0x70,
+	0x74, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x3a, 0x0a, 0x03, 0x6f, 0x74, 0x70, 0x12, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4f, 0x54, 0x50, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x4f, 0x54, 0x50, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a,
+	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x12, 0x1c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b,
+	0x0a, 0x05, 0x76, 0x63, 0x61, 0x72, 0x64, 0x12, 0x1a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x56, 0x43, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x05, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x16, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x11, 0x5a, 0x0f, 0x71, 0x72, 0x63, 0x6f,
+	0x64, 0x65, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -220,23 +2519,73 @@ func file_v1alpha1_proto_rawDescGZIP() []byte {
 	return file_v1alpha1_proto_rawDescData
 }
 
-var file_v1alpha1_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_v1alpha1_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
 var file_v1alpha1_proto_goTypes = []interface{}{
 	(*Request)(nil),                // 0: api.v1alpha1.Request
 	(*Response)(nil),               // 1: api.v1alpha1.Response
-	(*emptypb.Empty)(nil),          // 2: google.protobuf.Empty
-	(*wrapperspb.StringValue)(nil), // 3: google.protobuf.StringValue
+	(*TLV)(nil),                    // 2: api.v1alpha1.TLV
+	(*MerchantAccount)(nil),        // 3: api.v1alpha1.MerchantAccount
+	(*EMVCoAdditionalData)(nil),    // 4: api.v1alpha1.EMVCoAdditionalData
+	(*EMVCoLanguage)(nil),          // 5: api.v1alpha1.EMVCoLanguage
+	(*EMVCoRequest)(nil),           // 6: api.v1alpha1.EMVCoRequest
+	(*BitcoinPayment)(nil),         // 7: api.v1alpha1.BitcoinPayment
+	(*EthereumPayment)(nil),        // 8: api.v1alpha1.EthereumPayment
+	(*CryptoRequest)(nil),          // 9: api.v1alpha1.CryptoRequest
+	(*OTPRequest)(nil),             // 10: api.v1alpha1.OTPRequest
+	(*OTPResponse)(nil),            // 11: api.v1alpha1.OTPResponse
+	(*Address)(nil),                // 12: api.v1alpha1.Address
+	(*Card)(nil),                   // 13: api.v1alpha1.Card
+	(*CardImage)(nil),              // 14: api.v1alpha1.CardImage
+	(*SocialProfile)(nil),          // 15: api.v1alpha1.SocialProfile
+	(*ContactRequest)(nil),         // 16: api.v1alpha1.ContactRequest
+	(*VCardRequest)(nil),           // 17: api.v1alpha1.VCardRequest
+	(*Attendee)(nil),               // 18: api.v1alpha1.Attendee
+	(*Recurrence)(nil),             // 19: api.v1alpha1.Recurrence
+	(*Event)(nil),                  // 20: api.v1alpha1.Event
+	(*EventRequest)(nil),           // 21: api.v1alpha1.EventRequest
+	(*emptypb.Empty)(nil),          // 22: google.protobuf.Empty
+	(*wrapperspb.StringValue)(nil), // 23: google.protobuf.StringValue
 }
 var file_v1alpha1_proto_depIdxs = []int32{
-	2, // 0: api.v1alpha1.QRCode.version:input_type -> google.protobuf.Empty
-	0, // 1: api.v1alpha1.QRCode.generate:input_type -> api.v1alpha1.Request
-	3, // 2: api.v1alpha1.QRCode.version:output_type -> google.protobuf.StringValue
-	1, // 3: api.v1alpha1.QRCode.generate:output_type -> api.v1alpha1.Response
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	2,  // 0: api.v1alpha1.MerchantAccount.fields:type_name -> api.v1alpha1.TLV
+	2,  // 1: api.v1alpha1.EMVCoAdditionalData.fields:type_name -> api.v1alpha1.TLV
+	3,  // 2: api.v1alpha1.EMVCoRequest.merchant_accounts:type_name -> api.v1alpha1.MerchantAccount
+	4,  // 3: api.v1alpha1.EMVCoRequest.additional_data:type_name -> api.v1alpha1.EMVCoAdditionalData
+	5,  // 4: api.v1alpha1.EMVCoRequest.language:type_name -> api.v1alpha1.EMVCoLanguage
+	2,  // 5: api.v1alpha1.EMVCoRequest.fields:type_name -> api.v1alpha1.TLV
+	7,  // 6: api.v1alpha1.CryptoRequest.bitcoin:type_name -> api.v1alpha1.BitcoinPayment
+	8,  // 7: api.v1alpha1.CryptoRequest.ethereum:type_name -> api.v1alpha1.EthereumPayment
+	1,  // 8: api.v1alpha1.OTPResponse.qrcode:type_name -> api.v1alpha1.Response
+	12, // 9: api.v1alpha1.Card.home_addr:type_name -> api.v1alpha1.Address
+	12, // 10: api.v1alpha1.Card.work_addr:type_name -> api.v1alpha1.Address
+	15, // 11: api.v1alpha1.Card.social_profiles:type_name -> api.v1alpha1.SocialProfile
+	14, // 12: api.v1alpha1.Card.photo:type_name -> api.v1alpha1.CardImage
+	14, // 13: api.v1alpha1.Card.logo:type_name -> api.v1alpha1.CardImage
+	13, // 14: api.v1alpha1.ContactRequest.card:type_name -> api.v1alpha1.Card
+	18, // 15: api.v1alpha1.Event.attendees:type_name -> api.v1alpha1.Attendee
+	19, // 16: api.v1alpha1.Event.recurrence:type_name -> api.v1alpha1.Recurrence
+	20, // 17: api.v1alpha1.EventRequest.event:type_name -> api.v1alpha1.Event
+	22, // 18: api.v1alpha1.QRCode.version:input_type -> google.protobuf.Empty
+	0,  // 19: api.v1alpha1.QRCode.generate:input_type -> api.v1alpha1.Request
+	6,  // 20: api.v1alpha1.QRCode.emvco:input_type -> api.v1alpha1.EMVCoRequest
+	9,  // 21: api.v1alpha1.QRCode.crypto:input_type -> api.v1alpha1.CryptoRequest
+	10, // 22: api.v1alpha1.QRCode.otp:input_type -> api.v1alpha1.OTPRequest
+	16, // 23: api.v1alpha1.QRCode.contact:input_type -> api.v1alpha1.ContactRequest
+	17, // 24: api.v1alpha1.QRCode.vcard:input_type -> api.v1alpha1.VCardRequest
+	21, // 25: api.v1alpha1.QRCode.event:input_type -> api.v1alpha1.EventRequest
+	23, // 26: api.v1alpha1.QRCode.version:output_type -> google.protobuf.StringValue
+	1,  // 27: api.v1alpha1.QRCode.generate:output_type -> api.v1alpha1.Response
+	1,  // 28: api.v1alpha1.QRCode.emvco:output_type -> api.v1alpha1.Response
+	1,  // 29: api.v1alpha1.QRCode.crypto:output_type -> api.v1alpha1.Response
+	11, // 30: api.v1alpha1.QRCode.otp:output_type -> api.v1alpha1.OTPResponse
+	1,  // 31: api.v1alpha1.QRCode.contact:output_type -> api.v1alpha1.Response
+	1,  // 32: api.v1alpha1.QRCode.vcard:output_type -> api.v1alpha1.Response
+	1,  // 33: api.v1alpha1.QRCode.event:output_type -> api.v1alpha1.Response
+	26, // [26:34] is the sub-list for method output_type
+	18, // [18:26] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_v1alpha1_proto_init() }
@@ -269,6 +2618,254 @@ func file_v1alpha1_proto_init() {
 				return nil
 			}
 		}
+		file_v1alpha1_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TLV); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha1_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MerchantAccount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha1_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EMVCoAdditionalData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha1_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EMVCoLanguage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha1_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EMVCoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha1_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BitcoinPayment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha1_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EthereumPayment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha1_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CryptoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha1_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OTPRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha1_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OTPResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha1_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Address); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha1_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Card); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha1_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CardImage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha1_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SocialProfile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha1_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContactRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha1_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VCardRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha1_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Attendee); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha1_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Recurrence); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha1_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Event); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha1_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EventRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_v1alpha1_proto_msgTypes[9].OneofWrappers = []interface{}{
+		(*CryptoRequest_Bitcoin)(nil),
+		(*CryptoRequest_Ethereum)(nil),
+	}
+	file_v1alpha1_proto_msgTypes[17].OneofWrappers = []interface{}{
+		(*VCardRequest_Vcard)(nil),
+		(*VCardRequest_Jcard)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -276,7 +2873,7 @@ func file_v1alpha1_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_v1alpha1_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
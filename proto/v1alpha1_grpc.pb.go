@@ -23,6 +23,12 @@ const _ = grpc.SupportPackageIsVersion7
 const (
 	QRCode_Version_FullMethodName  = "/api.v1alpha1.QRCode/version"
 	QRCode_Generate_FullMethodName = "/api.v1alpha1.QRCode/generate"
+	QRCode_Emvco_FullMethodName    = "/api.v1alpha1.QRCode/emvco"
+	QRCode_Crypto_FullMethodName   = "/api.v1alpha1.QRCode/crypto"
+	QRCode_Otp_FullMethodName      = "/api.v1alpha1.QRCode/otp"
+	QRCode_Contact_FullMethodName  = "/api.v1alpha1.QRCode/contact"
+	QRCode_Vcard_FullMethodName    = "/api.v1alpha1.QRCode/vcard"
+	QRCode_Event_FullMethodName    = "/api.v1alpha1.QRCode/event"
 )
 
 // QRCodeClient is the client API for QRCode service.
@@ -31,6 +37,12 @@ const (
 type QRCodeClient interface {
 	Version(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*wrapperspb.StringValue, error)
 	Generate(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Response, error)
+	Emvco(ctx context.Context, in *EMVCoRequest, opts ...grpc.CallOption) (*Response, error)
+	Crypto(ctx context.Context, in *CryptoRequest, opts ...grpc.CallOption) (*Response, error)
+	Otp(ctx context.Context, in *OTPRequest, opts ...grpc.CallOption) (*OTPResponse, error)
+	Contact(ctx context.Context, in *ContactRequest, opts ...grpc.CallOption) (*Response, error)
+	Vcard(ctx context.Context, in *VCardRequest, opts ...grpc.CallOption) (*Response, error)
+	Event(ctx context.Context, in *EventRequest, opts ...grpc.CallOption) (*Response, error)
 }
 
 type qRCodeClient struct {
@@ -59,12 +71,72 @@ func (c *qRCodeClient) Generate(ctx context.Context, in *Request, opts ...grpc.C
 	return out, nil
 }
 
+func (c *qRCodeClient) Emvco(ctx context.Context, in *EMVCoRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, QRCode_Emvco_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qRCodeClient) Crypto(ctx context.Context, in *CryptoRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, QRCode_Crypto_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qRCodeClient) Otp(ctx context.Context, in *OTPRequest, opts ...grpc.CallOption) (*OTPResponse, error) {
+	out := new(OTPResponse)
+	err := c.cc.Invoke(ctx, QRCode_Otp_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qRCodeClient) Contact(ctx context.Context, in *ContactRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, QRCode_Contact_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qRCodeClient) Vcard(ctx context.Context, in *VCardRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, QRCode_Vcard_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qRCodeClient) Event(ctx context.Context, in *EventRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, QRCode_Event_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // QRCodeServer is the server API for QRCode service.
 // All implementations must embed UnimplementedQRCodeServer
 // for forward compatibility
 type QRCodeServer interface {
 	Version(context.Context, *emptypb.Empty) (*wrapperspb.StringValue, error)
 	Generate(context.Context, *Request) (*Response, error)
+	Emvco(context.Context, *EMVCoRequest) (*Response, error)
+	Crypto(context.Context, *CryptoRequest) (*Response, error)
+	Otp(context.Context, *OTPRequest) (*OTPResponse, error)
+	Contact(context.Context, *ContactRequest) (*Response, error)
+	Vcard(context.Context, *VCardRequest) (*Response, error)
+	Event(context.Context, *EventRequest) (*Response, error)
 	mustEmbedUnimplementedQRCodeServer()
 }
 
@@ -78,6 +150,24 @@ func (UnimplementedQRCodeServer) Version(context.Context, *emptypb.Empty) (*wrap
 func (UnimplementedQRCodeServer) Generate(context.Context, *Request) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Generate not implemented")
 }
+func (UnimplementedQRCodeServer) Emvco(context.Context, *EMVCoRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Emvco not implemented")
+}
+func (UnimplementedQRCodeServer) Crypto(context.Context, *CryptoRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Crypto not implemented")
+}
+func (UnimplementedQRCodeServer) Otp(context.Context, *OTPRequest) (*OTPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Otp not implemented")
+}
+func (UnimplementedQRCodeServer) Contact(context.Context, *ContactRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Contact not implemented")
+}
+func (UnimplementedQRCodeServer) Vcard(context.Context, *VCardRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Vcard not implemented")
+}
+func (UnimplementedQRCodeServer) Event(context.Context, *EventRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Event not implemented")
+}
 func (UnimplementedQRCodeServer) mustEmbedUnimplementedQRCodeServer() {}
 
 // UnsafeQRCodeServer may be embedded to opt out of forward compatibility for this service.
@@ -127,6 +217,114 @@ func _QRCode_Generate_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QRCode_Emvco_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EMVCoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QRCodeServer).Emvco(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QRCode_Emvco_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QRCodeServer).Emvco(ctx, req.(*EMVCoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QRCode_Crypto_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CryptoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QRCodeServer).Crypto(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QRCode_Crypto_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QRCodeServer).Crypto(ctx, req.(*CryptoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QRCode_Otp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OTPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QRCodeServer).Otp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QRCode_Otp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QRCodeServer).Otp(ctx, req.(*OTPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QRCode_Contact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QRCodeServer).Contact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QRCode_Contact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QRCodeServer).Contact(ctx, req.(*ContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QRCode_Vcard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QRCodeServer).Vcard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QRCode_Vcard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QRCodeServer).Vcard(ctx, req.(*VCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QRCode_Event_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QRCodeServer).Event(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QRCode_Event_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QRCodeServer).Event(ctx, req.(*EventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // QRCode_ServiceDesc is the grpc.ServiceDesc for QRCode service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -142,6 +340,30 @@ var QRCode_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "generate",
 			Handler:    _QRCode_Generate_Handler,
 		},
+		{
+			MethodName: "emvco",
+			Handler:    _QRCode_Emvco_Handler,
+		},
+		{
+			MethodName: "crypto",
+			Handler:    _QRCode_Crypto_Handler,
+		},
+		{
+			MethodName: "otp",
+			Handler:    _QRCode_Otp_Handler,
+		},
+		{
+			MethodName: "contact",
+			Handler:    _QRCode_Contact_Handler,
+		},
+		{
+			MethodName: "vcard",
+			Handler:    _QRCode_Vcard_Handler,
+		},
+		{
+			MethodName: "event",
+			Handler:    _QRCode_Event_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "v1alpha1.proto",